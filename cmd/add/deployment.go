@@ -135,19 +135,13 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 		newDeployment.Namespace = &cmd.Namespace
 	}
 
-	// Restore vars in config
-	clonedConfig, err := configutil.RestoreVars(config)
-	if err != nil {
-		log.Fatalf("Error restoring vars: %v", err)
-	}
-
 	// Add image config if necessary
 	if newImage != nil {
 		imageAlreadyExists := false
 
 		// First check if image already exists in another configuration
-		if clonedConfig.Images != nil {
-			for _, imageConfig := range *clonedConfig.Images {
+		if config.Images != nil {
+			for _, imageConfig := range *config.Images {
 				if *imageConfig.Image == *newImage.Image {
 					imageAlreadyExists = true
 					break
@@ -161,9 +155,9 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 			imageName := deploymentName
 
 			// Check if image name exits
-			if clonedConfig.Images != nil {
+			if config.Images != nil {
 				for i := 0; true; i++ {
-					if _, ok := (*clonedConfig.Images)[imageName]; ok {
+					if _, ok := (*config.Images)[imageName]; ok {
 						if i == 0 {
 							imageName = imageName + "-" + strconv.Itoa(i)
 						} else {
@@ -176,22 +170,22 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 					break
 				}
 			} else {
-				clonedConfig.Images = &map[string]*latest.ImageConfig{}
+				config.Images = &map[string]*latest.ImageConfig{}
 			}
 
-			(*clonedConfig.Images)[imageName] = newImage
+			(*config.Images)[imageName] = newImage
 		}
 	}
 
 	// Prepend deployment
-	if clonedConfig.Deployments == nil {
-		clonedConfig.Deployments = &[]*latest.DeploymentConfig{}
+	if config.Deployments == nil {
+		config.Deployments = &[]*latest.DeploymentConfig{}
 	}
 
-	(*clonedConfig.Deployments) = append([]*latest.DeploymentConfig{newDeployment}, (*clonedConfig.Deployments)...)
+	(*config.Deployments) = append([]*latest.DeploymentConfig{newDeployment}, (*config.Deployments)...)
 
 	// Save config
-	err = configutil.SaveConfig(clonedConfig)
+	err = configutil.SaveLoadedConfig()
 	if err != nil {
 		log.Fatalf("Couldn't save config file: %s", err.Error())
 	}
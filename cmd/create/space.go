@@ -202,11 +202,14 @@ func getCluster(p *cloud.Provider) (*cloud.Cluster, error) {
 		}
 
 		// Choose cluster
-		chosenCluster := survey.Question(&survey.QuestionOptions{
+		chosenCluster, err := survey.Question(&survey.QuestionOptions{
 			Question:     "Which cluster should the space created in?",
 			DefaultValue: clusterNames[0],
 			Options:      clusterNames,
 		})
+		if err != nil {
+			return nil, err
+		}
 		if chosenCluster != DevSpaceCloudHostedCluster {
 			for _, cluster := range connectedClusters {
 				if cluster.Name == chosenCluster {
@@ -234,11 +237,14 @@ func getCluster(p *cloud.Provider) (*cloud.Cluster, error) {
 	}
 
 	// Choose cluster
-	chosenCluster := survey.Question(&survey.QuestionOptions{
+	chosenCluster, err := survey.Question(&survey.QuestionOptions{
 		Question:     "Which hosted DevSpace cluster should the space created in?",
 		DefaultValue: clusterNames[0],
 		Options:      clusterNames,
 	})
+	if err != nil {
+		return nil, err
+	}
 	for _, cluster := range devSpaceClusters {
 		if cluster.Name == chosenCluster {
 			return cluster, nil
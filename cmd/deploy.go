@@ -29,6 +29,8 @@ type DeployCmd struct {
 	ForceBuild        bool
 	SkipBuild         bool
 	BuildSequential   bool
+	DeployConcurrency int
+	Prune             bool
 	ForceDeploy       bool
 	Deployments       string
 	ForceDependencies bool
@@ -72,6 +74,8 @@ devspace deploy --kube-context=deploy-context
 	deployCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to (re-)build every image")
 	deployCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips building of images")
 	deployCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
+	deployCmd.Flags().IntVar(&cmd.DeployConcurrency, "deploy-concurrency", 1, "Maximum number of independent deployments that are deployed in parallel")
+	deployCmd.Flags().BoolVar(&cmd.Prune, "prune", false, "Deletes objects that were deployed before, but are not part of the deployments anymore (after confirmation)")
 	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
 	deployCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", false, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 	deployCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
@@ -150,7 +154,7 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 	// Build images
 	builtImages := make(map[string]string)
 	if cmd.SkipBuild == false {
-		builtImages, err = build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, false, cmd.ForceBuild, cmd.BuildSequential, log.GetInstance())
+		builtImages, err = build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, false, cmd.ForceBuild, cmd.BuildSequential, nil, log.GetInstance())
 		if err != nil {
 			if strings.Index(err.Error(), "no space left on device") != -1 {
 				err = fmt.Errorf("%v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -178,7 +182,7 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Deploy all defined deployments
-	err = deploy.All(config, generatedConfig.GetActive(), client, false, cmd.ForceDeploy, builtImages, deployments, log.GetInstance())
+	err = deploy.All(config, generatedConfig.GetActive(), client, false, cmd.ForceDeploy, cmd.DeployConcurrency, cmd.Prune, builtImages, deployments, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
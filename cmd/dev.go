@@ -3,11 +3,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/bmatcuk/doublestar"
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
@@ -16,6 +20,7 @@ import (
 	"github.com/mgutz/ansi"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	v1 "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -37,6 +42,8 @@ type DevCmd struct {
 	ForceBuild        bool
 	SkipBuild         bool
 	BuildSequential   bool
+	DeployConcurrency int
+	Prune             bool
 	ForceDeploy       bool
 	Deployments       string
 	ForceDependencies bool
@@ -80,6 +87,8 @@ Starts your project in development mode:
 	devCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
 	devCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips building of images")
 	devCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
+	devCmd.Flags().IntVar(&cmd.DeployConcurrency, "deploy-concurrency", 1, "Maximum number of independent deployments that are deployed in parallel")
+	devCmd.Flags().BoolVar(&cmd.Prune, "prune", false, "Deletes objects that were deployed before, but are not part of the deployments anymore (after confirmation)")
 
 	devCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to deploy every deployment")
 	devCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
@@ -179,121 +188,233 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) {
 
 func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, args []string) (int, error) {
 	if cmd.SkipPipeline == false {
-		// Dependencies
-		err := dependency.DeployAll(config, generatedConfig, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, log.GetInstance())
+		err := cmd.runPipeline(config, generatedConfig, client, true, nil, nil)
 		if err != nil {
-			return 0, fmt.Errorf("Error deploying dependencies: %v", err)
+			return 0, err
 		}
+	}
 
-		// Build image if necessary
-		builtImages := make(map[string]string)
-		if cmd.SkipBuild == false {
-			builtImages, err = build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, log.GetInstance())
-			if err != nil {
-				if strings.Index(err.Error(), "no space left on device") != -1 {
-					return 0, fmt.Errorf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
-				}
+	if cmd.ExitAfterDeploy {
+		return 0, nil
+	}
 
-				return 0, fmt.Errorf("Error building image: %v", err)
-			}
+	// Port forwarding and sync keep running across reloads and are only restarted if their pods were replaced
+	devServices := &devServices{}
+	defer devServices.stop()
 
-			// Save config if an image was built
-			if len(builtImages) > 0 {
-				err := generated.SaveConfig(generatedConfig)
-				if err != nil {
-					return 0, fmt.Errorf("Error saving generated config: %v", err)
-				}
-			}
+	for {
+		exitCode, err := cmd.startServices(config, client, devServices, args, log.GetInstance())
+		reload, ok := err.(*reloadError)
+		if ok == false {
+			return exitCode, err
 		}
 
-		// Deploy all defined deployments
-		if config.Deployments != nil {
-			// What deployments should be deployed
-			deployments := []string{}
-			if cmd.Deployments != "" {
-				deployments = strings.Split(cmd.Deployments, ",")
-				for index := range deployments {
-					deployments[index] = strings.TrimSpace(deployments[index])
-				}
-			}
+		images, deployments, ok := GetAffected(config, reload.changedFiles)
+		if ok == false {
+			log.Info("Rebuilding and redeploying everything")
 
-			// Deploy all
-			err = deploy.All(config, generatedConfig.GetActive(), client, true, cmd.ForceDeploy, builtImages, deployments, log.GetInstance())
-			if err != nil {
-				return 0, fmt.Errorf("Error deploying: %v", err)
-			}
+			// Get the config
+			devServices.stop()
+			config = cmd.loadConfig(generatedConfig)
 
-			// Save Config
-			err = generated.SaveConfig(generatedConfig)
+			// Trigger rebuild & redeploy
+			err = cmd.runPipeline(config, generatedConfig, client, true, nil, nil)
 			if err != nil {
-				return 0, fmt.Errorf("Error saving generated config: %v", err)
+				return 0, err
 			}
+
+			continue
+		}
+
+		if len(images) > 0 {
+			log.Infof("Rebuilding images %s", strings.Join(images, ", "))
+
+			// Deployments that use the rebuilt images are redeployed as well
+			deployments = nil
+		} else {
+			log.Infof("Redeploying %s", strings.Join(deployments, ", "))
+		}
+
+		err = cmd.runPipeline(config, generatedConfig, client, false, images, deployments)
+		if err != nil {
+			return 0, err
+		}
+
+		err = devServices.restartReplaced(config, client, cmd.VerboseSync, log.GetInstance())
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// runPipeline builds the images and deploys the deployments. If images or deployments are not empty, only the given
+// images are built or the given deployments are deployed
+func (cmd *DevCmd) runPipeline(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, withDependencies bool, images []string, deployments []string) error {
+	// Dependencies
+	if withDependencies {
+		err := dependency.DeployAll(config, generatedConfig, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, log.GetInstance())
+		if err != nil {
+			return fmt.Errorf("Error deploying dependencies: %v", err)
 		}
 	}
 
-	// Start services
-	exitCode := 0
-	if cmd.ExitAfterDeploy == false {
+	// Build image if necessary
+	builtImages := make(map[string]string)
+	if cmd.SkipBuild == false && (withDependencies || len(images) > 0) {
 		var err error
 
-		// Start services
-		exitCode, err = cmd.startServices(config, client, args, log.GetInstance())
+		builtImages, err = build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, images, log.GetInstance())
 		if err != nil {
-			// Check if we should reload
-			if _, ok := err.(*reloadError); ok {
-				// Get the config
-				config := cmd.loadConfig(generatedConfig)
+			if strings.Index(err.Error(), "no space left on device") != -1 {
+				return fmt.Errorf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
+			}
 
-				// Trigger rebuild & redeploy
-				return cmd.buildAndDeploy(config, generatedConfig, client, args)
+			return fmt.Errorf("Error building image: %v", err)
+		}
+
+		// Save config if an image was built
+		if len(builtImages) > 0 {
+			err := generated.SaveConfig(generatedConfig)
+			if err != nil {
+				return fmt.Errorf("Error saving generated config: %v", err)
 			}
+		}
+	}
 
-			return 0, err
+	// Deploy all defined deployments
+	if config.Deployments != nil {
+		// What deployments should be deployed
+		if cmd.Deployments != "" {
+			deployments = selectDeployments(strings.Split(cmd.Deployments, ","), deployments)
+			if len(deployments) == 0 {
+				return nil
+			}
+		}
+
+		// Deploy all
+		err := deploy.All(config, generatedConfig.GetActive(), client, true, cmd.ForceDeploy, cmd.DeployConcurrency, cmd.Prune, builtImages, deployments, log.GetInstance())
+		if err != nil {
+			return fmt.Errorf("Error deploying: %v", err)
+		}
+
+		// Save Config
+		err = generated.SaveConfig(generatedConfig)
+		if err != nil {
+			return fmt.Errorf("Error saving generated config: %v", err)
 		}
 	}
 
-	return exitCode, nil
+	return nil
 }
 
-func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interface, args []string, log log.Logger) (int, error) {
-	if cmd.Portforwarding {
-		portForwarder, err := services.StartPortForwarding(config, client, log)
-		if err != nil {
-			return 0, fmt.Errorf("Unable to start portforwarding: %v", err)
+// selectDeployments returns the deployments of the --deployments flag that are affected by a change. All deployments
+// of the flag are affected if affected is empty
+func selectDeployments(flagDeployments []string, affected []string) []string {
+	selected := []string{}
+	for _, deployment := range flagDeployments {
+		deployment = strings.TrimSpace(deployment)
+		if len(affected) == 0 {
+			selected = append(selected, deployment)
+			continue
 		}
 
-		defer func() {
-			for _, v := range portForwarder {
-				v.Close()
+		for _, affectedDeployment := range affected {
+			if affectedDeployment == deployment {
+				selected = append(selected, deployment)
+				break
 			}
-		}()
+		}
 	}
 
-	if cmd.Sync {
-		syncConfigs, err := services.StartSync(config, cmd.VerboseSync, log)
-		if err != nil {
-			return 0, fmt.Errorf("Unable to start sync: %v", err)
+	return selected
+}
+
+// devServices are the port forwardings and syncs that were started by devspace dev
+type devServices struct {
+	started         bool
+	portForwardings []*services.PortForwarding
+	syncSessions    []*services.SyncSession
+
+	// stopPortForwardingWatch stops checking the port forwardings
+	stopPortForwardingWatch chan struct{}
+}
+
+func (d *devServices) stop() {
+	if d.stopPortForwardingWatch != nil {
+		close(d.stopPortForwardingWatch)
+	}
+	for _, portForwarding := range d.portForwardings {
+		portForwarding.Close()
+	}
+	for _, syncSession := range d.syncSessions {
+		syncSession.Stop()
+	}
+
+	d.started = false
+	d.portForwardings = nil
+	d.syncSessions = nil
+	d.stopPortForwardingWatch = nil
+}
+
+// restartReplaced reconnects the port forwardings and syncs whose pods were replaced by a redeployment
+func (d *devServices) restartReplaced(config *latest.Config, client kubernetes.Interface, verboseSync bool, log log.Logger) error {
+	err := services.RestartPortForwarding(config, client, d.portForwardings, log)
+	if err != nil {
+		return fmt.Errorf("Unable to restart portforwarding: %v", err)
+	}
+
+	err = services.RestartSync(config, d.syncSessions, verboseSync, log)
+	if err != nil {
+		return fmt.Errorf("Unable to restart sync: %v", err)
+	}
+
+	return nil
+}
+
+func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interface, devServices *devServices, args []string, log log.Logger) (int, error) {
+	if devServices.started == false {
+		devServices.started = true
+
+		if cmd.Portforwarding {
+			portForwardings, err := services.StartPortForwarding(config, client, log)
+			if err != nil {
+				return 0, fmt.Errorf("Unable to start portforwarding: %v", err)
+			}
+
+			devServices.portForwardings = portForwardings
+			devServices.stopPortForwardingWatch = make(chan struct{})
+
+			// Port forwardings are moved to another ready pod if their pod dies
+			go services.WatchPortForwarding(config, client, portForwardings, devServices.stopPortForwardingWatch, log)
 		}
 
-		defer func() {
-			for _, v := range syncConfigs {
-				v.Stop(nil)
+		if cmd.Sync {
+			syncSessions, err := services.StartSync(config, cmd.VerboseSync, log)
+			if err != nil {
+				return 0, fmt.Errorf("Unable to start sync: %v", err)
 			}
-		}()
+
+			devServices.syncSessions = syncSessions
+		}
 	}
 
-	exitChan := make(chan error)
+	exitChan := make(chan error, 1)
 	autoReloadPaths := GetPaths(config)
 
 	// Start watcher if we have at least one auto reload path and if we should not skip the pipeline
 	if cmd.SkipPipeline == false && len(autoReloadPaths) > 0 {
-		var once sync.Once
+		var (
+			once     sync.Once
+			debounce = time.Second * 2
+		)
+		if config.Dev.AutoReload != nil && config.Dev.AutoReload.Debounce != nil {
+			debounce = time.Millisecond * time.Duration(*config.Dev.AutoReload.Debounce)
+		}
+
 		watcher, err := watch.New(autoReloadPaths, func(changed []string, deleted []string) error {
 			once.Do(func() {
-				log.Info("Change detected, will reload in 2 seconds")
-				time.Sleep(time.Second * 2)
-
-				exitChan <- &reloadError{}
+				log.Info("Change detected, will reload")
+				exitChan <- &reloadError{changedFiles: append(changed, deleted...)}
 			})
 
 			return nil
@@ -302,6 +423,10 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 			return 0, err
 		}
 
+		// Changes are reloaded together once no further change happened for the debounce time
+		watcher.Debounce = debounce
+		watcher.BatchWindow = debounce * 5
+
 		watcher.Start()
 		defer watcher.Stop()
 	}
@@ -321,7 +446,15 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 		params.Namespace = &cmd.Namespace
 	}
 
-	if cmd.Terminal && (config.Dev == nil || config.Dev.Terminal == nil || config.Dev.Terminal.Disabled == nil || *config.Dev.Terminal.Disabled == false) {
+	terminal := cmd.Terminal && (config.Dev == nil || config.Dev.Terminal == nil || config.Dev.Terminal.Disabled == nil || *config.Dev.Terminal.Disabled == false)
+
+	// Stream the logs of all selected containers instead of opening a single terminal
+	if config.Dev != nil && config.Dev.Logs != nil && (config.Dev.Logs.Disabled == nil || *config.Dev.Logs.Disabled == false) {
+		log.Done("Services started (Press Ctrl+C to abort port-forwarding and sync)")
+		return 0, services.StartMultiplexedLogs(config, client, terminal, exitChan, log)
+	}
+
+	if terminal {
 		return services.StartTerminal(config, client, params, args, exitChan, log)
 	}
 
@@ -372,6 +505,13 @@ func GetPaths(config *latest.Config) []string {
 							for _, manifestPath := range *deployConf.Kubectl.Manifests {
 								paths = append(paths, *manifestPath)
 							}
+						} else if deployConf.Template != nil && deployConf.Template.Path != nil {
+							paths = append(paths, strings.TrimSuffix(*deployConf.Template.Path, "/")+"/**")
+							if deployConf.Template.ValuesFiles != nil {
+								for _, valuesFile := range *deployConf.Template.ValuesFiles {
+									paths = append(paths, *valuesFile)
+								}
+							}
 						}
 					}
 				}
@@ -405,7 +545,121 @@ func GetPaths(config *latest.Config) []string {
 	return paths
 }
 
+// GetAffected maps the changed files to the images and deployments that have to be rebuilt and redeployed. It returns false
+// if a file does not belong to an image or a deployment, e.g. a config file or an additional auto reload path, and
+// everything has to be reloaded
+func GetAffected(config *latest.Config, changedFiles []string) ([]string, []string, bool) {
+	images := []string{}
+	deployments := []string{}
+
+	for _, file := range changedFiles {
+		switch filepath.Clean(file) {
+		case constants.DefaultConfigPath, constants.DefaultConfigsPath, constants.DefaultVarsPath, constants.DefaultLocalConfigPath:
+			return nil, nil, false
+		}
+
+		affected := false
+		if config.Images != nil {
+			for imageConfigName, imageConf := range *config.Images {
+				if imageConf.Build != nil && imageConf.Build.Disabled != nil && *imageConf.Build.Disabled == true {
+					continue
+				}
+
+				dockerfilePath, contextPath := helper.GetDockerfileAndContext(config, imageConfigName, imageConf, true)
+				if isSamePath(file, dockerfilePath) || isInPath(file, contextPath) {
+					images = appendUnique(images, imageConfigName)
+					affected = true
+				}
+			}
+		}
+
+		if config.Deployments != nil {
+			for _, deployConf := range *config.Deployments {
+				if deploymentUsesFile(deployConf, file) {
+					deployments = appendUnique(deployments, *deployConf.Name)
+					affected = true
+				}
+			}
+		}
+
+		if affected == false {
+			return nil, nil, false
+		}
+	}
+
+	sort.Strings(images)
+	return images, deployments, true
+}
+
+// deploymentUsesFile checks if the file is part of the chart, the manifests or the templates of the deployment
+func deploymentUsesFile(deployConf *latest.DeploymentConfig, file string) bool {
+	paths := []*string{}
+	if deployConf.Helm != nil {
+		if deployConf.Helm.Chart != nil && deployConf.Helm.Chart.Name != nil {
+			paths = append(paths, deployConf.Helm.Chart.Name)
+		}
+		if deployConf.Helm.ValuesFiles != nil {
+			paths = append(paths, *deployConf.Helm.ValuesFiles...)
+		}
+	} else if deployConf.Kubectl != nil && deployConf.Kubectl.Manifests != nil {
+		paths = append(paths, *deployConf.Kubectl.Manifests...)
+	} else if deployConf.Template != nil {
+		if deployConf.Template.Path != nil {
+			paths = append(paths, deployConf.Template.Path)
+		}
+		if deployConf.Template.ValuesFiles != nil {
+			paths = append(paths, *deployConf.Template.ValuesFiles...)
+		}
+	}
+
+	for _, path := range paths {
+		// Paths can be globs like kube/* or directories like ./chart
+		match, err := doublestar.Match(filepath.ToSlash(filepath.Clean(*path)), filepath.ToSlash(filepath.Clean(file)))
+		if (err == nil && match) || isInPath(file, *path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSamePath(file, path string) bool {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	absPath, err := filepath.Abs(path)
+	return err == nil && absFile == absPath
+}
+
+func isInPath(file, dir string) bool {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(absDir, absFile)
+	return err == nil && rel != ".." && strings.HasPrefix(rel, ".."+string(filepath.Separator)) == false
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, item := range list {
+		if item == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
+
 type reloadError struct {
+	changedFiles []string
 }
 
 func (r *reloadError) Error() string {
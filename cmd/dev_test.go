@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+func TestGetAffected(t *testing.T) {
+	config := &latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"backend":  {Image: ptr.String("backend"), Context: ptr.String("./backend"), Dockerfile: ptr.String("./backend/Dockerfile")},
+			"frontend": {Image: ptr.String("frontend"), Context: ptr.String("./frontend"), Dockerfile: ptr.String("./frontend/Dockerfile")},
+		},
+		Deployments: &[]*latest.DeploymentConfig{
+			{Name: ptr.String("chart"), Helm: &latest.HelmConfig{Chart: &latest.ChartConfig{Name: ptr.String("./chart")}}},
+			{Name: ptr.String("manifests"), Kubectl: &latest.KubectlConfig{Manifests: &[]*string{ptr.String("kube/*.yaml")}}},
+		},
+	}
+
+	images, deployments, ok := GetAffected(config, []string{"frontend/Dockerfile", "backend/package.json"})
+	assert.Equal(t, ok, true)
+	assert.DeepEqual(t, images, []string{"backend", "frontend"})
+	assert.DeepEqual(t, deployments, []string{})
+
+	images, deployments, ok = GetAffected(config, []string{"chart/templates/deployment.yaml", "kube/service.yaml"})
+	assert.Equal(t, ok, true)
+	assert.DeepEqual(t, images, []string{})
+	assert.DeepEqual(t, deployments, []string{"chart", "manifests"})
+
+	// Files that do not belong to an image or a deployment reload everything
+	_, _, ok = GetAffected(config, []string{"chart/values.yaml", "README.md"})
+	assert.Equal(t, ok, false)
+
+	_, _, ok = GetAffected(config, []string{"devspace.yaml"})
+	assert.Equal(t, ok, false)
+}
@@ -130,7 +130,7 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 
 	_, err = os.Stat(cmd.Dockerfile)
 	if err != nil {
-		selectedOption = survey.Question(&survey.QuestionOptions{
+		selectedOption, err = survey.Question(&survey.QuestionOptions{
 			Question:     "This project does not have a Dockerfile. What do you want to do?",
 			DefaultValue: createDockerfileOption,
 			Options: []string{
@@ -141,8 +141,11 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 				useExistingImageOption,
 			},
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	} else {
-		selectedOption = survey.Question(&survey.QuestionOptions{
+		selectedOption, err = survey.Question(&survey.QuestionOptions{
 			Question:     "How do you want to initialize this project?",
 			DefaultValue: useExistingDockerfileOption,
 			Options: []string{
@@ -153,6 +156,9 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 				useExistingImageOption,
 			},
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	if selectedOption == createDockerfileOption {
@@ -162,14 +168,21 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 			log.Fatalf("Error containerizing application: %v", err)
 		}
 	} else if selectedOption == enterDockerfileOption {
-		cmd.Dockerfile = survey.Question(&survey.QuestionOptions{
-			Question: "Please enter a path to your Dockerfile (e.g. ./MyDockerfile)",
+		cmd.Dockerfile, err = survey.Question(&survey.QuestionOptions{
+			Question:           "Please enter a path to your Dockerfile (e.g. ./MyDockerfile)",
+			NonInteractiveHint: "Please specify the Dockerfile with --dockerfile",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	} else if selectedOption == enterManifestsOption {
 		addFromDockerfile = false
-		manifests := survey.Question(&survey.QuestionOptions{
+		manifests, err := survey.Question(&survey.QuestionOptions{
 			Question: "Please enter Kubernetes manifests to deploy (glob pattern are allowed, comma separated, e.g. 'manifests/**' or 'kube/pod.yaml')",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		newDeployment, err = configure.GetKubectlDeployment(deploymentName, manifests)
 		if err != nil {
@@ -177,9 +190,12 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 		}
 	} else if selectedOption == enterHelmChartOption {
 		addFromDockerfile = false
-		chartName := survey.Question(&survey.QuestionOptions{
+		chartName, err := survey.Question(&survey.QuestionOptions{
 			Question: "Please enter the path to a helm chart to deploy (e.g. ./chart)",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		newDeployment, err = configure.GetHelmDeployment(deploymentName, chartName, "", "")
 		if err != nil {
@@ -187,9 +203,12 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 		}
 	} else if selectedOption == useExistingImageOption {
 		addFromDockerfile = false
-		existingImageName := survey.Question(&survey.QuestionOptions{
+		existingImageName, err := survey.Question(&survey.QuestionOptions{
 			Question: "Please enter a docker image to deploy (e.g. gcr.io/myuser/myrepo or dockeruser/repo:0.1 or mysql:latest)",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		newImage, newDeployment, err = configure.GetImageComponentDeployment(deploymentName, existingImageName)
 		if err != nil {
@@ -285,11 +304,14 @@ func (cmd *InitCmd) checkIfDevSpaceCloud() {
 			options = []string{useDevSpaceCloud, useCurrentContext}
 		}
 
-		selectedOption := survey.Question(&survey.QuestionOptions{
+		selectedOption, err := survey.Question(&survey.QuestionOptions{
 			Question:     "Which Kubernetes cluster do you want to use?",
 			DefaultValue: useDevSpaceCloud,
 			Options:      options,
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		if selectedOption == useDevSpaceCloud {
 			cmd.useCloud = true
@@ -317,10 +339,16 @@ func (cmd *InitCmd) checkIfDevSpaceCloud() {
 				options = append(options, provider.Name)
 			}
 
-			cmd.providerName = ptr.String(survey.Question(&survey.QuestionOptions{
-				Question: "Select a cloud provider",
-				Options:  options,
-			}))
+			providerName, err := survey.Question(&survey.QuestionOptions{
+				Question:           "Select a cloud provider",
+				Options:            options,
+				NonInteractiveHint: "Please select a default provider with 'devspace use provider [NAME]'",
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			cmd.providerName = &providerName
 		}
 
 		// Ensure user is logged in
@@ -369,23 +397,30 @@ func (cmd *InitCmd) connectCluster() {
 		}
 	}
 
-	connectCluster := false
+	selectedCluster := ""
 	if len(connectedClusters) == 0 {
-		connectCluster = survey.Question(&survey.QuestionOptions{
-			Question: "You do not have any clusters connected. What do you want to do?",
-			Options:  []string{demoClusterOption, connectClusterOption},
-		}) == connectClusterOption
+		selectedCluster, err = survey.Question(&survey.QuestionOptions{
+			Question:     "You do not have any clusters connected. What do you want to do?",
+			DefaultValue: demoClusterOption,
+			Options:      []string{demoClusterOption, connectClusterOption},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	} else {
 		connectedClusters = append(connectedClusters, connectClusterOption)
 
-		connectCluster = survey.Question(&survey.QuestionOptions{
+		selectedCluster, err = survey.Question(&survey.QuestionOptions{
 			Question: "Which cluster do you want to use?",
 			Options:  connectedClusters,
-		}) == connectClusterOption
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// User selected connect cluster
-	if connectCluster {
+	if selectedCluster == connectClusterOption {
 		err = provider.ConnectCluster(&cloud.ConnectClusterOptions{
 			DeployAdmissionController: true,
 			DeployIngressController:   true,
@@ -419,10 +454,13 @@ func getDeploymentName() (string, error) {
 }
 
 func (cmd *InitCmd) configureCluster() {
-	namespace := survey.Question(&survey.QuestionOptions{
+	namespace, err := survey.Question(&survey.QuestionOptions{
 		Question:     "Which namespace should the app run in?",
 		DefaultValue: "default",
 	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	config := configutil.GetConfig()
 	config.Cluster.Namespace = &namespace
@@ -75,7 +75,7 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(cobraCmd *cobra.Command, args []
 			var deployClient deploy.Interface
 
 			// Delete kubectl engine
-			if deployConfig.Kubectl != nil {
+			if deployConfig.Kubectl != nil || deployConfig.Template != nil {
 				deployClient, err = deployKubectl.New(config, kubectl, deployConfig, log.GetInstance())
 				if err != nil {
 					log.Warnf("Unable to create kubectl deploy config for %s: %v", *deployConfig.Name, err)
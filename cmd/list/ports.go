@@ -2,8 +2,11 @@ package list
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
 )
@@ -15,12 +18,14 @@ func newPortsCmd() *cobra.Command {
 
 	portsCmd := &cobra.Command{
 		Use:   "ports",
-		Short: "Lists port forwarding configurations",
+		Short: "Lists port forwarding configurations and their status",
 		Long: `
 #######################################################
 ############### devspace list ports ###################
 #######################################################
-Lists the port forwarding configurations
+Lists the port forwarding configurations and, while
+devspace dev is running, the pod and the live status of
+each port forwarding
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -48,48 +53,84 @@ func (cmd *portsCmd) RunListPort(cobraCmd *cobra.Command, args []string) {
 		return
 	}
 
+	// The live status is only available while devspace dev is running
+	statusFile, err := services.LoadPortForwardingStatus(services.PortForwardingStatusPath)
+	if err != nil {
+		log.Warnf("Error loading port forwarding status: %v", err)
+	}
+
 	headerColumnNames := []string{
-		"Selector",
-		"LabelSelector",
+		"Target",
 		"Ports (Local:Remote)",
+		"Reverse Ports (Remote:Local)",
+		"Pod",
+		"Status",
 	}
 
 	portForwards := make([][]string, 0, len(*config.Dev.Ports))
+	for index, value := range *config.Dev.Ports {
+		target := services.GetPortForwardingTarget(value)
 
-	// Transform values into string arrays
-	for _, value := range *config.Dev.Ports {
-		service := ""
-		selector := ""
-
-		if value.Selector != nil {
-			service = *value.Selector
-		} else {
-			for k, v := range *value.LabelSelector {
-				if len(selector) > 0 {
-					selector += ", "
-				}
+		portMappings := []string{}
+		if value.PortMappings != nil {
+			for _, v := range *value.PortMappings {
+				portMappings = append(portMappings, localPort(v)+":"+remotePort(v))
+			}
+		}
 
-				selector += k + "=" + *v
+		reversePortMappings := []string{}
+		if value.ReversePortMappings != nil {
+			for _, v := range *value.ReversePortMappings {
+				reversePortMappings = append(reversePortMappings, remotePort(v)+":"+localPort(v))
 			}
 		}
 
-		portMappings := ""
-		if value.PortMappings != nil {
-			for _, v := range *value.PortMappings {
-				if len(portMappings) > 0 {
-					portMappings += ", "
-				}
+		pod := ""
+		status := "Not running (start devspace dev)"
+		if statusFile != nil && index < len(statusFile.PortForwardings) && statusFile.PortForwardings[index].Target == target {
+			liveStatus := statusFile.PortForwardings[index]
 
-				portMappings += strconv.Itoa(*v.LocalPort) + ":" + strconv.Itoa(*v.RemotePort)
+			// The live ports contain the selected local ports of auto and ranges and the target ports of services
+			if len(liveStatus.Ports) > 0 {
+				portMappings = liveStatus.Ports
+			}
+			if len(liveStatus.ReversePorts) > 0 {
+				reversePortMappings = liveStatus.ReversePorts
+			}
+
+			pod = liveStatus.Pod
+			status = liveStatus.Status
+			if liveStatus.Error != "" {
+				status += ": " + liveStatus.Error
 			}
 		}
 
 		portForwards = append(portForwards, []string{
-			service,
-			selector,
-			portMappings,
+			target,
+			strings.Join(portMappings, ", "),
+			strings.Join(reversePortMappings, ", "),
+			pod,
+			status,
 		})
 	}
 
 	log.PrintTable(log.GetInstance(), headerColumnNames, portForwards)
 }
+
+func localPort(portMapping *latest.PortMapping) string {
+	if portMapping.LocalPortRange != nil {
+		return *portMapping.LocalPortRange
+	} else if portMapping.LocalPort != nil {
+		return strconv.Itoa(*portMapping.LocalPort)
+	}
+
+	return ""
+}
+
+func remotePort(portMapping *latest.PortMapping) string {
+	if portMapping.RemotePort != nil {
+		return strconv.Itoa(*portMapping.RemotePort)
+	}
+
+	return localPort(portMapping)
+}
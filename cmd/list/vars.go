@@ -52,8 +52,17 @@ func (cmd *configsCmd) RunListVars(cobraCmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
+	// Variables from other sources and secret variables are not saved in the generated config
+	vars := map[string]string{}
+	for name, value := range generatedConfig.GetActive().Vars {
+		vars[name] = value
+	}
+	for name, value := range configutil.ResolvedVars {
+		vars[name] = value
+	}
+
 	// No variable found
-	if generatedConfig.GetActive().Vars == nil || len(generatedConfig.GetActive().Vars) == 0 {
+	if len(vars) == 0 {
 		log.Infof("No variable found for config %s", generatedConfig.ActiveConfig)
 		return
 	}
@@ -64,9 +73,13 @@ func (cmd *configsCmd) RunListVars(cobraCmd *cobra.Command, args []string) {
 		"Value",
 	}
 
-	varRow := make([][]string, 0, len(generatedConfig.GetActive().Vars))
+	varRow := make([][]string, 0, len(vars))
+
+	for name, value := range vars {
+		if configutil.SecretVars[name] {
+			value = "********"
+		}
 
-	for name, value := range generatedConfig.GetActive().Vars {
 		varRow = append(varRow, []string{
 			name,
 			fmt.Sprintf("%v", value),
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -9,7 +14,9 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
 // LogsCmd holds the logs cmd flags
@@ -19,9 +26,15 @@ type LogsCmd struct {
 	LabelSelector     string
 	Container         string
 	Pod               string
+	Deployment        string
+	All               bool
 	Pick              bool
 	Follow            bool
 	LastAmountOfLines int
+	Since             time.Duration
+	Timestamps        bool
+	Previous          bool
+	Filter            string
 }
 
 // NewLogsCmd creates a new login command
@@ -35,12 +48,17 @@ func NewLogsCmd() *cobra.Command {
 #######################################################
 #################### devspace logs ####################
 #######################################################
-Logs prints the last log of a pod container and attachs 
-to it
+Logs prints the last log of a pod container and follows
+it with --follow. With --deployment or --all the logs of
+all containers of the matched pods are printed together
 
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs -f --since=10m --filter="ERROR|WARN"
+devspace logs -f --deployment=api
+devspace logs -f --all --label-selector=app=worker
+devspace logs --previous
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -52,9 +70,15 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to print the logs of")
 	logsCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	logsCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
+	logsCmd.Flags().StringVar(&cmd.Deployment, "deployment", "", "Print the logs of all pods of this Kubernetes deployment")
+	logsCmd.Flags().BoolVar(&cmd.All, "all", false, "Print the logs of all pods matched by the selector or label selector instead of only the newest one")
 	logsCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod")
-	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
+	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Keep printing new logs and reconnect if the container restarts or the pod is replaced")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
+	logsCmd.Flags().DurationVar(&cmd.Since, "since", 0, "Only print logs newer than a relative duration like 5s, 2m or 3h")
+	logsCmd.Flags().BoolVar(&cmd.Timestamps, "timestamps", false, "Print the timestamp of each line")
+	logsCmd.Flags().BoolVar(&cmd.Previous, "previous", false, "Print the logs of the previous instance of the container, e.g. after it crashed")
+	logsCmd.Flags().StringVar(&cmd.Filter, "filter", "", "Only print lines that match this regular expression")
 
 	return logsCmd
 }
@@ -110,9 +134,79 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) {
 		params.Pick = &cmd.Pick
 	}
 
-	// Start terminal
-	err = services.StartLogs(config, kubectl, params, cmd.Follow, int64(cmd.LastAmountOfLines), log.GetInstance())
+	options := &services.LogOptions{
+		Follow:     cmd.Follow,
+		Tail:       ptr.Int64(int64(cmd.LastAmountOfLines)),
+		Since:      cmd.Since,
+		Timestamps: cmd.Timestamps,
+		Previous:   cmd.Previous,
+	}
+	if cmd.Filter != "" {
+		options.Filter, err = regexp.Compile(cmd.Filter)
+		if err != nil {
+			log.Fatalf("Invalid filter %s: %v", cmd.Filter, err)
+		}
+	}
+
+	// Print the logs of all matched pods
+	if cmd.Deployment != "" || cmd.All {
+		source, err := cmd.getLogSource(config, kubectl, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = services.StartAggregatedLogs(kubectl, []*services.LogSource{source}, options, log.GetInstance())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// Print the logs of a single container
+	err = services.StartLogs(config, kubectl, params, options, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// getLogSource returns the pods to print the logs of by deployment name or label selector
+func (cmd *LogsCmd) getLogSource(config *latest.Config, client kubernetes.Interface, params targetselector.CmdParameter) (*services.LogSource, error) {
+	selectorParameter := &targetselector.SelectorParameter{CmdParameter: params}
+	if config != nil && config.Dev != nil && config.Dev.Terminal != nil {
+		selectorParameter.ConfigParameter = targetselector.ConfigParameter{
+			Selector:      config.Dev.Terminal.Selector,
+			Namespace:     config.Dev.Terminal.Namespace,
+			LabelSelector: config.Dev.Terminal.LabelSelector,
+		}
+	}
+
+	namespace, err := selectorParameter.GetNamespace(config)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &services.LogSource{
+		Namespace:     namespace,
+		ContainerName: cmd.Container,
+	}
+
+	if cmd.Deployment != "" {
+		source.LabelSelector, err = kubectl.GetDeploymentLabelSelector(client, cmd.Deployment, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to get deployment %s: %v", cmd.Deployment, err)
+		}
+
+		return source, nil
+	}
+
+	labelSelector, err := selectorParameter.GetLabelSelector(config)
+	if err != nil {
+		return nil, err
+	} else if labelSelector == nil {
+		return nil, errors.New("Please specify a selector or label selector to print the logs of all matched pods")
+	}
+
+	source.LabelSelector = *labelSelector
+	return source, nil
+}
@@ -113,10 +113,14 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 	if len(domains) == 1 {
 		host = domains[0]
 	} else {
-		host = survey.Question(&survey.QuestionOptions{
-			Question: "Please select a domain to open",
-			Options:  domains,
+		host, err = survey.Question(&survey.QuestionOptions{
+			Question:     "Please select a domain to open",
+			DefaultValue: domains[0],
+			Options:      domains,
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// If there is no config make sure the current kubectl context is correct
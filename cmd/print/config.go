@@ -0,0 +1,205 @@
+package print
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// secretMask replaces values that contain secret variables
+const secretMask = "********"
+
+type configCmd struct {
+	Annotate bool
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &configCmd{}
+
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Prints the effective config",
+		Long: `
+#######################################################
+################ devspace print config ################
+#######################################################
+Prints the config that is used by devspace dev, deploy
+and build after replacing the variables and merging the
+overrides and devspace.local.yaml. Values that contain
+variables marked as secret are masked.
+
+With --annotate every value is annotated with the file,
+override, profile and variables it comes from. Profiles
+are applied in the same way as for all other commands
+with the global --profile flag.
+
+Examples:
+devspace print config
+devspace print config --annotate
+devspace print config --profile production --annotate
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunPrintConfig,
+	}
+
+	configCmd.Flags().BoolVar(&cmd.Annotate, "annotate", false, "Annotate every value with its source")
+
+	return configCmd
+}
+
+// RunPrintConfig executes the devspace print config command logic
+func (cmd *configCmd) RunPrintConfig(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config, sources, err := configutil.GetConfigWithSources(".", generatedConfig.ActiveConfig, generatedConfig, log.Discard)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Save the answered variables
+	err = generated.SaveConfig(generatedConfig)
+	if err != nil {
+		log.Fatalf("Couldn't save generated config: %v", err)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Unmarshal into a map slice to keep the order of the config fields
+	ordered := yaml.MapSlice{}
+	err = yaml.Unmarshal(out, &ordered)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cmd.Annotate == false {
+		sources = maskedSources(sources)
+	}
+
+	buf := &bytes.Buffer{}
+	writeValue(buf, ordered, "", 0, sources, cmd.Annotate)
+	log.WriteString(buf.String())
+}
+
+// maskedSources only keeps the sources of secret values, so that they are masked without annotating other values
+func maskedSources(sources map[string]*configutil.ValueSource) map[string]*configutil.ValueSource {
+	masked := map[string]*configutil.ValueSource{}
+	for path, source := range sources {
+		if source.IsSecret() {
+			masked[path] = source
+		}
+	}
+
+	return masked
+}
+
+// writeValue writes a value unmarshalled from yaml as yaml. Secret values are masked and if annotate is true, the
+// source of every scalar value is added as a comment
+func writeValue(buf *bytes.Buffer, value interface{}, path string, indent int, sources map[string]*configutil.ValueSource, annotate bool) {
+	prefix := strings.Repeat(" ", indent)
+
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range value {
+			key := fmt.Sprintf("%v", item.Key)
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			buf.WriteString(prefix + scalarString(key) + ":")
+			writeChild(buf, item.Value, childPath, indent+2, sources, annotate)
+		}
+	case []interface{}:
+		for index, item := range value {
+			childPath := fmt.Sprintf("%s[%d]", path, index)
+
+			// Write the first line of map items after the dash
+			buf.WriteString(prefix + "-")
+			if mapSlice, ok := item.(yaml.MapSlice); ok && len(mapSlice) > 0 {
+				child := &bytes.Buffer{}
+				writeValue(child, mapSlice, childPath, indent+2, sources, annotate)
+				buf.WriteString(" " + strings.TrimPrefix(child.String(), prefix+"  "))
+				continue
+			} else if items, ok := item.([]interface{}); ok && len(items) > 0 {
+				buf.WriteString("\n")
+				writeValue(buf, items, childPath, indent+2, sources, annotate)
+				continue
+			}
+
+			writeChild(buf, item, childPath, indent+2, sources, annotate)
+		}
+	}
+}
+
+// writeChild writes the value after a key or dash
+func writeChild(buf *bytes.Buffer, value interface{}, path string, indent int, sources map[string]*configutil.ValueSource, annotate bool) {
+	switch child := value.(type) {
+	case yaml.MapSlice:
+		if len(child) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+
+		buf.WriteString("\n")
+		writeValue(buf, child, path, indent, sources, annotate)
+	case []interface{}:
+		if len(child) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+
+		// Sequences are not indented below keys
+		buf.WriteString("\n")
+		writeValue(buf, child, path, indent-2, sources, annotate)
+	default:
+		source := sources[path]
+		if source != nil && source.IsSecret() {
+			buf.WriteString(" " + secretMask)
+		} else {
+			buf.WriteString(" " + scalarString(child))
+		}
+
+		if annotate && source != nil {
+			buf.WriteString(" # " + source.String())
+		}
+
+		buf.WriteString("\n")
+	}
+}
+
+// scalarString returns the yaml representation of a scalar value
+func scalarString(value interface{}) string {
+	if str, ok := value.(string); ok && strings.Contains(str, "\n") {
+		return strconv.Quote(str)
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
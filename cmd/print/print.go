@@ -0,0 +1,24 @@
+package print
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewPrintCmd creates a new cobra command for the print sub command
+func NewPrintCmd() *cobra.Command {
+	printCmd := &cobra.Command{
+		Use:   "print",
+		Short: "Prints configuration information",
+		Long: `
+#######################################################
+################### devspace print ####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	printCmd.AddCommand(newConfigCmd())
+	printCmd.AddCommand(newSchemaCmd())
+
+	return printCmd
+}
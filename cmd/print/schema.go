@@ -0,0 +1,56 @@
+package print
+
+import (
+	"encoding/json"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/schema"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+type schemaCmd struct {
+	Configs bool
+}
+
+func newSchemaCmd() *cobra.Command {
+	cmd := &schemaCmd{}
+
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the json schema of devspace.yaml",
+		Long: `
+#######################################################
+################ devspace print schema ################
+#######################################################
+Prints the json schema of the latest devspace.yaml
+version, which can be used by editors for validation
+and auto completion
+
+Examples:
+devspace print schema > devspace-schema.json
+devspace print schema --configs
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunPrintSchema,
+	}
+
+	schemaCmd.Flags().BoolVar(&cmd.Configs, "configs", false, "Print the json schema of devspace-configs.yaml instead")
+
+	return schemaCmd
+}
+
+// RunPrintSchema executes the devspace print schema command logic
+func (cmd *schemaCmd) RunPrintSchema(cobraCmd *cobra.Command, args []string) {
+	configSchema := schema.Config()
+	if cmd.Configs {
+		configSchema = schema.Configs()
+	}
+
+	out, err := json.MarshalIndent(configSchema, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.WriteString(string(out) + "\n")
+}
@@ -53,14 +53,18 @@ func (cmd *clusterCmd) RunRemoveCluster(cobraCmd *cobra.Command, args []string)
 	}
 
 	// Verify user is sure to delete the cluster
-	deleteCluster := survey.Question(&survey.QuestionOptions{
+	deleteClusterAnswer, err := survey.Question(&survey.QuestionOptions{
 		Question:     fmt.Sprintf("Are you sure you want to delete cluster %s? This action is irreversible", args[0]),
 		DefaultValue: "No",
 		Options: []string{
 			"No",
 			"Yes",
 		},
-	}) == "Yes"
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	deleteCluster := deleteClusterAnswer == "Yes"
 	if deleteCluster == false {
 		return
 	}
@@ -72,24 +76,32 @@ func (cmd *clusterCmd) RunRemoveCluster(cobraCmd *cobra.Command, args []string)
 	}
 
 	// Delete all spaces?
-	deleteSpaces := survey.Question(&survey.QuestionOptions{
+	deleteSpacesAnswer, err := survey.Question(&survey.QuestionOptions{
 		Question:     "Do you want to delete all cluster spaces?",
 		DefaultValue: "No",
 		Options: []string{
 			"No",
 			"Yes",
 		},
-	}) == "Yes"
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	deleteSpaces := deleteSpacesAnswer == "Yes"
 
 	// Delete services
-	deleteServices := survey.Question(&survey.QuestionOptions{
+	deleteServicesAnswer, err := survey.Question(&survey.QuestionOptions{
 		Question:     "Do you want to delete all cluster services?",
 		DefaultValue: "No",
 		Options: []string{
 			"No",
 			"Yes",
 		},
-	}) == "Yes"
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	deleteServices := deleteServicesAnswer == "Yes"
 
 	// Delete cluster
 	log.StartWait("Deleting cluster " + cluster.Name)
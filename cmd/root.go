@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/cmd/add"
@@ -9,21 +11,33 @@ import (
 	"github.com/devspace-cloud/devspace/cmd/connect"
 	"github.com/devspace-cloud/devspace/cmd/create"
 	"github.com/devspace-cloud/devspace/cmd/list"
+	"github.com/devspace-cloud/devspace/cmd/print"
 	"github.com/devspace-cloud/devspace/cmd/remove"
 	"github.com/devspace-cloud/devspace/cmd/reset"
 	"github.com/devspace-cloud/devspace/cmd/set"
 	"github.com/devspace-cloud/devspace/cmd/status"
 	"github.com/devspace-cloud/devspace/cmd/update"
 	"github.com/devspace-cloud/devspace/cmd/use"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/upgrade"
 	"github.com/devspace-cloud/devspace/pkg/util/analytics/cloudanalytics"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	"github.com/devspace-cloud/devspace/pkg/util/terminal"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var cfgFile string
+var noInteractive bool
+var profiles []string
+
+// NoInteractiveEnv is the environment variable that enables the non-interactive mode if it is set to true
+const NoInteractiveEnv = "DEVSPACE_NO_INTERACTIVE"
+
+// CIEnv is the environment variable that enables the non-interactive mode if devspace runs in a CI pipeline
+const CIEnv = "CI"
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -67,6 +81,7 @@ func init() {
 	rootCmd.AddCommand(connect.NewConnectCmd())
 	rootCmd.AddCommand(create.NewCreateCmd())
 	rootCmd.AddCommand(list.NewListCmd())
+	rootCmd.AddCommand(print.NewPrintCmd())
 	rootCmd.AddCommand(remove.NewRemoveCmd())
 	rootCmd.AddCommand(reset.NewResetCmd())
 	rootCmd.AddCommand(set.NewSetCmd())
@@ -91,11 +106,17 @@ func init() {
 	rootCmd.AddCommand(NewUICmd())
 	rootCmd.AddCommand(NewContainerizeCmd())
 
+	rootCmd.PersistentFlags().StringSliceVar(&profiles, "profile", []string{}, "Profiles to apply to the config in the given order (e.g. --profile production --profile debug)")
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "Never ask questions and use the default answers instead (enabled automatically if stdin is not a terminal or "+NoInteractiveEnv+"=true)")
+
 	cobra.OnInitialize(initConfig)
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	survey.SetNonInteractive(isNonInteractive())
+	configutil.SetProfiles(profiles)
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -118,3 +139,21 @@ func initConfig() {
 		log.Info("Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// isNonInteractive returns true if devspace should not ask any questions
+func isNonInteractive() bool {
+	if noInteractive {
+		return true
+	}
+
+	if value, err := strconv.ParseBool(os.Getenv(NoInteractiveEnv)); err == nil {
+		return value
+	}
+
+	// Most CI systems set the CI environment variable
+	if ci := os.Getenv(CIEnv); ci != "" && ci != "false" && ci != "0" {
+		return true
+	}
+
+	return terminal.IsTerminalIn(os.Stdin) == false
+}
@@ -0,0 +1,240 @@
+package status
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	deployComponent "github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
+	deployHelm "github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	deployKubectl "github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	deployStatus "github.com/devspace-cloud/devspace/pkg/devspace/deploy/status"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type deploymentsCmd struct {
+	Output string
+}
+
+func newDeploymentsCmd() *cobra.Command {
+	cmd := &deploymentsCmd{}
+
+	deploymentsCmd := &cobra.Command{
+		Use:   "deployments",
+		Short: "Shows the status of the workloads, pods and services of all deployments",
+		Long: `
+#######################################################
+############ devspace status deployments ##############
+#######################################################
+Shows the ready replicas of the deployments, statefulsets
+and daemonsets, the restarts and last termination reason
+of the pods and the endpoints of the services that
+belong to the deployments in devspace.yaml. With
+-o json, stdout only contains the json output and log
+messages are written to stderr
+
+Examples:
+devspace status deployments
+devspace status deployments my-deployment
+devspace status deployments -o json
+#######################################################
+	`,
+		Args: cobra.ArbitraryArgs,
+		Run:  cmd.RunStatusDeployments,
+	}
+
+	deploymentsCmd.Flags().StringVarP(&cmd.Output, "output", "o", "table", "The output format (table or json)")
+
+	return deploymentsCmd
+}
+
+// RunStatusDeployments executes the devspace status deployments command logic
+func (cmd *deploymentsCmd) RunStatusDeployments(cobraCmd *cobra.Command, args []string) {
+	if cmd.Output != "table" && cmd.Output != "json" {
+		log.Fatalf("Unsupported output format %s. Please use table or json", cmd.Output)
+	}
+
+	// Log messages are written to stderr, so that stdout only contains the json output
+	if cmd.Output == "json" {
+		log.SetInstance(log.NewStreamLogger(os.Stderr, logrus.InfoLevel))
+	}
+
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
+	}
+
+	config := configutil.GetConfig()
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Signal that we are working on the space if there is any
+	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %s", err.Error())
+	}
+
+	statuses := []*deployStatus.DeploymentStatus{}
+	if config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			if len(args) > 0 && contains(args, *deployConfig.Name) == false {
+				continue
+			}
+
+			var deployClient deploy.Interface
+			if deployConfig.Kubectl != nil || deployConfig.Template != nil {
+				deployClient, err = deployKubectl.New(config, client, deployConfig, log.GetInstance())
+			} else if deployConfig.Helm != nil {
+				deployClient, err = deployHelm.New(config, client, deployConfig, log.GetInstance())
+			} else if deployConfig.Component != nil {
+				deployClient, err = deployComponent.New(config, client, deployConfig, log.GetInstance())
+			} else {
+				continue
+			}
+			if err != nil {
+				log.Warnf("Unable to create deploy config for %s: %v", *deployConfig.Name, err)
+				continue
+			}
+
+			result, err := deployClient.Status()
+			if err != nil {
+				log.Warnf("Error retrieving status for deployment %s: %v", *deployConfig.Name, err)
+				continue
+			}
+
+			statuses = append(statuses, deployStatus.Get(client, result))
+		}
+	}
+
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		_, err = os.Stdout.Write(append(out, '\n'))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	printStatuses(statuses)
+}
+
+func printStatuses(statuses []*deployStatus.DeploymentStatus) {
+	values := [][]string{}
+	for _, status := range statuses {
+		values = append(values, []string{
+			status.Name,
+			status.Type,
+			status.Target,
+			status.Status,
+		})
+	}
+
+	log.PrintTable(log.GetInstance(), []string{"NAME", "TYPE", "DEPLOY", "STATUS"}, values)
+
+	for _, status := range statuses {
+		log.WriteString("\n")
+		log.Infof("Deployment %s", status.Name)
+
+		if status.Error != "" {
+			log.Warn(status.Error)
+		}
+		if len(status.Workloads) == 0 && len(status.Pods) == 0 && len(status.Services) == 0 {
+			log.Info("No workloads, pods or services found")
+			continue
+		}
+
+		if len(status.Workloads) > 0 {
+			values = [][]string{}
+			for _, workload := range status.Workloads {
+				ready := strconv.Itoa(int(workload.Ready)) + "/" + strconv.Itoa(int(workload.Desired))
+				if workload.NotFound {
+					ready = "Not found"
+				}
+
+				values = append(values, []string{
+					workload.Kind,
+					workload.Name,
+					workload.Namespace,
+					ready,
+				})
+			}
+
+			log.PrintTable(log.GetInstance(), []string{"KIND", "NAME", "NAMESPACE", "READY"}, values)
+		}
+
+		if len(status.Pods) > 0 {
+			values = [][]string{}
+			for _, pod := range status.Pods {
+				values = append(values, []string{
+					pod.Name,
+					pod.Workload,
+					pod.Status,
+					strconv.Itoa(pod.ReadyContainers) + "/" + strconv.Itoa(pod.TotalContainers),
+					strconv.Itoa(int(pod.Restarts)),
+					pod.LastTermination,
+				})
+			}
+
+			log.PrintTable(log.GetInstance(), []string{"POD", "WORKLOAD", "STATUS", "READY", "RESTARTS", "LAST TERMINATION"}, values)
+		}
+
+		if len(status.Services) > 0 {
+			values = [][]string{}
+			for _, service := range status.Services {
+				endpoints := strings.Join(service.Endpoints, ", ")
+				if service.NotFound {
+					endpoints = "Not found"
+				} else if len(service.Endpoints) == 0 {
+					endpoints = "<none>"
+				}
+				if service.NotReadyEndpoints > 0 {
+					endpoints += " (" + strconv.Itoa(service.NotReadyEndpoints) + " not ready)"
+				}
+
+				values = append(values, []string{
+					service.Name,
+					service.Type,
+					service.ClusterIP,
+					endpoints,
+				})
+			}
+
+			log.PrintTable(log.GetInstance(), []string{"SERVICE", "TYPE", "CLUSTER-IP", "ENDPOINTS"}, values)
+		}
+	}
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
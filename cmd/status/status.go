@@ -18,6 +18,7 @@ func NewStatusCmd() *cobra.Command {
 	}
 
 	statusCmd.AddCommand(newSyncCmd())
+	statusCmd.AddCommand(newDeploymentsCmd())
 
 	return statusCmd
 }
@@ -94,10 +94,14 @@ func (cmd *chartCmd) RunChart(cobraCmd *cobra.Command, args []string) {
 			deployments = append(deployments, *deploy.Name)
 		}
 
-		deploymentName := survey.Question(&survey.QuestionOptions{
-			Question: "Select a deployment",
-			Options:  deployments,
+		deploymentName, err := survey.Question(&survey.QuestionOptions{
+			Question:           "Select a deployment",
+			Options:            deployments,
+			NonInteractiveHint: "Please specify the deployment with --deployment",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		for _, deploy := range helmDeployments {
 			if *deploy.Name == deploymentName {
@@ -26,8 +26,9 @@ config version
 Note: This does not upgrade the overwrite configs
 #######################################################
 	`,
-		Args: cobra.NoArgs,
-		Run:  cmd.RunConfig,
+		Deprecated: "please use 'devspace upgrade config' instead, which also converts the overrides and keeps variables",
+		Args:       cobra.NoArgs,
+		Run:        cmd.RunConfig,
 	}
 
 	return configCmd
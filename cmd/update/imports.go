@@ -0,0 +1,61 @@
+package update
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/spf13/cobra"
+)
+
+// importsCmd holds the cmd flags
+type importsCmd struct{}
+
+// newImportsCmd creates a new command
+func newImportsCmd() *cobra.Command {
+	cmd := &importsCmd{}
+
+	importsCmd := &cobra.Command{
+		Use:   "imports",
+		Short: "Updates the git imports of the config and pins their commits in devspace-lock.yaml",
+		Long: `
+#######################################################
+############### devspace update imports ###############
+#######################################################
+Fetches the git repositories of the imports defined in
+the devspace.yaml again and pins the current commit of
+their tag, branch or revision in devspace-lock.yaml
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunImports,
+	}
+
+	return importsCmd
+}
+
+// RunImports executes the functionality "devspace update imports"
+func (cmd *importsCmd) RunImports(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatalf("Error loading generated.yaml: %v", err)
+	}
+
+	err = configutil.UpdateImports(".", generatedConfig.ActiveConfig, generatedConfig, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Donef("Successfully updated all imports in %s", constants.DefaultImportsLockPath)
+}
@@ -20,6 +20,7 @@ func NewUpdateCmd() *cobra.Command {
 	updateCmd.AddCommand(newConfigCmd())
 	updateCmd.AddCommand(newChartCmd())
 	updateCmd.AddCommand(newDependenciesCmd())
+	updateCmd.AddCommand(newImportsCmd())
 
 	return updateCmd
 }
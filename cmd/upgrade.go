@@ -21,11 +21,16 @@ func NewUpgradeCmd() *cobra.Command {
 ################## devspace upgrade ###################
 #######################################################
 Upgrades the DevSpace CLI to the newest version
+
+Run 'devspace upgrade config' to convert the config
+files to the latest config version
 #######################################################`,
 		Args: cobra.NoArgs,
 		Run:  cmd.Run,
 	}
 
+	upgradeCmd.AddCommand(NewUpgradeConfigCmd())
+
 	return upgradeCmd
 }
 
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/diff"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeConfigCmd is a struct that defines a command call for "upgrade config"
+type UpgradeConfigCmd struct {
+	DryRun bool
+}
+
+// NewUpgradeConfigCmd creates a new upgrade config command
+func NewUpgradeConfigCmd() *cobra.Command {
+	cmd := &UpgradeConfigCmd{}
+
+	upgradeConfigCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Converts the config files to the latest config version",
+		Long: `
+#######################################################
+############### devspace upgrade config ###############
+#######################################################
+Converts devspace.yaml, devspace-configs.yaml and all
+config files referenced in devspace-configs.yaml to the
+latest config version. The changes are shown before
+the files are written and the original files are kept
+with the suffix .bak
+
+Variables, the key order and the comments at the
+beginning of the files are kept, other comments are
+removed
+
+Examples:
+devspace upgrade config
+devspace upgrade config --dry-run
+#######################################################`,
+		Args: cobra.NoArgs,
+		Run:  cmd.Run,
+	}
+
+	upgradeConfigCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Only show the changes without writing the files")
+
+	return upgradeConfigCmd
+}
+
+// Run executes the command logic
+func (cmd *UpgradeConfigCmd) Run(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	upgradedFiles, err := configutil.UpgradeConfigFiles(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(upgradedFiles) == 0 {
+		log.Donef("All config files already use the latest config version %s", latest.Version)
+		return
+	}
+
+	for _, upgradedFile := range upgradedFiles {
+		log.WriteString(diff.Unified(upgradedFile.Path, upgradedFile.Path+" ("+latest.Version+")", string(upgradedFile.Original), string(upgradedFile.Upgraded)) + "\n")
+	}
+	if cmd.DryRun {
+		return
+	}
+
+	writeFiles, err := survey.Question(&survey.QuestionOptions{
+		Question:     "Do you want to write the upgraded config files?",
+		DefaultValue: "yes",
+		Options:      []string{"yes", "no"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if writeFiles != "yes" {
+		return
+	}
+
+	for _, upgradedFile := range upgradedFiles {
+		err = upgradedFile.Save()
+		if err != nil {
+			log.Fatalf("Error writing %s: %v", upgradedFile.Path, err)
+		}
+
+		log.Donef("Upgraded %s to %s (backup: %s)", upgradedFile.Path, latest.Version, upgradedFile.Path+configutil.BackupSuffix)
+	}
+}
@@ -61,10 +61,14 @@ func (*configCmd) RunUseConfig(cobraCmd *cobra.Command, args []string) {
 			configNames = append(configNames, configKey)
 		}
 
-		configName = survey.Question(&survey.QuestionOptions{
-			Question: "Please select a config to use",
-			Options:  configNames,
+		configName, err = survey.Question(&survey.QuestionOptions{
+			Question:           "Please select a config to use",
+			Options:            configNames,
+			NonInteractiveHint: "Please specify the config with 'devspace use config [NAME]'",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	// Check if config exists
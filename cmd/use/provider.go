@@ -49,10 +49,14 @@ func (*providerCmd) RunUseProvider(cobraCmd *cobra.Command, args []string) {
 			providerNames = append(providerNames, provider.Name)
 		}
 
-		providerName = survey.Question(&survey.QuestionOptions{
-			Question: "Please select a default provider",
-			Options:  providerNames,
+		providerName, err = survey.Question(&survey.QuestionOptions{
+			Question:           "Please select a default provider",
+			Options:            providerNames,
+			NonInteractiveHint: "Please specify the provider with 'devspace use provider [NAME]'",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	provider := config.GetProvider(providerConfig, providerName)
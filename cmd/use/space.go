@@ -120,10 +120,14 @@ func (cmd *spaceCmd) RunUseSpace(cobraCmd *cobra.Command, args []string) {
 			names = append(names, space.Name)
 		}
 
-		spaceName := survey.Question(&survey.QuestionOptions{
-			Question: "Please select a space that you want to use",
-			Options:  names,
+		spaceName, err := survey.Question(&survey.QuestionOptions{
+			Question:           "Please select a space that you want to use",
+			Options:            names,
+			NonInteractiveHint: "Please specify the space with 'devspace use space [NAME]'",
 		})
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		// Set space id
 		for _, space := range spaces {
@@ -3,7 +3,7 @@ module github.com/devspace-cloud/devspace
 require (
 	github.com/MakeNowJust/heredoc v0.0.0-20171113091838-e9091a26100e // indirect
 	github.com/Masterminds/semver v1.4.2 // indirect
-	github.com/Masterminds/sprig v2.16.0+incompatible // indirect
+	github.com/Masterminds/sprig v2.16.0+incompatible
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
 	github.com/agl/ed25519 v0.0.0-20170116200512-5312a6153412 // indirect
 	github.com/aokoli/goutils v1.0.1 // indirect
@@ -89,9 +89,11 @@ require (
 	gotest.tools v2.2.0+incompatible
 	k8s.io/api v0.0.0
 	k8s.io/apimachinery v0.0.0
+	k8s.io/cli-runtime v0.0.0
 	k8s.io/client-go v0.0.0
 	k8s.io/helm v2.14.2+incompatible
 	k8s.io/kubernetes v1.15.0
+	sigs.k8s.io/kustomize v2.0.3+incompatible
 	vbom.ml/util v0.0.0-20180919145318-efcd4e0f9787 // indirect
 )
 
@@ -111,16 +111,23 @@ func Pods(client kubernetes.Interface, namespace string, noWait bool) ([]string,
 	}
 
 	// Analyzing pods
-	if pods.Items != nil {
-		for _, pod := range pods.Items {
-			problem := checkPod(client, &pod)
-			if problem != nil {
-				problems = append(problems, printPodProblem(problem))
-			}
+	problems = append(problems, CheckPods(client, pods.Items)...)
+
+	return problems, nil
+}
+
+// CheckPods analyzes the given pods without waiting and returns the found problems
+func CheckPods(client kubernetes.Interface, pods []v1.Pod) []string {
+	problems := []string{}
+
+	for _, pod := range pods {
+		problem := checkPod(client, &pod)
+		if problem != nil {
+			problems = append(problems, printPodProblem(problem))
 		}
 	}
 
-	return problems, nil
+	return problems
 }
 
 type podProblem struct {
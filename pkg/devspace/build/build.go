@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
@@ -19,10 +20,11 @@ type imageNameAndTag struct {
 	imageConfigName string
 	imageName       string
 	imageTag        string
+	imageDigest     string
 }
 
-// All builds all images
-func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, skipPush, isDev, forceRebuild, sequential bool, log logpkg.Logger) (map[string]string, error) {
+// All builds all images. If images is not empty, only the given image configs are built
+func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, skipPush, isDev, forceRebuild, sequential bool, images []string, log logpkg.Logger) (map[string]string, error) {
 	var (
 		builtImages = make(map[string]string)
 
@@ -47,8 +49,16 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 		return nil, err
 	}
 
+	selectedImages := map[string]bool{}
+	for _, imageConfigName := range images {
+		selectedImages[imageConfigName] = true
+	}
+
 	imagesToBuild := 0
 	for key, imageConf := range *config.Images {
+		if len(selectedImages) > 0 && selectedImages[key] == false {
+			continue
+		}
 		if imageConf.Build != nil && imageConf.Build.Disabled != nil && *imageConf.Build.Disabled == true {
 			log.Infof("Skipping building image %s", key)
 			continue
@@ -96,6 +106,7 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 			imageCache := cache.GetImageCache(imageConfigName)
 			imageCache.ImageName = imageName
 			imageCache.Tag = imageTag
+			imageCache.Digest = getDigest(builder)
 
 			// Track built images
 			builtImages[imageName] = imageTag
@@ -118,6 +129,7 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 					imageConfigName: imageConfigName,
 					imageName:       imageName,
 					imageTag:        imageTag,
+					imageDigest:     getDigest(builder),
 				}
 			}()
 		}
@@ -140,6 +152,7 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 				imageCache := cache.GetImageCache(done.imageConfigName)
 				imageCache.ImageName = done.imageName
 				imageCache.Tag = done.imageTag
+				imageCache.Digest = done.imageDigest
 
 				// Track built images
 				builtImages[done.imageName] = done.imageTag
@@ -155,3 +168,12 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 
 	return builtImages, nil
 }
+
+// getDigest returns the digest of the pushed image if the builder knows it
+func getDigest(imageBuilder builder.Interface) string {
+	if digestBuilder, ok := imageBuilder.(builder.DigestInterface); ok {
+		return digestBuilder.Digest()
+	}
+
+	return ""
+}
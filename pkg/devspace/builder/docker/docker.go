@@ -49,6 +49,9 @@ type Builder struct {
 	authConfig *types.AuthConfig
 	client     client.CommonAPIClient
 	skipPush   bool
+
+	// digest is the digest of the pushed image
+	digest string
 }
 
 // NewBuilder creates a new docker Builder instance
@@ -79,6 +82,8 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 		displayRegistryURL = "hub.docker.com"
 	)
 
+	b.digest = ""
+
 	// Display nice registry name
 	registryURL, err := registry.GetRegistryFromImageName(b.helper.ImageName)
 	if err != nil {
@@ -264,6 +269,11 @@ func (b *Builder) Authenticate() (*types.AuthConfig, error) {
 	return b.authConfig, nil
 }
 
+// Digest returns the digest of the image that was pushed during the last build or an empty string if the image was not pushed
+func (b *Builder) Digest() string {
+	return b.digest
+}
+
 // PushImage pushes an image to the specified registry
 func (b *Builder) PushImage(writer io.Writer) error {
 	ref, err := reference.ParseNormalizedNamed(b.helper.ImageName + ":" + b.helper.ImageTag)
@@ -283,8 +293,20 @@ func (b *Builder) PushImage(writer io.Writer) error {
 		return err
 	}
 
+	// Remember the digest of the pushed image
+	auxCallback := func(msg jsonmessage.JSONMessage) {
+		if msg.Aux == nil {
+			return
+		}
+
+		pushResult := types.PushResult{}
+		if json.Unmarshal(*msg.Aux, &pushResult) == nil && pushResult.Digest != "" {
+			b.digest = pushResult.Digest
+		}
+	}
+
 	outStream := command.NewOutStream(writer)
-	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), nil)
+	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), auxCallback)
 	if err != nil {
 		return err
 	}
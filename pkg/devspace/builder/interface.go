@@ -10,3 +10,8 @@ type Interface interface {
 	ShouldRebuild(cache *generated.CacheConfig) (bool, error)
 	Build(log log.Logger) error
 }
+
+// DigestInterface is implemented by builders that know the digest of the pushed image
+type DigestInterface interface {
+	Digest() string
+}
@@ -308,11 +308,17 @@ func deleteCluster(p *Provider, clusterID int, key string) error {
 
 func (p *Provider) specifyDomain(clusterID int, options *ConnectClusterOptions) error {
 	if options.Domain == "" {
-		options.Domain = survey.Question(&survey.QuestionOptions{
+		domain, err := survey.Question(&survey.QuestionOptions{
 			Question:               "DevSpace will automatically create an ingress for each space, which base domain do you want to use for the created spaces? (e.g. users.test.com)",
 			ValidationRegexPattern: "^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]*[a-zA-Z0-9])\\.)*([A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9\\-]*[A-Za-z0-9])$",
 			ValidationMessage:      "Please enter a valid hostname (e.g. users.my-domain.com)",
+			NonInteractiveHint:     "Please specify the domain with --domain",
 		})
+		if err != nil {
+			return err
+		}
+
+		options.Domain = domain
 	}
 
 	log.StartWait("Updating domain name")
@@ -365,14 +371,19 @@ func (p *Provider) deployServices(client kubernetes.Interface, clusterID int, av
 	if options.DeployIngressController {
 		// Ask if we should use the host network
 		if options.UseHostNetwork == nil {
-			options.UseHostNetwork = ptr.Bool(survey.Question(&survey.QuestionOptions{
+			networkOption, err := survey.Question(&survey.QuestionOptions{
 				Question:     "Should the ingress controller use a LoadBalancer or the host network?",
 				DefaultValue: loadBalancerOption,
 				Options: []string{
 					loadBalancerOption,
 					hostNetworkOption,
 				},
-			}) == hostNetworkOption)
+			})
+			if err != nil {
+				return err
+			}
+
+			options.UseHostNetwork = ptr.Bool(networkOption == hostNetworkOption)
 		}
 
 		log.StartWait("Deploying ingress controller")
@@ -570,19 +581,26 @@ func getKey(provider *Provider, forceQuestion bool) (string, error) {
 	}
 
 	for true {
-		firstKey := survey.Question(&survey.QuestionOptions{
+		firstKey, err := survey.Question(&survey.QuestionOptions{
 			Question:               "Please enter a secure encryption key for your cluster credentials",
 			ValidationRegexPattern: "^.{6,32}$",
 			ValidationMessage:      "Key has to be between 6 and 32 characters long",
 			IsPassword:             true,
+			NonInteractiveHint:     "Please specify the key with --key",
 		})
+		if err != nil {
+			return "", err
+		}
 
-		secondKey := survey.Question(&survey.QuestionOptions{
+		secondKey, err := survey.Question(&survey.QuestionOptions{
 			Question:               "Please re-enter the key",
 			ValidationRegexPattern: "^.{6,32}$",
 			ValidationMessage:      "Key has to be between 6 and 32 characters long",
 			IsPassword:             true,
 		})
+		if err != nil {
+			return "", err
+		}
 
 		if firstKey != secondKey {
 			log.Info("Keys do not match! Please reenter")
@@ -610,10 +628,14 @@ func getClusterName(clusterName string) (string, error) {
 
 	// Ask for cluster name
 	for true {
-		clusterName = survey.Question(&survey.QuestionOptions{
+		var err error
+		clusterName, err = survey.Question(&survey.QuestionOptions{
 			Question:     "Please enter a cluster name (e.g. my-cluster)",
 			DefaultValue: "my-cluster",
 		})
+		if err != nil {
+			return "", err
+		}
 
 		if ClusterNameValidationRegEx.MatchString(clusterName) == false {
 			log.Infof("Cluster name %s can only contain letters, numbers and dashes (-)", clusterName)
@@ -35,10 +35,14 @@ func GetProvider(useProviderName *string, log log.Logger) (*Provider, error) {
 				options = append(options, providerHost.Name)
 			}
 
-			providerName = survey.Question(&survey.QuestionOptions{
-				Question: "Select cloud provider",
-				Options:  options,
+			providerName, err = survey.Question(&survey.QuestionOptions{
+				Question:           "Select cloud provider",
+				Options:            options,
+				NonInteractiveHint: "Please specify the provider with --provider",
 			})
+			if err != nil {
+				return nil, err
+			}
 		}
 	} else {
 		providerName = *useProviderName
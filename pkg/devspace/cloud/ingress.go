@@ -64,10 +64,16 @@ func (p *Provider) CreateIngress(config *latest.Config, client kubernetes.Interf
 		servicePort = splitted[1]
 	} else {
 		// Ask user which service
-		splitted := strings.Split(survey.Question(&survey.QuestionOptions{
-			Question: fmt.Sprintf("Please specify the service you want to connect '%s' to", ansi.Color(host, "white+b")),
-			Options:  serviceNameList,
-		}), ":")
+		serviceNameAndPort, err := survey.Question(&survey.QuestionOptions{
+			Question:     fmt.Sprintf("Please specify the service you want to connect '%s' to", ansi.Color(host, "white+b")),
+			DefaultValue: serviceNameList[0],
+			Options:      serviceNameList,
+		})
+		if err != nil {
+			return err
+		}
+
+		splitted := strings.Split(serviceNameAndPort, ":")
 
 		serviceName = splitted[0]
 		servicePort = splitted[1]
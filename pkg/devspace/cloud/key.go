@@ -57,12 +57,16 @@ func (p *Provider) AskForEncryptionKey(cluster *Cluster) (string, error) {
 
 	// Wait till user enters the correct key
 	for true {
-		key := survey.Question(&survey.QuestionOptions{
+		key, err := survey.Question(&survey.QuestionOptions{
 			Question:               "Please enter your encryption key for cluster " + cluster.Name,
 			ValidationRegexPattern: "^.{6,32}$",
 			ValidationMessage:      "Key has to be between 6 and 32 characters long",
 			IsPassword:             true,
+			NonInteractiveHint:     "The key is saved after it has been entered once, please run the command once in a terminal",
 		})
+		if err != nil {
+			return "", err
+		}
 
 		hashedKey, err := hash.Password(key)
 		if err != nil {
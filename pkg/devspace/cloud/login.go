@@ -152,6 +152,10 @@ func EnsureLoggedIn(providerConfig *latest.Config, cloudProvider string, log log
 
 // Login logs the user into DevSpace Cloud
 func (p *Provider) Login(log log.Logger) error {
+	if survey.IsNonInteractive() {
+		return errors.Errorf("Cannot login to %s in non-interactive mode. Please login with 'devspace login --key [ACCESS_KEY]'", p.Name)
+	}
+
 	var (
 		url        = p.Host + LoginEndpoint
 		ctx        = context.Background()
@@ -164,10 +168,15 @@ func (p *Provider) Login(log log.Logger) error {
 	if err != nil {
 		log.Infof("Unable to open web browser for login page.\n\n Please follow these instructions for manually loggin in:\n\n  1. Open this URL in a browser: %s\n  2. After logging in, click the 'Create Key' button\n  3. Enter a key name (e.g. my-key) and click 'Create Access Key'\n  4. Copy the generated key from the input field", p.Host + "/settings/access-keys")
 
-		key = strings.TrimSpace(survey.Question(&survey.QuestionOptions{
+		key, err = survey.Question(&survey.QuestionOptions{
 			Question: "5. Enter the access key here:",
 			IsPassword: true,
-		}))
+		})
+		if err != nil {
+			return err
+		}
+
+		key = strings.TrimSpace(key)
 
 		log.WriteString("\n")
 		
@@ -25,12 +25,33 @@ type VarsWrapper struct {
 // Variable describes the var definition
 type Variable struct {
 	Name              *string         `yaml:"name"`
+	Type              *VariableType   `yaml:"type,omitempty"`
 	Source            *VariableSource `yaml:"source,omitempty"`
 	Options           *[]string       `yaml:"options,omitempty"`
 	Default           *string         `yaml:"default,omitempty"`
 	Question          *string         `yaml:"question,omitempty"`
 	ValidationPattern *string         `yaml:"validationPattern,omitempty"`
 	ValidationMessage *string         `yaml:"validationMessage,omitempty"`
+	Secret            *bool           `yaml:"secret,omitempty"`
+
+	File     *string         `yaml:"file,omitempty"`
+	Command  *string         `yaml:"command,omitempty"`
+	Args     *[]string       `yaml:"args,omitempty"`
+	Keychain *KeychainSource `yaml:"keychain,omitempty"`
+	Vault    *VaultSource    `yaml:"vault,omitempty"`
+}
+
+// KeychainSource defines where to find the value of a variable in the keychain of the operating system
+type KeychainSource struct {
+	Service *string `yaml:"service"`
+	Account *string `yaml:"account,omitempty"`
+}
+
+// VaultSource defines where to find the value of a variable in a Vault compatible secret store
+type VaultSource struct {
+	Address *string `yaml:"address,omitempty"`
+	Path    *string `yaml:"path"`
+	Key     *string `yaml:"key"`
 }
 
 // VariableSource is type of a variable source
@@ -41,4 +62,21 @@ const (
 	VariableSourceAll   VariableSource = "all"
 	VariableSourceEnv   VariableSource = "env"
 	VariableSourceInput VariableSource = "input"
+
+	VariableSourceFile     VariableSource = "file"
+	VariableSourceCommand  VariableSource = "command"
+	VariableSourceKeychain VariableSource = "keychain"
+	VariableSourceVault    VariableSource = "vault"
+)
+
+// VariableType is the type of the value of a variable
+type VariableType string
+
+// List of values that type can take
+const (
+	VariableTypeString VariableType = "string"
+	VariableTypeInt    VariableType = "int"
+	VariableTypeBool   VariableType = "bool"
+	VariableTypeList   VariableType = "list"
+	VariableTypeMap    VariableType = "map"
 )
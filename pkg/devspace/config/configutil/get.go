@@ -15,10 +15,12 @@ import (
 
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	varspkg "github.com/devspace-cloud/devspace/pkg/util/vars"
 
 	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/varsource"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
 	"github.com/mgutz/ansi"
@@ -101,7 +103,9 @@ func GetConfig() *latest.Config {
 	return config
 }
 
-func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites bool, generatedConfig *generated.Config, log log.Logger) (*latest.Config, *configspkg.ConfigDefinition, error) {
+// loadBaseConfigFromPath loads the config and merges the overrides. If sources is not nil, the source of every value of the
+// returned config is added to it
+func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites bool, generatedConfig *generated.Config, sources map[string]*ValueSource, log log.Logger) (*latest.Config, *configspkg.ConfigDefinition, error) {
 	var (
 		config           = latest.New().(*latest.Config)
 		configRaw        = latest.New().(*latest.Config)
@@ -109,6 +113,8 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 		configPath       = filepath.Join(basePath, constants.DefaultConfigPath)
 		configsPath      = filepath.Join(basePath, constants.DefaultConfigsPath)
 		varsPath         = filepath.Join(basePath, constants.DefaultVarsPath)
+		rawSource        *ValueSource
+		rawVars          map[string]string
 	)
 
 	// Check if configs.yaml exists
@@ -173,10 +179,15 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 		}
 
 		// Load config
-		configRaw, err = loadConfigFromWrapper(basePath, configDefinition.Config)
+		rawVars, err = collectLoadedVars(func() error {
+			configRaw, err = loadConfigFromWrapper(basePath, configDefinition.Config)
+			return err
+		})
 		if err != nil {
 			return nil, nil, err
 		}
+
+		rawSource = wrapperSource(constants.DefaultConfigsPath, configDefinition.Config, -1)
 	} else {
 		_, err := os.Stat(varsPath)
 		if err == nil {
@@ -198,24 +209,61 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 			}
 		}
 
-		configRaw, err = loadConfigFromPath(configPath)
+		rawVars, err = collectLoadedVars(func() error {
+			configRaw, err = loadConfigFromPath(configPath)
+			return err
+		})
 		if err != nil {
 			return nil, nil, fmt.Errorf("Loading config: %v", err)
 		}
+
+		rawSource = &ValueSource{File: constants.DefaultConfigPath, Override: -1}
+	}
+
+	// Imports are merged before the config itself, but not if the config is loaded without overrides to change and
+	// save it
+	hasImports := loadOverwrites && configRaw.Imports != nil
+	if hasImports {
+		config = &latest.Config{}
+
+		err = mergeImports(basePath, config, *configRaw.Imports, sources, log)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = addSources(sources, configRaw, rawSource, rawVars)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	Merge(&config, deepCopy(configRaw))
+	if hasImports {
+		// Merge the config field by field into the imported fragments instead of replacing them
+		Merge(&config, *deepCopy(configRaw).(**latest.Config))
+	} else {
+		Merge(&config, deepCopy(configRaw))
+	}
 
 	// Check if we should load overrides
 	if loadOverwrites {
 		if configDefinition != nil {
 			if configDefinition.Overrides != nil {
 				for index, configWrapper := range *configDefinition.Overrides {
-					overwriteConfig, err := loadConfigFromWrapper(".", configWrapper)
+					var overwriteConfig *latest.Config
+
+					layerVars, err := collectLoadedVars(func() error {
+						overwriteConfig, err = loadConfigFromWrapper(".", configWrapper)
+						return err
+					})
 					if err != nil {
 						return nil, nil, fmt.Errorf("Error loading override config at index %d: %v", index, err)
 					}
 
+					err = addSources(sources, overwriteConfig, wrapperSource(constants.DefaultConfigsPath, configWrapper, index), layerVars)
+					if err != nil {
+						return nil, nil, err
+					}
+
 					Merge(&config, overwriteConfig)
 				}
 
@@ -227,6 +275,22 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 			log.Infof("Loaded config from %s", constants.DefaultConfigPath)
 		}
 
+		// The selected profiles are only applied to the config of the project and not to the configs of dependencies
+		if basePath == "." && len(selectedProfiles) > 0 {
+			config, err = applyProfiles(config, selectedProfiles, sources)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			log.Infof("Applied profiles %s", strings.Join(selectedProfiles, ", "))
+		}
+
+		// The local config of the developer is merged last, so it overrides the overrides and the profiles
+		err = mergeLocalConfig(basePath, &config, sources, log)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		// Exchange kube context if necessary, but only if we don't load the base config
 		// we do this to avoid saving the kube context on commands like
 		// devspace add deployment && devspace add image etc.
@@ -239,6 +303,10 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 				config.Cluster = &latest.Cluster{
 					KubeContext: &generatedConfig.CloudSpace.KubeContext,
 				}
+
+				if sources != nil {
+					sources["cluster.kubeContext"] = &ValueSource{Space: generatedConfig.CloudSpace.Name, Override: -1}
+				}
 			}
 		}
 	} else {
@@ -252,9 +320,37 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 	return config, configDefinition, nil
 }
 
+// mergeLocalConfig merges devspace.local.yaml into the config if it exists. The file is never changed by the commands
+// that save the config, because it is only merged when the overrides are loaded
+func mergeLocalConfig(basePath string, config **latest.Config, sources map[string]*ValueSource, log log.Logger) error {
+	localConfigPath := filepath.Join(basePath, constants.DefaultLocalConfigPath)
+	_, err := os.Stat(localConfigPath)
+	if err != nil {
+		return nil
+	}
+
+	var localConfig *latest.Config
+	localVars, err := collectLoadedVars(func() error {
+		localConfig, err = loadConfigFromPath(localConfigPath)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Error loading %s: %v", localConfigPath, err)
+	}
+
+	err = addSources(sources, localConfig, &ValueSource{File: constants.DefaultLocalConfigPath, Override: -1}, localVars)
+	if err != nil {
+		return err
+	}
+
+	Merge(config, localConfig)
+	log.Infof("Merged local config %s", constants.DefaultLocalConfigPath)
+	return nil
+}
+
 // GetConfigFromPath loads the config from a given base path
 func GetConfigFromPath(basePath string, loadConfig string, loadOverrides bool, generatedConfig *generated.Config, log log.Logger) (*latest.Config, error) {
-	config, _, err := loadBaseConfigFromPath(basePath, loadConfig, loadOverrides, generatedConfig, log)
+	config, _, err := loadBaseConfigFromPath(basePath, loadConfig, loadOverrides, generatedConfig, nil, log)
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +381,7 @@ func GetConfigWithoutDefaults(loadOverwrites bool) *latest.Config {
 		LoadedConfig = generatedConfig.ActiveConfig
 
 		// Load base config
-		config, configDefinition, err = loadBaseConfigFromPath(".", LoadedConfig, loadOverwrites, generatedConfig, log.GetInstance())
+		config, configDefinition, err = loadBaseConfigFromPath(".", LoadedConfig, loadOverwrites, generatedConfig, nil, log.GetInstance())
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -327,11 +423,11 @@ func validate(config *latest.Config) error {
 
 		if config.Dev.Ports != nil {
 			for index, port := range *config.Dev.Ports {
-				if port.Selector == nil && port.LabelSelector == nil {
-					return fmt.Errorf("Error in config: selector and label selector are nil in port config at index %d", index)
+				if port.Selector == nil && port.LabelSelector == nil && port.Service == nil && port.Deployment == nil {
+					return fmt.Errorf("Error in config: selector, label selector, service and deployment are nil in port config at index %d", index)
 				}
-				if port.PortMappings == nil {
-					return fmt.Errorf("Error in config: portMappings is empty in port config at index %d", index)
+				if port.PortMappings == nil && port.ReversePortMappings == nil {
+					return fmt.Errorf("Error in config: forward and reverseForward are empty in port config at index %d", index)
 				}
 			}
 		}
@@ -374,8 +470,8 @@ func validate(config *latest.Config) error {
 			if deployConfig.Name == nil {
 				return fmt.Errorf("deployments[%d].name is required", index)
 			}
-			if deployConfig.Helm == nil && deployConfig.Kubectl == nil && deployConfig.Component == nil {
-				return fmt.Errorf("Please specify either component, helm or kubectl as deployment type in deployment %s", *deployConfig.Name)
+			if deployConfig.Helm == nil && deployConfig.Kubectl == nil && deployConfig.Component == nil && deployConfig.Template == nil {
+				return fmt.Errorf("Please specify either component, helm, kubectl or template as deployment type in deployment %s", *deployConfig.Name)
 			}
 			if deployConfig.Helm != nil && (deployConfig.Helm.Chart == nil || deployConfig.Helm.Chart.Name == nil) {
 				return fmt.Errorf("deployments[%d].helm.chart and deployments[%d].helm.chart.name is required", index, index)
@@ -383,6 +479,12 @@ func validate(config *latest.Config) error {
 			if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil {
 				return fmt.Errorf("deployments[%d].kubectl.manifests is required", index)
 			}
+			if deployConfig.Template != nil && deployConfig.Template.Path == nil {
+				return fmt.Errorf("deployments[%d].template.path is required", index)
+			}
+			if deployConfig.Rollout != nil && deployConfig.Rollout.Timeout != nil && *deployConfig.Rollout.Timeout <= 0 {
+				return fmt.Errorf("deployments[%d].rollout.timeout must be greater than 0", index)
+			}
 		}
 	}
 
@@ -390,12 +492,52 @@ func validate(config *latest.Config) error {
 }
 
 func askQuestions(cache *generated.CacheConfig, vars []*configspkg.Variable) error {
+	// Default values can reference predefined variables
+	for _, variable := range vars {
+		if variable.Default != nil && varspkg.VarMatchRegex.MatchString(*variable.Default) {
+			err := fillPredefinedVars()
+			if err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
 	for idx, variable := range vars {
 		if variable.Name == nil {
 			return fmt.Errorf("Name required for variable with index %d", idx)
 		}
+		isSecret := variable.Secret != nil && *variable.Secret
+		if isSecret {
+			SecretVars[*variable.Name] = true
+		}
+		if variable.Type != nil {
+			VarTypes[*variable.Name] = *variable.Type
+		}
+
+		// Resolve variables from other sources like files or commands
+		if varsource.Get(variable) != nil {
+			value, err := varsource.Resolve(variable)
+			if err != nil {
+				return err
+			}
 
-		isInEnv := os.Getenv(VarEnvPrefix+strings.ToUpper(*variable.Name)) != "" || os.Getenv(*variable.Name) != ""
+			err = validateVar(variable, value)
+			if err != nil {
+				return err
+			}
+
+			setResolvedVar(*variable.Name, value, isSecret)
+			continue
+		}
+
+		envValue := os.Getenv(VarEnvPrefix + strings.ToUpper(*variable.Name))
+		if envValue == "" {
+			envValue = os.Getenv(*variable.Name)
+		}
+
+		isInEnv := envValue != ""
 		if variable.Source != nil && *variable.Source == configspkg.VariableSourceEnv && isInEnv == false {
 			return fmt.Errorf("Couldn't find environment variable %s, but is needed for loading the config", *variable.Name)
 		}
@@ -403,17 +545,48 @@ func askQuestions(cache *generated.CacheConfig, vars []*configspkg.Variable) err
 		// Check if variable is in environment
 		if variable.Source == nil || *variable.Source != configspkg.VariableSourceInput {
 			if isInEnv {
+				err := validateVar(variable, envValue)
+				if err != nil {
+					return err
+				}
+
+				setResolvedVar(*variable.Name, envValue, isSecret)
 				continue
 			}
 		}
 
-		// Is cached
-		if _, ok := cache.Vars[*variable.Name]; ok {
+		// Secret values are only kept in memory
+		if _, ok := ResolvedVars[*variable.Name]; ok {
+			continue
+		}
+
+		// Is cached, values that do not match the type of the variable are asked again
+		if value, ok := cache.Vars[*variable.Name]; ok && validateVar(variable, value) == nil {
+			// Remove values that were saved before the variable was marked as secret
+			if isSecret {
+				delete(cache.Vars, *variable.Name)
+				setResolvedVar(*variable.Name, value, isSecret)
+			}
+
 			continue
 		}
 
 		// Ask question
-		cache.Vars[*variable.Name] = AskQuestion(variable)
+		value, err := askVariable(variable)
+		if err != nil {
+			return err
+		}
+
+		err = validateVar(variable, value)
+		if err != nil {
+			return err
+		}
+
+		if isSecret {
+			setResolvedVar(*variable.Name, value, isSecret)
+		} else {
+			cache.Vars[*variable.Name] = value
+		}
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"testing"
 
+	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -132,7 +133,7 @@ func TestGetBaseConfig(t *testing.T) {
 		}
 	}()
 
-	fsutil.WriteToFile([]byte(`version: v1beta2
+	fsutil.WriteToFile([]byte(`version: v1beta3
 cluster:
   kubeContext: someKubeContext
   namespace: someNS
@@ -211,7 +212,7 @@ func TestGetConfig(t *testing.T) {
 		}
 	}()
 
-	configString := `version: v1beta2
+	configString := `version: v1beta3
 cluster:
   kubeContext: someKubeContext
   namespace: someNS
@@ -369,7 +370,7 @@ func TestGetConfigFromPath(t *testing.T){
 		}
 	}()
 
-	configString := `version: v1beta2
+	configString := `version: v1beta3
 cluster:
   kubeContext: someKubeContext
   namespace: someNS
@@ -710,6 +711,19 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with invalid deployment %v", err)
 	}
 
+	err = validate(&latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name:    ptr.String("Invalid timeout"),
+				Kubectl: &latest.KubectlConfig{Manifests: &[]*string{ptr.String("kube")}},
+				Rollout: &latest.RolloutConfig{Timeout: ptr.Int64(0)},
+			},
+		},
+	})
+	if err == nil || err.Error() != "deployments[0].rollout.timeout must be greater than 0" {
+		t.Fatalf("No error in config with invalid rollout timeout %v", err)
+	}
+
 	err = validate(&latest.Config{
 		Dev: &latest.DevConfig{
 			Selectors: &[]*latest.SelectorConfig{
@@ -759,3 +773,73 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("Error in valid config found: %v", err)
 	}
 }
+
+func TestAskQuestions(t *testing.T) {
+	resolvedVarsBackup := ResolvedVars
+	secretVarsBackup := SecretVars
+	nonInteractiveBackup := survey.IsNonInteractive()
+	defer func() {
+		ResolvedVars = resolvedVarsBackup
+		SecretVars = secretVarsBackup
+		survey.SetNonInteractive(nonInteractiveBackup)
+	}()
+
+	ResolvedVars = map[string]string{}
+	SecretVars = map[string]bool{}
+	survey.SetNonInteractive(true)
+
+	cache := &generated.CacheConfig{
+		Vars: map[string]string{
+			"CACHED":        "cached-value",
+			"CACHED_SECRET": "secret-value",
+		},
+	}
+
+	commandSource := configspkg.VariableSourceCommand
+	err := askQuestions(cache, []*configspkg.Variable{
+		{Name: ptr.String("CACHED")},
+		{Name: ptr.String("CACHED_SECRET"), Secret: ptr.Bool(true)},
+		{Name: ptr.String("COMMAND"), Source: &commandSource, Command: ptr.String("echo"), Args: &[]string{"command-value"}},
+	})
+	assert.NilError(t, err, "Error asking questions")
+
+	// Secret values are removed from the generated config
+	assert.DeepEqual(t, cache.Vars, map[string]string{"CACHED": "cached-value"})
+	assert.DeepEqual(t, ResolvedVars, map[string]string{"CACHED_SECRET": "secret-value", "COMMAND": "command-value"})
+	assert.DeepEqual(t, SecretVars, map[string]bool{"CACHED_SECRET": true})
+
+	// Questions are not asked in non-interactive mode, default values are used instead
+	err = askQuestions(cache, []*configspkg.Variable{{Name: ptr.String("WITH_DEFAULT"), Default: ptr.String("default-value")}})
+	assert.NilError(t, err, "Error using default value")
+	assert.Equal(t, cache.Vars["WITH_DEFAULT"], "default-value")
+
+	err = askQuestions(cache, []*configspkg.Variable{{Name: ptr.String("MISSING")}})
+	assert.Error(t, err, "Couldn't find a value for variable MISSING and cannot ask for it in non-interactive mode. Please set the environment variable DEVSPACE_VAR_MISSING")
+}
+
+func TestLoadLocalConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte("version: "+latest.Version+"\ncluster:\n  namespace: team\ndev:\n  ports:\n  - labelSelector:\n      app: default\n    forward:\n    - port: 3000\n"), filepath.Join(dir, constants.DefaultConfigPath))
+	assert.NilError(t, err, "Error writing config")
+	err = fsutil.WriteToFile([]byte("cluster:\n  namespace: my-namespace\ndev:\n  ports:\n  - labelSelector:\n      app: default\n    forward:\n    - port: 8080\n"), filepath.Join(dir, constants.DefaultLocalConfigPath))
+	assert.NilError(t, err, "Error writing local config")
+
+	// The local config is merged last and the sources show which values came from it
+	generatedConfig := &generated.Config{Configs: map[string]*generated.CacheConfig{}}
+	sources := map[string]*ValueSource{}
+	config, _, err := loadBaseConfigFromPath(dir, "", true, generatedConfig, sources, log.Discard)
+	assert.NilError(t, err, "Error loading config")
+	assert.Equal(t, *config.Cluster.Namespace, "my-namespace")
+	assert.Equal(t, *(*(*config.Dev.Ports)[0].PortMappings)[0].LocalPort, 8080)
+	assert.Equal(t, sources["cluster.namespace"].String(), constants.DefaultLocalConfigPath)
+
+	// The local config is not merged if the config is loaded to be changed and saved
+	config, _, err = loadBaseConfigFromPath(dir, "", false, generatedConfig, nil, log.Discard)
+	assert.NilError(t, err, "Error loading base config")
+	assert.Equal(t, *config.Cluster.Namespace, "team")
+}
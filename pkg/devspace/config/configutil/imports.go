@@ -0,0 +1,213 @@
+package configutil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/dependency/source"
+	"github.com/devspace-cloud/devspace/pkg/util/git"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ImportsFolder is the folder in the home directory of the user where git imports are cached per commit
+const ImportsFolder = ".devspace/imports"
+
+// ImportsFolderPath will be filled during init
+var ImportsFolderPath string
+
+func init() {
+	homedir, _ := homedir.Dir()
+
+	ImportsFolderPath = filepath.Join(homedir, filepath.FromSlash(ImportsFolder))
+}
+
+// updateImports is true while UpdateImports loads the config, which ignores the pinned commits
+var updateImports bool
+
+// ImportsLock pins the commits of imported config fragments from git repositories
+type ImportsLock struct {
+	// Imports maps the git repository and ref of an import to the resolved commit
+	Imports map[string]string `yaml:"imports,omitempty"`
+}
+
+// UpdateImports resolves the git imports of the config in basePath again and pins their current commits in the lock
+// file
+func UpdateImports(basePath string, loadConfig string, generatedConfig *generated.Config, log log.Logger) error {
+	updateImports = true
+	defer func() { updateImports = false }()
+
+	_, err := GetConfigFromPath(basePath, loadConfig, true, generatedConfig, log)
+	return err
+}
+
+// mergeImports loads the imported config fragments and merges them in order into config. The commits of git imports
+// are pinned in the lock file in basePath
+func mergeImports(basePath string, config *latest.Config, imports []*latest.ImportConfig, sources map[string]*ValueSource, log log.Logger) error {
+	lockPath := filepath.Join(basePath, constants.DefaultImportsLockPath)
+	lock, err := loadImportsLock(lockPath)
+	if err != nil {
+		return fmt.Errorf("Error loading %s: %v", constants.DefaultImportsLockPath, err)
+	}
+
+	newLock := &ImportsLock{Imports: map[string]string{}}
+	for index, importConfig := range imports {
+		importPath, name, err := downloadImport(basePath, importConfig, lock, newLock, log)
+		if err != nil {
+			return fmt.Errorf("Error loading import %d: %v", index, err)
+		}
+
+		var importedConfig *latest.Config
+		layerVars, err := collectLoadedVars(func() error {
+			importedConfig, err = loadConfigFromPath(importPath)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Error loading import %s: %v", name, err)
+		}
+
+		// Imports of imported fragments are not resolved
+		importedConfig.Imports = nil
+
+		err = addSources(sources, importedConfig, &ValueSource{File: name, Override: -1}, layerVars)
+		if err != nil {
+			return err
+		}
+
+		Merge(&config, importedConfig)
+	}
+
+	// Save the lock file if the pinned commits changed
+	if reflect.DeepEqual(lock.Imports, newLock.Imports) == false {
+		out, err := yaml.Marshal(newLock)
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(lockPath, out, 0666)
+		if err != nil {
+			return fmt.Errorf("Error saving %s: %v", constants.DefaultImportsLockPath, err)
+		}
+	}
+
+	return nil
+}
+
+// downloadImport makes sure the import is available locally and returns the path and a name of the config fragment.
+// Git imports are checked out at the commit pinned in lock and the checked out commit is added to newLock
+func downloadImport(basePath string, importConfig *latest.ImportConfig, lock, newLock *ImportsLock, log log.Logger) (string, string, error) {
+	if importConfig.Source == nil || (importConfig.Source.Git == nil && importConfig.Source.Path == nil) {
+		return "", "", errors.New("source.git or source.path is required")
+	}
+
+	file := constants.DefaultConfigPath
+	if importConfig.File != nil {
+		file = *importConfig.File
+	}
+
+	// Local imports are loaded from the path, which may point to a config file directly
+	if importConfig.Source.Git == nil {
+		localPath, err := source.Download(basePath, importConfig.Source, false, log)
+		if err != nil {
+			return "", "", err
+		}
+
+		stat, err := os.Stat(localPath)
+		if err == nil && stat.IsDir() == false && importConfig.File == nil {
+			return localPath, *importConfig.Source.Path, nil
+		}
+
+		return filepath.Join(localPath, filepath.FromSlash(file)), filepath.ToSlash(filepath.Join(*importConfig.Source.Path, file)), nil
+	}
+
+	key := source.GetID(importConfig.Source)
+	commit, ok := lock.Imports[key]
+	if ok == false || updateImports {
+		var err error
+
+		commit, err = resolveImportCommit(importConfig.Source, log)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	// Every commit is checked out in its own folder, which is never changed afterwards
+	localPath := getImportPath(importConfig.Source, commit)
+	err := source.Clone(localPath, &latest.SourceConfig{Git: importConfig.Source.Git, Revision: &commit}, false, log)
+	if err != nil {
+		return "", "", err
+	}
+
+	newLock.Imports[key] = commit
+	return filepath.Join(localPath, filepath.FromSlash(file)), key + ":" + file, nil
+}
+
+// resolveImportCommit clones the git repository of the import and returns the commit of the tag, branch or revision.
+// The clone is kept as the folder of the resolved commit
+func resolveImportCommit(importSource *latest.SourceConfig, log log.Logger) (string, error) {
+	err := os.MkdirAll(ImportsFolderPath, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	tempPath, err := ioutil.TempDir(ImportsFolderPath, "resolve")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempPath)
+
+	err = source.Clone(tempPath, importSource, true, log)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := git.NewGitRepository(tempPath, "").GetHash()
+	if err != nil {
+		return "", err
+	}
+
+	localPath := getImportPath(importSource, commit)
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		os.Rename(tempPath, localPath)
+	}
+
+	return commit, nil
+}
+
+func getImportPath(importSource *latest.SourceConfig, commit string) string {
+	return filepath.Join(ImportsFolderPath, hash.String(strings.TrimSpace(*importSource.Git)+"@"+commit))
+}
+
+func loadImportsLock(path string) (*ImportsLock, error) {
+	lock := &ImportsLock{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			lock.Imports = map[string]string{}
+			return lock, nil
+		}
+
+		return nil, err
+	}
+
+	err = yaml.UnmarshalStrict(data, lock)
+	if err != nil {
+		return nil, err
+	}
+	if lock.Imports == nil {
+		lock.Imports = map[string]string{}
+	}
+
+	return lock, nil
+}
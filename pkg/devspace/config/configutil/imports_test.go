@@ -0,0 +1,168 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/dependency/source"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+// commitImportRepo commits a devspace.yaml with the given image to the git repository and returns the commit
+func commitImportRepo(t *testing.T, repoPath, image string) string {
+	err := fsutil.WriteToFile([]byte("version: "+latest.Version+"\nimages:\n  default:\n    image: "+image+"\n"), filepath.Join(repoPath, constants.DefaultConfigPath))
+	assert.NilError(t, err, "Error writing fragment")
+
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"-c", "user.name=test", "-c", "user.email=test@test.com", "commit", "-q", "-m", image}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		out, err := cmd.CombinedOutput()
+		assert.NilError(t, err, "Error running git %v: %s", args, out)
+	}
+
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	assert.NilError(t, err, "Error getting commit")
+
+	return strings.TrimSpace(string(out))
+}
+
+func TestMergeImports(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	importsFolderBackup := ImportsFolderPath
+	dependencyFolderBackup := source.DependencyFolderPath
+	ImportsFolderPath = filepath.Join(dir, "imports")
+	source.DependencyFolderPath = filepath.Join(dir, "dependencies")
+	defer func() {
+		ImportsFolderPath = importsFolderBackup
+		source.DependencyFolderPath = dependencyFolderBackup
+	}()
+
+	repoPath := filepath.Join(dir, "repo")
+	firstCommit := commitImportRepo(t, repoPath, "first")
+
+	projectPath := filepath.Join(dir, "project")
+	err = os.MkdirAll(projectPath, 0755)
+	assert.NilError(t, err, "Error creating project directory")
+
+	err = fsutil.WriteToFile([]byte("version: "+latest.Version+"\ndeployments:\n- name: shared\n  kubectl:\n    manifests:\n    - kube/*\n"), filepath.Join(dir, "shared", "deployments.yaml"))
+	assert.NilError(t, err, "Error writing local fragment")
+
+	imports := []*latest.ImportConfig{
+		{Source: &latest.SourceConfig{Git: ptr.String(repoPath)}},
+		{Source: &latest.SourceConfig{Path: ptr.String("../shared/deployments.yaml")}},
+	}
+
+	// The git import is pinned in the lock file
+	config := latest.New().(*latest.Config)
+	sources := map[string]*ValueSource{}
+	err = mergeImports(projectPath, config, imports, sources, log.Discard)
+	assert.NilError(t, err, "Error merging imports")
+	assert.Equal(t, *(*config.Images)["default"].Image, "first")
+	assert.Equal(t, *(*config.Deployments)[0].Name, "shared")
+	assert.Equal(t, sources["images.default.image"].String(), repoPath+":devspace.yaml")
+	assert.Equal(t, sources["deployments[0].name"].String(), "../shared/deployments.yaml")
+
+	lock, err := loadImportsLock(filepath.Join(projectPath, constants.DefaultImportsLockPath))
+	assert.NilError(t, err, "Error loading lock file")
+	assert.DeepEqual(t, lock.Imports, map[string]string{repoPath: firstCommit})
+
+	// New commits are ignored until the lock is updated
+	secondCommit := commitImportRepo(t, repoPath, "second")
+
+	config = latest.New().(*latest.Config)
+	err = mergeImports(projectPath, config, imports, nil, log.Discard)
+	assert.NilError(t, err, "Error merging pinned imports")
+	assert.Equal(t, *(*config.Images)["default"].Image, "first")
+
+	updateImports = true
+	config = latest.New().(*latest.Config)
+	err = mergeImports(projectPath, config, imports, nil, log.Discard)
+	updateImports = false
+	assert.NilError(t, err, "Error updating imports")
+	assert.Equal(t, *(*config.Images)["default"].Image, "second")
+
+	out, err := ioutil.ReadFile(filepath.Join(projectPath, constants.DefaultImportsLockPath))
+	assert.NilError(t, err, "Error reading lock file")
+
+	lock = &ImportsLock{}
+	err = yaml.UnmarshalStrict(out, lock)
+	assert.NilError(t, err, "Error parsing lock file")
+	assert.DeepEqual(t, lock.Imports, map[string]string{repoPath: secondCommit})
+
+	// Removed imports are removed from the lock file
+	err = mergeImports(projectPath, latest.New().(*latest.Config), imports[1:], nil, log.Discard)
+	assert.NilError(t, err, "Error merging local import")
+
+	lock, err = loadImportsLock(filepath.Join(projectPath, constants.DefaultImportsLockPath))
+	assert.NilError(t, err, "Error loading lock file")
+	assert.DeepEqual(t, lock.Imports, map[string]string{})
+}
+
+func TestDownloadImport(t *testing.T) {
+	lock := &ImportsLock{Imports: map[string]string{}}
+	newLock := &ImportsLock{Imports: map[string]string{}}
+
+	_, _, err := downloadImport(".", &latest.ImportConfig{Source: &latest.SourceConfig{}}, lock, newLock, log.Discard)
+	assert.Error(t, err, "source.git or source.path is required")
+
+	importPath, name, err := downloadImport("/project", &latest.ImportConfig{
+		Source: &latest.SourceConfig{Path: ptr.String("../shared")},
+		File:   ptr.String("images.yaml"),
+	}, lock, newLock, log.Discard)
+	assert.NilError(t, err, "Error downloading local import")
+	assert.Equal(t, importPath, filepath.Join(string(filepath.Separator)+"shared", "images.yaml"))
+	assert.Equal(t, name, "../shared/images.yaml")
+	assert.Equal(t, len(newLock.Imports), 0, "Local import was added to the lock")
+
+	assert.Equal(t, source.GetID(&latest.SourceConfig{Git: ptr.String("https://github.com/my-org/repo.git"), Tag: ptr.String("v1.0.0")}), "https://github.com/my-org/repo.git@v1.0.0")
+}
+
+func TestLoadConfigWithImports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte("version: "+latest.Version+"\nimages:\n  default:\n    image: shared\ndeployments:\n- name: shared\n  kubectl:\n    manifests:\n    - kube/*\n"), filepath.Join(dir, "shared", constants.DefaultConfigPath))
+	assert.NilError(t, err, "Error writing fragment")
+	err = fsutil.WriteToFile([]byte("version: "+latest.Version+"\nimports:\n- source:\n    path: ../shared\nimages:\n  default:\n    tag: local\n"), filepath.Join(dir, "project", constants.DefaultConfigPath))
+	assert.NilError(t, err, "Error writing config")
+
+	// The config is merged into the imported fragment
+	generatedConfig := &generated.Config{Configs: map[string]*generated.CacheConfig{}}
+	config, _, err := loadBaseConfigFromPath(filepath.Join(dir, "project"), "", true, generatedConfig, nil, log.Discard)
+	assert.NilError(t, err, "Error loading config")
+	assert.Equal(t, *(*config.Images)["default"].Image, "shared")
+	assert.Equal(t, *(*config.Images)["default"].Tag, "local")
+	assert.Equal(t, *(*config.Deployments)[0].Name, "shared")
+
+	// Imports are not merged if the config is loaded to be changed and saved
+	config, _, err = loadBaseConfigFromPath(filepath.Join(dir, "project"), "", false, generatedConfig, nil, log.Discard)
+	assert.NilError(t, err, "Error loading base config")
+	assert.Assert(t, (*config.Images)["default"].Image == nil, "Imported image was merged into the base config")
+	assert.Assert(t, config.Deployments == nil, "Imported deployments were merged into the base config")
+}
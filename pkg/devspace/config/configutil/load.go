@@ -6,9 +6,11 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/util/git"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/randutil"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
@@ -20,6 +22,7 @@ import (
 	cloudtoken "github.com/devspace-cloud/devspace/pkg/devspace/cloud/token"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/schema"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
@@ -32,6 +35,14 @@ const VarEnvPrefix = "DEVSPACE_VAR_"
 // LoadedVars holds all variables that were loaded
 var LoadedVars = make(map[string]string)
 
+// ResolvedVars holds the values of variables that are not saved in the generated config, i.e. secret variables and
+// variables from sources like files or commands
+var ResolvedVars = make(map[string]string)
+
+// varsMutex guards the variables and the generated config while variables are resolved, because manifests of
+// parallel deployments are rendered concurrently
+var varsMutex sync.Mutex
+
 // PredefinedVars holds all predefined variables that can be used in the config
 var PredefinedVars = map[string]*predefinedVarDefinition{
 	"DEVSPACE_RANDOM": &predefinedVarDefinition{
@@ -160,7 +171,7 @@ func varReplaceFn(path, value string) (interface{}, error) {
 	// Save old value
 	LoadedVars[path] = value
 
-	return vars.ParseString(value, resolveVar)
+	return vars.ParseString(value, resolveTypedVar)
 }
 
 func resolveVar(varName string) (string, error) {
@@ -172,6 +183,11 @@ func resolveVar(varName string) (string, error) {
 		return value, nil
 	}
 
+	// Is resolved from another source or secret?
+	if value, ok := ResolvedVars[varName]; ok {
+		return value, nil
+	}
+
 	// Is in generated config?
 	generatedConfig, err := generated.LoadConfig()
 	if err != nil {
@@ -189,24 +205,97 @@ func resolveVar(varName string) (string, error) {
 		return os.Getenv(varName), nil
 	}
 
-	// Ask for variable
-	currentConfig.Vars[varName] = AskQuestion(&configs.Variable{
+	// Ask for variable. The value is only kept in memory, because variables that are not declared in the vars
+	// could contain secrets
+	value, err = askVariable(&configs.Variable{
+		Name:     ptr.String(varName),
 		Question: ptr.String("Please enter a value for " + varName),
+		Secret:   ptr.Bool(SecretVars[varName]),
 	})
-	err = generated.SaveConfig(generatedConfig)
 	if err != nil {
-		return "", fmt.Errorf("Error saving generated config: %v", err)
+		return "", err
 	}
 
-	return currentConfig.Vars[varName], nil
+	setResolvedVar(varName, value, SecretVars[varName])
+	return value, nil
+}
+
+// ResolveVarsInString replaces all devspace variables in the given string with their values
+func ResolveVarsInString(value string) (string, error) {
+	varsMutex.Lock()
+	defer varsMutex.Unlock()
+
+	resolved, err := vars.ParseString(value, resolveTypedVar)
+	if err != nil {
+		return "", err
+	}
+
+	switch resolved.(type) {
+	case []interface{}, map[interface{}]interface{}:
+		return "", fmt.Errorf("%s is a list or map and cannot be used within a string", value)
+	}
+
+	return fmt.Sprintf("%v", resolved), nil
 }
 
 func varMatchFn(path, key, value string) bool {
 	return vars.VarMatchRegex.MatchString(value)
 }
 
+// GetVars returns the values of all variables that were resolved while loading the config, including the variables that
+// are not saved in the generated config
+func GetVars(cache *generated.CacheConfig) map[string]string {
+	varsMutex.Lock()
+	defer varsMutex.Unlock()
+
+	values := map[string]string{}
+	for name, value := range cache.Vars {
+		values[name] = value
+	}
+	for name, value := range ResolvedVars {
+		values[name] = value
+	}
+
+	return values
+}
+
+// setResolvedVar saves the value of a variable that is not saved in the generated config
+func setResolvedVar(name, value string, isSecret bool) {
+	if isSecret {
+		log.AddSecret(value)
+	}
+
+	ResolvedVars[name] = value
+}
+
+// askVariable asks the user for the value of the variable. In non-interactive mode the default value is used or an
+// error is returned
+func askVariable(variable *configs.Variable) (string, error) {
+	// Resolve variables in the default value
+	if variable.Default != nil && vars.VarMatchRegex.MatchString(*variable.Default) {
+		defaultValue, err := resolveDefault(*variable.Default)
+		if err != nil {
+			return "", errors.Wrapf(err, "resolve default value of variable %s", *variable.Name)
+		}
+
+		copied := *variable
+		copied.Default = &defaultValue
+		variable = &copied
+	}
+
+	if survey.IsNonInteractive() {
+		if variable.Default != nil {
+			return *variable.Default, nil
+		}
+
+		return "", fmt.Errorf("Couldn't find a value for variable %s and cannot ask for it in non-interactive mode. Please set the environment variable %s", *variable.Name, VarEnvPrefix+strings.ToUpper(*variable.Name))
+	}
+
+	return AskQuestion(variable)
+}
+
 // AskQuestion asks the user a question depending on the variable options
-func AskQuestion(variable *configs.Variable) string {
+func AskQuestion(variable *configs.Variable) (string, error) {
 	params := &survey.QuestionOptions{}
 
 	if variable == nil {
@@ -222,10 +311,16 @@ func AskQuestion(variable *configs.Variable) string {
 			params.Question = *variable.Question
 		}
 
+		params.NonInteractiveHint = "Please set the environment variable " + VarEnvPrefix + strings.ToUpper(*variable.Name)
+
 		if variable.Default != nil {
 			params.DefaultValue = *variable.Default
 		}
 
+		if variable.Secret != nil && *variable.Secret {
+			params.IsPassword = true
+		}
+
 		if variable.Options != nil {
 			params.Options = *variable.Options
 		} else if variable.ValidationPattern != nil {
@@ -235,6 +330,14 @@ func AskQuestion(variable *configs.Variable) string {
 				params.ValidationMessage = *variable.ValidationMessage
 			}
 		}
+
+		if variable.Type != nil {
+			name, varType := *variable.Name, *variable.Type
+			params.ValidationFunc = func(value string) error {
+				_, err := convertVar(name, varType, value)
+				return err
+			}
+		}
 	}
 
 	return survey.Question(params)
@@ -257,14 +360,56 @@ func loadConfigFromPath(path string) (*latest.Config, error) {
 		return nil, err
 	}
 
+	err = validateConfigSchema(path, yamlFileContent, oldConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	newConfig, err := versions.Parse(oldConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	err = validateUpgradedConfig(path, oldConfig, newConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return newConfig, nil
 }
 
+// validateConfigSchema validates a config of the latest version against the json schema. Configs of older versions
+// are validated by validateUpgradedConfig after they are converted to the latest version
+func validateConfigSchema(path string, content []byte, rawConfig map[interface{}]interface{}) error {
+	if version, ok := rawConfig["version"].(string); ok == false || version != latest.Version {
+		return nil
+	}
+
+	return schema.ValidateFile(schema.Config(), path, content, rawConfig)
+}
+
+// validateUpgradedConfig validates a config of an older version after it was converted to the latest version. The
+// violations cannot be located in the original file, so they only contain the path in the upgraded config
+func validateUpgradedConfig(path string, rawConfig map[interface{}]interface{}, newConfig *latest.Config) error {
+	version, ok := rawConfig["version"].(string)
+	if ok == false || version == latest.Version {
+		return nil
+	}
+
+	out, err := yaml.Marshal(newConfig)
+	if err != nil {
+		return err
+	}
+
+	upgradedConfig := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(out, upgradedConfig)
+	if err != nil {
+		return err
+	}
+
+	return schema.ValidateFile(schema.Config(), fmt.Sprintf("%s (upgraded from %s to %s)", path, version, latest.Version), nil, upgradedConfig)
+}
+
 func loadConfigFromInterface(m interface{}) (*latest.Config, error) {
 	yamlFileContent, err := yaml.Marshal(m)
 	if err != nil {
@@ -282,11 +427,21 @@ func loadConfigFromInterface(m interface{}) (*latest.Config, error) {
 		return nil, err
 	}
 
+	err = validateConfigSchema("data of config "+LoadedConfig, nil, oldConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	newConfig, err := versions.Parse(oldConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	err = validateUpgradedConfig("data of config "+LoadedConfig, oldConfig, newConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return newConfig, nil
 }
 
@@ -297,6 +452,17 @@ func LoadConfigs(configs *configs.Configs, path string) error {
 		return err
 	}
 
+	rawConfigs := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(yamlFileContent, rawConfigs)
+	if err != nil {
+		return err
+	}
+
+	err = schema.ValidateFile(schema.Configs(), path, yamlFileContent, rawConfigs)
+	if err != nil {
+		return err
+	}
+
 	return yaml.UnmarshalStrict(yamlFileContent, configs)
 }
 
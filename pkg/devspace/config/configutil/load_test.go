@@ -0,0 +1,74 @@
+package configutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestValidateUpgradedConfig(t *testing.T) {
+	rawConfig := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`version: v1beta2
+images:
+  default:
+    image: my-image
+    build:
+      kaniko:
+        snapshotMode: unknown
+`), rawConfig)
+	assert.NilError(t, err, "Error parsing config")
+
+	// Configs of older versions are not validated against the schema before they are upgraded
+	err = validateConfigSchema("devspace.yaml", nil, rawConfig)
+	assert.NilError(t, err, "Error validating config of an older version")
+
+	newConfig, err := versions.Parse(rawConfig)
+	assert.NilError(t, err, "Error upgrading config")
+
+	err = validateUpgradedConfig("devspace.yaml", rawConfig, newConfig)
+	assert.Assert(t, err != nil, "No error for an invalid upgraded config")
+	assert.Assert(t, strings.Contains(err.Error(), "devspace.yaml (upgraded from v1beta2 to v1beta3)"), err.Error())
+	assert.Assert(t, strings.Contains(err.Error(), "images.default.build.kaniko.snapshotMode: invalid value"), err.Error())
+	assert.Assert(t, strings.Contains(err.Error(), "line ") == false, "Violation of an upgraded config has a position: %s", err.Error())
+
+	rawConfig["images"].(map[interface{}]interface{})["default"].(map[interface{}]interface{})["build"] = nil
+	newConfig, err = versions.Parse(rawConfig)
+	assert.NilError(t, err, "Error upgrading config")
+
+	err = validateUpgradedConfig("devspace.yaml", rawConfig, newConfig)
+	assert.NilError(t, err, "Error validating a valid upgraded config")
+}
+
+func TestResolveVarNotSaved(t *testing.T) {
+	resolvedVarsBackup := ResolvedVars
+	nonInteractiveBackup := survey.IsNonInteractive()
+	defer func() {
+		ResolvedVars = resolvedVarsBackup
+		survey.SetNonInteractive(nonInteractiveBackup)
+	}()
+
+	ResolvedVars = map[string]string{}
+	survey.SetNonInteractive(false)
+
+	generatedConfig := &generated.Config{ActiveConfig: generated.DefaultConfigName, Configs: map[string]*generated.CacheConfig{}}
+	generated.InitDevSpaceConfig(generatedConfig, generated.DefaultConfigName)
+	generated.SetTestConfig(generatedConfig)
+
+	// Values of variables that are not declared are asked once, but not saved in the generated config
+	survey.SetNextAnswer("answer")
+	value, err := ResolveVarsInString("${UNDECLARED_TEST_VAR}")
+	assert.NilError(t, err, "Error resolving variable")
+	assert.Equal(t, value, "answer")
+
+	value, err = ResolveVarsInString("${UNDECLARED_TEST_VAR}")
+	assert.NilError(t, err, "Error resolving variable again")
+	assert.Equal(t, value, "answer")
+
+	_, ok := generatedConfig.GetActive().Vars["UNDECLARED_TEST_VAR"]
+	assert.Equal(t, ok, false, "Undeclared variable saved in generated config")
+}
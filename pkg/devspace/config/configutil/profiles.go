@@ -0,0 +1,421 @@
+package configutil
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// selectedProfiles holds the profiles that are applied to the config of the project when it is loaded
+var selectedProfiles []string
+
+// SetProfiles sets the profiles that are applied in the given order to the config of the project when it is loaded
+func SetProfiles(profiles []string) {
+	selectedProfiles = profiles
+}
+
+// List of operations a profile patch can have
+const (
+	PatchOperationAdd     = "add"
+	PatchOperationReplace = "replace"
+	PatchOperationRemove  = "remove"
+)
+
+// pathSegment is a single part of a patch path, either a map key, a list index or a list item selector
+type pathSegment struct {
+	Key string
+
+	// Index is the index of the list item or -1
+	Index int
+
+	// SelectorKey and SelectorValue select the list item with a certain field value, e.g. [name=backend]
+	SelectorKey   string
+	SelectorValue string
+}
+
+func (s *pathSegment) isListSegment() bool {
+	return s.Index >= 0 || s.SelectorKey != ""
+}
+
+func (s *pathSegment) String() string {
+	if s.SelectorKey != "" {
+		return "[" + s.SelectorKey + "=" + s.SelectorValue + "]"
+	} else if s.Index >= 0 {
+		return "[" + strconv.Itoa(s.Index) + "]"
+	}
+
+	return s.Key
+}
+
+// ApplyProfiles applies the patches of the given profiles in the given order to the config and returns the patched
+// and validated config
+func ApplyProfiles(config *latest.Config, profiles []string) (*latest.Config, error) {
+	if len(profiles) == 0 {
+		return config, nil
+	}
+
+	patchedConfig, err := applyProfiles(config, profiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate(patchedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error validating config after applying profiles %s: %v", strings.Join(profiles, ", "), err)
+	}
+
+	return patchedConfig, nil
+}
+
+// applyProfiles applies the patches of the given profiles to the config. If sources is not nil, the values that are
+// changed by a profile get the profile as source
+func applyProfiles(config *latest.Config, profiles []string, sources map[string]*ValueSource) (*latest.Config, error) {
+	configMap := map[interface{}]interface{}{}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(out, configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profileName := range profiles {
+		profile, err := getProfile(config, profileName)
+		if err != nil {
+			return nil, err
+		}
+		if profile.Patches == nil {
+			continue
+		}
+
+		before := map[string]interface{}{}
+		flattenValues(configMap, "", before)
+
+		for index, patch := range *profile.Patches {
+			err = applyPatch(configMap, patch)
+			if err != nil {
+				return nil, fmt.Errorf("Error applying patch %d of profile %s: %v", index, profileName, err)
+			}
+		}
+
+		if sources != nil {
+			after := map[string]interface{}{}
+			flattenValues(configMap, "", after)
+			addProfileSources(sources, before, after, profileName)
+		}
+	}
+
+	out, err = yaml.Marshal(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedConfig := &latest.Config{}
+	err = yaml.UnmarshalStrict(out, patchedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error applying profiles %s: %v", strings.Join(profiles, ", "), err)
+	}
+
+	return patchedConfig, nil
+}
+
+// flattenValues adds all scalar values, empty maps and empty lists within value to values. The keys are the paths of
+// the values in the same format as the paths of the value sources, e.g. deployments[0].helm.chart.name
+func flattenValues(value interface{}, path string, values map[string]interface{}) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		if len(value) == 0 {
+			values[path] = value
+		}
+
+		for key, child := range value {
+			childPath := fmt.Sprintf("%v", key)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+
+			flattenValues(child, childPath, values)
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			values[path] = value
+		}
+
+		for index, child := range value {
+			flattenValues(child, path+"["+strconv.Itoa(index)+"]", values)
+		}
+	default:
+		values[path] = value
+	}
+}
+
+// addProfileSources sets the profile as source of all values that were added or changed by the profile and removes
+// the sources of the removed values
+func addProfileSources(sources map[string]*ValueSource, before, after map[string]interface{}, profile string) {
+	for path, value := range after {
+		if previous, ok := before[path]; ok == false || reflect.DeepEqual(previous, value) == false {
+			sources[path] = &ValueSource{Profile: profile, Override: -1}
+		}
+	}
+
+	for path := range before {
+		if _, ok := after[path]; ok == false {
+			delete(sources, path)
+		}
+	}
+}
+
+func getProfile(config *latest.Config, name string) (*latest.ProfileConfig, error) {
+	availableProfiles := []string{}
+	if config.Profiles != nil {
+		for _, profile := range *config.Profiles {
+			if profile.Name == nil {
+				continue
+			}
+			if *profile.Name == name {
+				return profile, nil
+			}
+
+			availableProfiles = append(availableProfiles, *profile.Name)
+		}
+	}
+
+	return nil, fmt.Errorf("Profile %s couldn't be found. Please select one of the profiles %v", name, availableProfiles)
+}
+
+// applyPatch applies a single patch to the given config map
+func applyPatch(configMap map[interface{}]interface{}, patch *latest.PatchConfig) error {
+	if patch.Operation == nil {
+		return errors.New("op is required")
+	}
+	if patch.Path == nil {
+		return errors.New("path is required")
+	}
+
+	switch *patch.Operation {
+	case PatchOperationAdd, PatchOperationReplace, PatchOperationRemove:
+	default:
+		return fmt.Errorf("Unknown op %s, op has to be one of %s, %s or %s", *patch.Operation, PatchOperationAdd, PatchOperationReplace, PatchOperationRemove)
+	}
+
+	segments, err := parsePatchPath(*patch.Path)
+	if err != nil {
+		return err
+	}
+
+	_, err = patchValue(configMap, segments, 0, *patch.Operation, patch.Value)
+	return err
+}
+
+// patchValue applies the operation to the value at segments[index:] within value and returns the patched value. Lists
+// are returned as new slices if items are added or removed, so the caller has to replace the value
+func patchValue(value interface{}, segments []*pathSegment, index int, operation string, newValue interface{}) (interface{}, error) {
+	segment := segments[index]
+	isLast := index == len(segments)-1
+
+	if segment.isListSegment() == false {
+		if value == nil && operation == PatchOperationAdd {
+			value = map[interface{}]interface{}{}
+		}
+
+		valueMap, ok := value.(map[interface{}]interface{})
+		if ok == false {
+			return nil, fmt.Errorf("%s is not a map", joinPatchPath(segments[:index]))
+		}
+
+		child, exists := valueMap[segment.Key]
+		if isLast {
+			switch operation {
+			case PatchOperationAdd:
+				// Values are appended to existing lists
+				if list, ok := child.([]interface{}); ok {
+					if items, ok := newValue.([]interface{}); ok {
+						valueMap[segment.Key] = append(list, items...)
+					} else {
+						valueMap[segment.Key] = append(list, newValue)
+					}
+				} else {
+					valueMap[segment.Key] = newValue
+				}
+			case PatchOperationReplace:
+				if exists == false {
+					return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments))
+				}
+
+				valueMap[segment.Key] = newValue
+			case PatchOperationRemove:
+				if exists == false {
+					return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments))
+				}
+
+				delete(valueMap, segment.Key)
+			}
+
+			return valueMap, nil
+		}
+
+		if exists == false && operation != PatchOperationAdd {
+			return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments[:index+1]))
+		}
+
+		child, err := patchValue(child, segments, index+1, operation, newValue)
+		if err != nil {
+			return nil, err
+		}
+
+		valueMap[segment.Key] = child
+		return valueMap, nil
+	}
+
+	if value == nil && operation == PatchOperationAdd {
+		value = []interface{}{}
+	}
+
+	list, ok := value.([]interface{})
+	if ok == false {
+		return nil, fmt.Errorf("%s is not a list", joinPatchPath(segments[:index]))
+	}
+
+	itemIndex := segment.Index
+	if segment.SelectorKey != "" {
+		itemIndex = findListItem(list, segment.SelectorKey, segment.SelectorValue)
+		if itemIndex == -1 {
+			return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments[:index+1]))
+		}
+	}
+
+	if isLast {
+		switch operation {
+		case PatchOperationAdd:
+			if segment.SelectorKey != "" {
+				return nil, fmt.Errorf("Cannot add at %s, please use an index or the path of the list to append to", joinPatchPath(segments))
+			}
+			if itemIndex > len(list) {
+				return nil, fmt.Errorf("Cannot add at %s, the list has only %d items", joinPatchPath(segments), len(list))
+			}
+
+			list = append(list, nil)
+			copy(list[itemIndex+1:], list[itemIndex:])
+			list[itemIndex] = newValue
+		case PatchOperationReplace:
+			if itemIndex >= len(list) {
+				return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments))
+			}
+
+			list[itemIndex] = newValue
+		case PatchOperationRemove:
+			if itemIndex >= len(list) {
+				return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments))
+			}
+
+			list = append(list[:itemIndex], list[itemIndex+1:]...)
+		}
+
+		return list, nil
+	}
+
+	if itemIndex >= len(list) {
+		return nil, fmt.Errorf("%s couldn't be found", joinPatchPath(segments[:index+1]))
+	}
+
+	child, err := patchValue(list[itemIndex], segments, index+1, operation, newValue)
+	if err != nil {
+		return nil, err
+	}
+
+	list[itemIndex] = child
+	return list, nil
+}
+
+// findListItem returns the index of the first map in list whose field key has the given value or -1
+func findListItem(list []interface{}, key, value string) int {
+	for index, item := range list {
+		itemMap, ok := item.(map[interface{}]interface{})
+		if ok == false {
+			continue
+		}
+
+		if fieldValue, ok := itemMap[key]; ok && fmt.Sprintf("%v", fieldValue) == value {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// parsePatchPath parses a path like deployments[name=backend].helm.values or dev.ports[0]
+func parsePatchPath(path string) ([]*pathSegment, error) {
+	segments := []*pathSegment{}
+	rest := strings.TrimPrefix(path, ".")
+
+	for rest != "" {
+		if rest[0] == '[' {
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("Invalid path %s: missing ]", path)
+			}
+
+			segment, err := parseListSegment(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid path %s: %v", path, err)
+			}
+
+			segments = append(segments, segment)
+			rest = strings.TrimPrefix(rest[end+1:], ".")
+			continue
+		}
+
+		end := strings.IndexAny(rest, ".[")
+		if end == -1 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("Invalid path %s: empty key", path)
+		}
+
+		segments = append(segments, &pathSegment{Key: rest[:end], Index: -1})
+		rest = strings.TrimPrefix(rest[end:], ".")
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("Invalid path %s: path is empty", path)
+	}
+
+	return segments, nil
+}
+
+func parseListSegment(selector string) (*pathSegment, error) {
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 0 {
+			return nil, fmt.Errorf("negative index %d", index)
+		}
+
+		return &pathSegment{Index: index}, nil
+	}
+
+	splitted := strings.SplitN(selector, "=", 2)
+	if len(splitted) != 2 || splitted[0] == "" {
+		return nil, fmt.Errorf("expected an index or key=value in [%s]", selector)
+	}
+
+	return &pathSegment{Index: -1, SelectorKey: splitted[0], SelectorValue: splitted[1]}, nil
+}
+
+func joinPatchPath(segments []*pathSegment) string {
+	path := ""
+	for _, segment := range segments {
+		if path != "" && segment.isListSegment() == false {
+			path += "."
+		}
+
+		path += segment.String()
+	}
+
+	return path
+}
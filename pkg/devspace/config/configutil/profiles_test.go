@@ -0,0 +1,168 @@
+package configutil
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const profilesTestConfig = `version: v1beta3
+images:
+  default:
+    image: my-image
+deployments:
+- name: frontend
+  kubectl:
+    manifests:
+    - frontend/*
+- name: backend
+  helm:
+    chart:
+      name: backend
+    values:
+      replicas: 1
+- name: debug
+  kubectl:
+    manifests:
+    - debug/*
+profiles:
+- name: production
+  patches:
+  - op: replace
+    path: images.default.image
+    value: my-registry/my-image
+  - op: remove
+    path: deployments[name=debug]
+  - op: replace
+    path: deployments[name=backend].helm.values.replicas
+    value: 3
+  - op: add
+    path: deployments[name=frontend].kubectl.manifests
+    value: production/*
+- name: tracing
+  patches:
+  - op: add
+    path: deployments[0]
+    value:
+      name: jaeger
+      helm:
+        chart:
+          name: jaeger
+  - op: add
+    path: dev.ports
+    value:
+    - labelSelector:
+        app: jaeger
+      forward:
+      - port: 16686
+`
+
+func TestApplyProfiles(t *testing.T) {
+	config := &latest.Config{}
+	err := yaml.UnmarshalStrict([]byte(profilesTestConfig), config)
+	assert.NilError(t, err, "Error parsing config")
+
+	patched, err := ApplyProfiles(config, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, patched, config, "Config changed without profiles")
+
+	patched, err = ApplyProfiles(config, []string{"production", "tracing"})
+	assert.NilError(t, err, "Error applying profiles")
+
+	assert.Equal(t, *(*patched.Images)["default"].Image, "my-registry/my-image")
+	assert.Equal(t, len(*patched.Deployments), 3)
+
+	deploymentNames := []string{}
+	for _, deployment := range *patched.Deployments {
+		deploymentNames = append(deploymentNames, *deployment.Name)
+	}
+	assert.DeepEqual(t, deploymentNames, []string{"jaeger", "frontend", "backend"})
+
+	frontend := (*patched.Deployments)[1]
+	assert.Equal(t, len(*frontend.Kubectl.Manifests), 2)
+	assert.Equal(t, *(*frontend.Kubectl.Manifests)[1], "production/*")
+
+	backend := (*patched.Deployments)[2]
+	assert.Equal(t, (*backend.Helm.Values)["replicas"], 3)
+
+	assert.Equal(t, len(*patched.Dev.Ports), 1)
+	assert.Equal(t, *(*(*patched.Dev.Ports)[0].PortMappings)[0].LocalPort, 16686)
+
+	// The original config is not changed
+	assert.Equal(t, *(*config.Images)["default"].Image, "my-image")
+	assert.Equal(t, len(*config.Deployments), 3)
+}
+
+func TestApplyProfilesSources(t *testing.T) {
+	config := &latest.Config{}
+	err := yaml.UnmarshalStrict([]byte(profilesTestConfig), config)
+	assert.NilError(t, err, "Error parsing config")
+
+	sources := map[string]*ValueSource{}
+	err = addSources(sources, config, &ValueSource{File: "devspace.yaml", Override: -1}, nil)
+	assert.NilError(t, err, "Error adding sources")
+
+	_, err = applyProfiles(config, []string{"production"}, sources)
+	assert.NilError(t, err, "Error applying profiles")
+
+	assert.Equal(t, sources["images.default.image"].String(), "profile production")
+	assert.Equal(t, sources["deployments[1].helm.values.replicas"].String(), "profile production")
+	assert.Equal(t, sources["deployments[0].kubectl.manifests[1]"].String(), "profile production")
+	assert.Equal(t, sources["deployments[0].kubectl.manifests[0]"].String(), "devspace.yaml")
+	assert.Assert(t, sources["deployments[2].name"] == nil, "Source of removed deployment not removed")
+}
+
+func TestApplyProfilesErrors(t *testing.T) {
+	testCases := map[string]struct {
+		patch         string
+		expectedError string
+	}{
+		"unknown op": {
+			patch:         "op: merge\n    path: images",
+			expectedError: "Error applying patch 0 of profile test: Unknown op merge, op has to be one of add, replace or remove",
+		},
+		"missing item": {
+			patch:         "op: replace\n    path: deployments[name=missing].helm\n    value: {}",
+			expectedError: "Error applying patch 0 of profile test: deployments[name=missing] couldn't be found",
+		},
+		"missing key": {
+			patch:         "op: remove\n    path: images.other",
+			expectedError: "Error applying patch 0 of profile test: images.other couldn't be found",
+		},
+		"invalid path": {
+			patch:         "op: remove\n    path: deployments[0",
+			expectedError: "Error applying patch 0 of profile test: Invalid path deployments[0: missing ]",
+		},
+		"add with selector": {
+			patch:         "op: add\n    path: deployments[name=frontend]\n    value: {}",
+			expectedError: "Error applying patch 0 of profile test: Cannot add at deployments[name=frontend], please use an index or the path of the list to append to",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		config := &latest.Config{}
+		err := yaml.UnmarshalStrict([]byte(`version: v1beta3
+images:
+  default:
+    image: my-image
+deployments:
+- name: frontend
+  kubectl:
+    manifests:
+    - frontend/*
+profiles:
+- name: test
+  patches:
+  - `+testCase.patch+"\n"), config)
+		assert.NilError(t, err, "Error parsing config in test case %s", testName)
+
+		_, err = ApplyProfiles(config, []string{"test"})
+		assert.Error(t, err, testCase.expectedError, "Wrong error in test case %s", testName)
+	}
+
+	_, err := ApplyProfiles(&latest.Config{}, []string{"missing"})
+	assert.Error(t, err, "Profile missing couldn't be found. Please select one of the profiles []")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
+	"github.com/devspace-cloud/devspace/pkg/util/vars"
+	"github.com/devspace-cloud/devspace/pkg/util/yamlutil"
 	"github.com/pkg/errors"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
@@ -66,8 +68,16 @@ func RestoreVars(config *latest.Config) (*latest.Config, error) {
 	return clonedConfig, nil
 }
 
-// SaveLoadedConfig writes the data of a config to its yaml file
+// SaveLoadedConfig writes the data of a config to its yaml file. If possible, only the values that were changed are
+// written, so that the variables, the comments and the formatting of the file are kept
 func SaveLoadedConfig() error {
+	saved, err := updateConfigFile(config)
+	if err != nil {
+		return err
+	} else if saved {
+		return nil
+	}
+
 	// RestoreVars restores the variables in the config
 	clonedConfig, err := RestoreVars(config)
 	if err != nil {
@@ -77,6 +87,81 @@ func SaveLoadedConfig() error {
 	return SaveConfig(clonedConfig)
 }
 
+// updateConfigFile changes only the values of the config file that differ from the config. It returns false if the file
+// cannot be changed partially, e.g. because it does not exist yet, has an older config version or uses yaml features like
+// anchors
+func updateConfigFile(config *latest.Config) (bool, error) {
+	savePath := constants.DefaultConfigPath
+	dataPath := []interface{}{}
+
+	if LoadedConfig != "" {
+		configs := configs.Configs{}
+
+		err := LoadConfigs(&configs, constants.DefaultConfigsPath)
+		if err != nil {
+			return false, fmt.Errorf("Error loading %s: %v", constants.DefaultConfigsPath, err)
+		}
+
+		configDefinition := configs[LoadedConfig]
+		if configDefinition.Config.Data != nil {
+			savePath = constants.DefaultConfigsPath
+			dataPath = []interface{}{LoadedConfig, "config", "data"}
+		} else {
+			savePath = *configDefinition.Config.Path
+		}
+	}
+
+	content, err := ioutil.ReadFile(savePath)
+	if err != nil {
+		return false, nil
+	}
+
+	// Configs of older versions are converted by rewriting the whole file
+	rawConfig := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(content, &rawConfig)
+	if err != nil {
+		return false, nil
+	}
+	for _, key := range dataPath {
+		rawConfig, _ = rawConfig[key].(map[interface{}]interface{})
+	}
+	if version, _ := rawConfig["version"].(string); version != latest.Version {
+		return false, nil
+	}
+
+	newConfig := yaml.MapSlice{}
+	err = util.Convert(config, &newConfig)
+	if err != nil {
+		return false, errors.Wrap(err, "convert config")
+	}
+
+	document := yamlutil.NewDocument(content)
+	err = document.Update(dataPath, newConfig, resolveConfigString)
+	if err != nil {
+		return false, nil
+	}
+
+	err = ioutil.WriteFile(savePath, document.Bytes(), os.ModePerm)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// resolveConfigString resolves the variables in a string of the config file, so that it can be compared with the loaded
+// config
+func resolveConfigString(value string) (interface{}, error) {
+	if varMatchFn("", "", value) == false {
+		return value, nil
+	}
+
+	varsMutex.Lock()
+	defer varsMutex.Unlock()
+
+	return vars.ParseString(value, resolveTypedVar)
+}
+
 // SaveConfig saves the config to file
 func SaveConfig(config *latest.Config) error {
 	// Convert to string
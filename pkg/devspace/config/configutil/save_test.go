@@ -61,7 +61,7 @@ func TestSaveLoadedConfig(t *testing.T) {
 		}
 	}()
 
-	configString := `version: v1beta2
+	configString := `version: v1beta3
 cluster:
   kubeContext: someKubeContext
   namespace: someNS
@@ -169,7 +169,11 @@ dev-service1:
 	assert.NilError(t, err, "Error saving loaded config")
 	configContent, err := fsutil.ReadFile(constants.DefaultConfigPath, -1)
 	assert.NilError(t, err, "Error reading config file after save. Maybe it was not saved")
-	expectedContent := `version: v1beta2
+	// Only the changed values are written and the order of the file is kept
+	expectedContent := `version: v1beta3
+cluster:
+  kubeContext: someKubeContext
+  namespace: someNS
 images:
   default:
     image: defaultImage
@@ -178,15 +182,16 @@ deployments:
   helm:
     chart:
       name: ./chart
+hooks:
+- command: echo
 dev:
+  selectors:
+  - name: someSelector
   overrideImages:
   - name: service-image-1
     entrypoint:
     - sleep
     - "9999999999"
-  terminal:
-    labelSelector:
-      app.kubernetes.io/component: service-1
   ports:
   - labelSelector:
       app.kubernetes.io/component: service-1
@@ -196,14 +201,66 @@ dev:
   - labelSelector:
       app.kubernetes.io/component: service-1
     localSubPath: ./service1
-  selectors:
-  - name: someSelector
-    labelSelector: null
-hooks:
-- command: echo
-cluster:
-  kubeContext: someKubeContext
-  namespace: LoadedNS
+  terminal:
+    labelSelector:
+      app.kubernetes.io/component: service-1
 `
 	assert.Equal(t, expectedContent, string(configContent), "Config differently saved than loaded")
 }
+
+func TestSaveLoadedConfigKeepsComments(t *testing.T) {
+	configBackup := config
+	defer func() { config = configBackup }()
+
+	dir, err := ioutil.TempDir("", "testDir")
+	assert.NilError(t, err, "Error creating temporary directory")
+
+	wdBackup, err := os.Getwd()
+	assert.NilError(t, err, "Error getting current working directory")
+	err = os.Chdir(dir)
+	assert.NilError(t, err, "Error changing working directory")
+	defer func() {
+		os.Chdir(wdBackup)
+		os.RemoveAll(dir)
+	}()
+
+	configString := `# Deploys the app
+version: v1beta3
+images:
+  default:
+    image: ${IMAGE} # pushed by the CI
+deployments:
+- name: app
+  helm:
+    chart:
+      name: ./chart
+dev:
+  ports:
+  - labelSelector:
+      app: default
+    forward:
+    - port: 3000 # the web server
+`
+	err = fsutil.WriteToFile([]byte(configString), constants.DefaultConfigPath)
+	assert.NilError(t, err, "Error writing config")
+
+	generatedConfig := &generated.Config{ActiveConfig: generated.DefaultConfigName, Configs: map[string]*generated.CacheConfig{}}
+	generated.InitDevSpaceConfig(generatedConfig, generated.DefaultConfigName)
+	generatedConfig.GetActive().Vars["IMAGE"] = "my-image"
+	generated.SetTestConfig(generatedConfig)
+
+	getConfigOnce = sync.Once{}
+	config = GetBaseConfig()
+	assert.Equal(t, *(*config.Images)["default"].Image, "my-image")
+
+	// Add a port like devspace add port
+	portMappings := (*config.Dev.Ports)[0].PortMappings
+	*portMappings = append(*portMappings, &latest.PortMapping{LocalPort: ptr.Int(8080)})
+
+	err = SaveLoadedConfig()
+	assert.NilError(t, err, "Error saving loaded config")
+
+	configContent, err := fsutil.ReadFile(constants.DefaultConfigPath, -1)
+	assert.NilError(t, err, "Error reading config file")
+	assert.Equal(t, string(configContent), configString+"    - port: 8080\n")
+}
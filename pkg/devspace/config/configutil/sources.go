@@ -0,0 +1,189 @@
+package configutil
+
+import (
+	"fmt"
+	"strings"
+
+	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/vars"
+)
+
+// SecretVars holds the names of all loaded variables that are marked as secret
+var SecretVars = make(map[string]bool)
+
+// ValueSource describes where a value of the loaded config was defined
+type ValueSource struct {
+	// File is the config file the value was loaded from
+	File string
+
+	// Override is the index of the override in devspace-configs.yaml the value was loaded from or -1
+	Override int
+
+	// Space is the name of the space the value was taken from (only for the kube context)
+	Space string
+
+	// Profile is the name of the profile that changed the value
+	Profile string
+
+	// Vars holds the names of the variables that were used in the value
+	Vars []string
+}
+
+// String returns a short description of the source, e.g. devspace-configs.yaml (override 0) via ${IMAGE}
+func (s *ValueSource) String() string {
+	str := s.File
+	if s.Space != "" {
+		str = "space " + s.Space
+	} else if s.Profile != "" {
+		str = "profile " + s.Profile
+	}
+	if s.Override >= 0 {
+		str += fmt.Sprintf(" (override %d)", s.Override)
+	}
+	if len(s.Vars) > 0 {
+		names := make([]string, 0, len(s.Vars))
+		for _, name := range s.Vars {
+			names = append(names, "${"+name+"}")
+		}
+
+		str += " via " + strings.Join(names, ", ")
+	}
+
+	return str
+}
+
+// IsSecret returns true if a variable that is marked as secret was used in the value
+func (s *ValueSource) IsSecret() bool {
+	for _, name := range s.Vars {
+		if SecretVars[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetConfigWithSources loads the config with the overrides in the same way as GetConfigFromPath and additionally returns the
+// source of every value. The keys of the returned map are the paths of the values, e.g. deployments[0].helm.chart.name
+func GetConfigWithSources(basePath string, loadConfig string, generatedConfig *generated.Config, log log.Logger) (*latest.Config, map[string]*ValueSource, error) {
+	sources := map[string]*ValueSource{}
+
+	config, _, err := loadBaseConfigFromPath(basePath, loadConfig, true, generatedConfig, sources, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = validate(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error validating config in %s: %v", basePath, err)
+	}
+
+	return config, sources, nil
+}
+
+// collectLoadedVars executes load and returns the variables that were replaced while loading. The replaced
+// variables are still added to LoadedVars
+func collectLoadedVars(load func() error) (map[string]string, error) {
+	loadedVars := LoadedVars
+	LoadedVars = make(map[string]string)
+
+	err := load()
+
+	layerVars := LoadedVars
+	LoadedVars = loadedVars
+	for path, value := range layerVars {
+		LoadedVars[path] = value
+	}
+
+	return layerVars, err
+}
+
+// wrapperSource returns the source of the values of a config wrapper
+func wrapperSource(configsPath string, configWrapper *configspkg.ConfigWrapper, override int) *ValueSource {
+	if configWrapper.Path != nil {
+		return &ValueSource{File: *configWrapper.Path, Override: override}
+	}
+
+	return &ValueSource{File: configsPath, Override: override}
+}
+
+// addSources adds the source of all values of the config layer to sources. Later layers overwrite the sources of
+// earlier layers in the same way as they are merged, i.e. arrays are replaced and maps are merged
+func addSources(sources map[string]*ValueSource, layer *latest.Config, source *ValueSource, layerVars map[string]string) error {
+	if sources == nil {
+		return nil
+	}
+
+	layerMap := map[interface{}]interface{}{}
+	err := util.Convert(layer, &layerMap)
+	if err != nil {
+		return err
+	}
+
+	addValueSources(sources, layerMap, "", source, layerVars)
+	return nil
+}
+
+func addValueSources(sources map[string]*ValueSource, value interface{}, path string, source *ValueSource, layerVars map[string]string) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range value {
+			childPath := fmt.Sprintf("%v", key)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+
+			addValueSources(sources, child, childPath, source, layerVars)
+		}
+	case []interface{}:
+		// Arrays are replaced, so the sources of the previous array items are removed
+		for sourcePath := range sources {
+			if strings.HasPrefix(sourcePath, path+"[") {
+				delete(sources, sourcePath)
+			}
+		}
+
+		for index, child := range value {
+			addValueSources(sources, child, fmt.Sprintf("%s[%d]", path, index), source, layerVars)
+		}
+	case nil:
+		// Nil values are not merged, so they keep the source of the previous layer
+	default:
+		valueSource := *source
+		valueSource.Vars = nil
+
+		if original, ok := findLoadedVar(layerVars, path); ok {
+			for _, match := range vars.VarMatchRegex.FindAllString(original, -1) {
+				if strings.HasPrefix(match, "$$") == false {
+					valueSource.Vars = append(valueSource.Vars, match[2:len(match)-1])
+				}
+			}
+		}
+
+		sources[path] = &valueSource
+	}
+}
+
+// findLoadedVar returns the original value of the path or of the closest parent path, because a variable can also be
+// replaced by a whole list or map
+func findLoadedVar(layerVars map[string]string, path string) (string, bool) {
+	for path != "" {
+		// The var paths start with a dot, e.g. .deployments[0].name
+		if original, ok := layerVars["."+path]; ok {
+			return original, true
+		}
+
+		idx := strings.LastIndexAny(path, ".[")
+		if idx == -1 {
+			break
+		}
+
+		path = path[:idx]
+	}
+
+	return "", false
+}
@@ -0,0 +1,62 @@
+package configutil
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+func TestAddSources(t *testing.T) {
+	secretVarsBackup := SecretVars
+	SecretVars = map[string]bool{"PASSWORD": true}
+	defer func() { SecretVars = secretVarsBackup }()
+
+	sources := map[string]*ValueSource{}
+
+	base := &latest.Config{
+		Version: ptr.String(latest.Version),
+		Deployments: &[]*latest.DeploymentConfig{
+			{Name: ptr.String("first")},
+			{Name: ptr.String("second")},
+		},
+		Dev: &latest.DevConfig{
+			OverrideImages: &[]*latest.ImageOverrideConfig{
+				{Name: ptr.String("default"), Entrypoint: &[]*string{ptr.String("${PASSWORD}")}},
+				{Name: ptr.String("other"), Entrypoint: &[]*string{ptr.String("run"), ptr.String("fast")}},
+			},
+		},
+	}
+	err := addSources(sources, base, &ValueSource{File: "devspace.yaml", Override: -1}, map[string]string{
+		".dev.overrideImages[0].entrypoint[0]": "${PASSWORD}",
+		".dev.overrideImages[1].entrypoint":    "${ENTRYPOINT}",
+	})
+	assert.NilError(t, err, "Error adding base sources")
+
+	override := &latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			{Name: ptr.String("${NAME}-$${ESCAPED}")},
+		},
+	}
+	err = addSources(sources, override, &ValueSource{File: "devspace-configs.yaml", Override: 0}, map[string]string{
+		".deployments[0].name": "${NAME}-$${ESCAPED}",
+	})
+	assert.NilError(t, err, "Error adding override sources")
+
+	assert.Equal(t, sources["version"].String(), "devspace.yaml")
+	assert.Equal(t, sources["deployments[0].name"].String(), "devspace-configs.yaml (override 0) via ${NAME}")
+	assert.Equal(t, sources["deployments[0].name"].IsSecret(), false)
+	assert.Assert(t, sources["deployments[1].name"] == nil, "Source of replaced array item was not removed")
+
+	entrypoint := sources["dev.overrideImages[0].entrypoint[0]"]
+	assert.Equal(t, entrypoint.String(), "devspace.yaml via ${PASSWORD}")
+	assert.Equal(t, entrypoint.IsSecret(), true)
+
+	// The items of a list variable have the source of the variable
+	assert.Equal(t, sources["dev.overrideImages[1].entrypoint[1]"].String(), "devspace.yaml via ${ENTRYPOINT}")
+
+	space := &ValueSource{Space: "my-space", Override: -1}
+	assert.Equal(t, space.String(), "space my-space")
+}
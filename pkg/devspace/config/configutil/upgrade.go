@@ -0,0 +1,329 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// BackupSuffix is appended to the path of a config file to get the path of its backup
+const BackupSuffix = ".bak"
+
+// varPlaceholderBase is the first placeholder a variable is replaced with while a config is upgraded. Integers are used,
+// because they can be loaded into string and integer fields
+const varPlaceholderBase = 1000000000
+
+// UpgradedFile is a config file that was converted to the latest config version
+type UpgradedFile struct {
+	Path     string
+	Original []byte
+	Upgraded []byte
+}
+
+// Save writes the upgraded config file and keeps the original file with the suffix .bak as backup
+func (f *UpgradedFile) Save() error {
+	stat, err := os.Stat(f.Path)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(f.Path+BackupSuffix, f.Original, stat.Mode())
+	if err != nil {
+		return errors.Wrap(err, "write backup")
+	}
+
+	return ioutil.WriteFile(f.Path, f.Upgraded, stat.Mode())
+}
+
+// UpgradeConfigFiles converts devspace.yaml, devspace-configs.yaml and all config files referenced in devspace-configs.yaml
+// to the latest config version. Variables, the key order and the comments at the beginning of the files are kept, other
+// comments are lost. Only the files that change are returned
+func UpgradeConfigFiles(basePath string) ([]*UpgradedFile, error) {
+	var (
+		upgradedFiles = []*UpgradedFile{}
+		configPaths   = []string{}
+		configPath    = filepath.Join(basePath, constants.DefaultConfigPath)
+		configsPath   = filepath.Join(basePath, constants.DefaultConfigsPath)
+	)
+
+	_, err := os.Stat(configsPath)
+	if err == nil {
+		upgradedFile, referencedPaths, err := upgradeConfigsFile(configsPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "upgrade %s", configsPath)
+		} else if upgradedFile != nil {
+			upgradedFiles = append(upgradedFiles, upgradedFile)
+		}
+
+		for _, path := range referencedPaths {
+			configPaths = append(configPaths, filepath.Join(basePath, filepath.FromSlash(path)))
+		}
+	}
+
+	_, err = os.Stat(configPath)
+	if err == nil {
+		configPaths = append([]string{configPath}, configPaths...)
+	}
+
+	upgradedPaths := map[string]bool{}
+	for _, path := range configPaths {
+		path = filepath.Clean(path)
+		if upgradedPaths[path] {
+			continue
+		}
+
+		upgradedPaths[path] = true
+		upgradedFile, err := upgradeConfigFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "upgrade %s", path)
+		} else if upgradedFile != nil {
+			upgradedFiles = append(upgradedFiles, upgradedFile)
+		}
+	}
+
+	return upgradedFiles, nil
+}
+
+// upgradeConfigFile upgrades a config file and returns nil if it already has the latest version
+func upgradeConfigFile(path string) (*UpgradedFile, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConfig := yaml.MapSlice{}
+	err = yaml.Unmarshal(content, &rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedConfig, changed, err := upgradeConfigData(rawConfig)
+	if err != nil {
+		return nil, err
+	} else if changed == false {
+		return nil, nil
+	}
+
+	return newUpgradedFile(path, content, upgradedConfig)
+}
+
+// upgradeConfigsFile upgrades the inline configs of devspace-configs.yaml and returns the paths of all referenced configs
+func upgradeConfigsFile(path string) (*UpgradedFile, []string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawConfigs := yaml.MapSlice{}
+	err = yaml.Unmarshal(content, &rawConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := false
+	paths := []string{}
+	for _, configItem := range rawConfigs {
+		configDefinition, ok := configItem.Value.(yaml.MapSlice)
+		if ok == false {
+			continue
+		}
+
+		wrappers := []interface{}{}
+		for _, field := range configDefinition {
+			if field.Key == "config" {
+				wrappers = append(wrappers, field.Value)
+			} else if overrides, ok := field.Value.([]interface{}); ok && field.Key == "overrides" {
+				wrappers = append(wrappers, overrides...)
+			}
+		}
+
+		for _, wrapper := range wrappers {
+			wrapperFields, ok := wrapper.(yaml.MapSlice)
+			if ok == false {
+				continue
+			}
+
+			for idx, field := range wrapperFields {
+				if path, ok := field.Value.(string); ok && field.Key == "path" {
+					paths = append(paths, path)
+				} else if data, ok := field.Value.(yaml.MapSlice); ok && field.Key == "data" {
+					upgradedData, dataChanged, err := upgradeConfigData(data)
+					if err != nil {
+						return nil, nil, errors.Wrapf(err, "upgrade data of config %v", configItem.Key)
+					}
+
+					wrapperFields[idx].Value = upgradedData
+					changed = changed || dataChanged
+				}
+			}
+		}
+	}
+
+	if changed == false {
+		return nil, paths, nil
+	}
+
+	upgradedFile, err := newUpgradedFile(path, content, rawConfigs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return upgradedFile, paths, nil
+}
+
+// upgradeConfigData converts the config to the latest version. The variables are replaced with placeholders while the
+// config is upgraded, because the config is not valid if a variable is used for a value that is not a string
+func upgradeConfigData(rawConfig yaml.MapSlice) (yaml.MapSlice, bool, error) {
+	version := ""
+	for _, item := range rawConfig {
+		if item.Key == "version" {
+			version, _ = item.Value.(string)
+		}
+	}
+
+	// Configs without a version are loaded as the latest version
+	if version == "" || version == latest.Version {
+		return rawConfig, false, nil
+	}
+
+	data := map[interface{}]interface{}{}
+	err := util.Convert(rawConfig, &data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	placeholders := map[string]string{}
+	err = walk.Walk(data, varMatchFn, func(path, value string) (interface{}, error) {
+		placeholder := varPlaceholderBase + len(placeholders)
+		placeholders[strconv.Itoa(placeholder)] = value
+		return placeholder, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	newConfig, err := versions.Parse(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	upgradedConfig := yaml.MapSlice{}
+	err = util.Convert(newConfig, &upgradedConfig)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return orderLike(rawConfig, restorePlaceholders(upgradedConfig, placeholders)).(yaml.MapSlice), true, nil
+}
+
+// restorePlaceholders replaces the placeholders in the upgraded config with the original values
+func restorePlaceholders(value interface{}, placeholders map[string]string) interface{} {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		for idx, item := range value {
+			value[idx].Value = restorePlaceholders(item.Value, placeholders)
+		}
+	case []interface{}:
+		for idx, item := range value {
+			value[idx] = restorePlaceholders(item, placeholders)
+		}
+	case int:
+		if original, ok := placeholders[strconv.Itoa(value)]; ok {
+			return original
+		}
+	case string:
+		for placeholder, original := range placeholders {
+			value = strings.Replace(value, placeholder, original, -1)
+		}
+
+		return value
+	}
+
+	return value
+}
+
+// orderLike sorts the keys of the upgraded value in the order of the original value. Keys that are new are added at the end,
+// unless they are empty, e.g. dev: {}
+func orderLike(original, upgraded interface{}) interface{} {
+	switch upgraded := upgraded.(type) {
+	case yaml.MapSlice:
+		originalMap, ok := original.(yaml.MapSlice)
+		if ok == false {
+			return upgraded
+		}
+
+		ordered := yaml.MapSlice{}
+		added := map[interface{}]bool{}
+		for _, originalItem := range originalMap {
+			for _, item := range upgraded {
+				if item.Key == originalItem.Key {
+					ordered = append(ordered, yaml.MapItem{Key: item.Key, Value: orderLike(originalItem.Value, item.Value)})
+					added[item.Key] = true
+					break
+				}
+			}
+		}
+		for _, item := range upgraded {
+			if added[item.Key] == false {
+				if emptyMap, ok := item.Value.(yaml.MapSlice); ok && len(emptyMap) == 0 {
+					continue
+				}
+
+				ordered = append(ordered, item)
+			}
+		}
+
+		return ordered
+	case []interface{}:
+		originalList, ok := original.([]interface{})
+		if ok == false {
+			return upgraded
+		}
+
+		for idx := range upgraded {
+			if idx < len(originalList) {
+				upgraded[idx] = orderLike(originalList[idx], upgraded[idx])
+			}
+		}
+	}
+
+	return upgraded
+}
+
+func newUpgradedFile(path string, original []byte, upgraded yaml.MapSlice) (*UpgradedFile, error) {
+	out, err := yaml.Marshal(upgraded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradedFile{
+		Path:     path,
+		Original: original,
+		Upgraded: append([]byte(leadingComments(string(original))), out...),
+	}, nil
+}
+
+// leadingComments returns the comment lines at the beginning of the file
+func leadingComments(content string) string {
+	comments := ""
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && strings.HasPrefix(trimmed, "#") == false {
+			break
+		}
+
+		comments += line
+	}
+
+	return comments
+}
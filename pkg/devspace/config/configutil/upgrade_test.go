@@ -0,0 +1,130 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const testOldConfig = `# My project
+version: v1beta2
+deployments:
+- name: chart
+  helm:
+    chart:
+      name: ./chart
+- name: app
+  component:
+    containers:
+    - image: ${IMAGE}:dev
+    service:
+      ports:
+      - port: ${PORT}
+images:
+  default:
+    image: my-image
+`
+
+const testUpgradedConfig = `# My project
+version: v1beta3
+deployments:
+- name: chart
+  helm:
+    chart:
+      name: ./chart
+    devSpaceValues: true
+- name: app
+  component:
+    containers:
+    - image: ${IMAGE}:dev
+    service:
+      ports:
+      - port: ${PORT}
+images:
+  default:
+    image: my-image
+`
+
+const testOldConfigs = `default:
+  config:
+    path: devspace.yaml
+  overrides:
+  - path: override.yaml
+  - data:
+      deployments:
+      - name: chart
+inline:
+  config:
+    data:
+      version: v1beta2
+      deployments:
+      - name: inline
+        helm:
+          chart:
+            name: ./chart
+`
+
+func TestUpgradeConfigFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-upgrade")
+	assert.NilError(t, err, "Error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"devspace.yaml":         testOldConfig,
+		"devspace-configs.yaml": testOldConfigs,
+		"override.yaml":         "version: v1beta3\n",
+	}
+	for name, content := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err, "Error writing %s", name)
+	}
+
+	upgradedFiles, err := UpgradeConfigFiles(dir)
+	assert.NilError(t, err, "Error upgrading config files")
+	assert.Equal(t, len(upgradedFiles), 2)
+
+	// The inline configs of devspace-configs.yaml are upgraded as well
+	assert.Equal(t, upgradedFiles[0].Path, filepath.Join(dir, "devspace-configs.yaml"))
+	assert.Equal(t, string(upgradedFiles[0].Upgraded), `default:
+  config:
+    path: devspace.yaml
+  overrides:
+  - path: override.yaml
+  - data:
+      deployments:
+      - name: chart
+inline:
+  config:
+    data:
+      version: v1beta3
+      deployments:
+      - name: inline
+        helm:
+          chart:
+            name: ./chart
+          devSpaceValues: true
+`)
+
+	// Variables, key order and the leading comments are kept
+	assert.Equal(t, upgradedFiles[1].Path, filepath.Join(dir, "devspace.yaml"))
+	assert.Equal(t, string(upgradedFiles[1].Original), testOldConfig)
+	assert.Equal(t, string(upgradedFiles[1].Upgraded), testUpgradedConfig)
+
+	err = upgradedFiles[1].Save()
+	assert.NilError(t, err, "Error saving upgraded file")
+
+	backup, err := ioutil.ReadFile(filepath.Join(dir, "devspace.yaml"+BackupSuffix))
+	assert.NilError(t, err, "Error reading backup")
+	assert.Equal(t, string(backup), testOldConfig)
+
+	// Config files that already have the latest version are not changed
+	err = upgradedFiles[0].Save()
+	assert.NilError(t, err, "Error saving upgraded file")
+
+	upgradedFiles, err = UpgradeConfigFiles(dir)
+	assert.NilError(t, err, "Error upgrading config files again")
+	assert.Equal(t, len(upgradedFiles), 0)
+}
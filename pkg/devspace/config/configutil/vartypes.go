@@ -0,0 +1,110 @@
+package configutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/util/vars"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// VarTypes holds the types of all loaded variables that have an explicit type
+var VarTypes = make(map[string]configs.VariableType)
+
+// convertVar converts the value of a variable to the given type. Lists and maps have to be in yaml format, e.g. [a, b]
+// or {a: b}. The value is not part of the error messages, because it could be a secret
+func convertVar(name string, varType configs.VariableType, value string) (interface{}, error) {
+	switch varType {
+	case configs.VariableTypeString:
+		return value, nil
+	case configs.VariableTypeInt:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("Variable %s has to be an integer", name)
+		}
+
+		return i, nil
+	case configs.VariableTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("Variable %s has to be a boolean (true or false)", name)
+		}
+
+		return b, nil
+	case configs.VariableTypeList:
+		list := []interface{}{}
+		err := yaml.Unmarshal([]byte(value), &list)
+		if err != nil {
+			return nil, fmt.Errorf("Variable %s has to be a list, e.g. [a, b]", name)
+		}
+
+		return list, nil
+	case configs.VariableTypeMap:
+		m := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(value), &m)
+		if err != nil {
+			return nil, fmt.Errorf("Variable %s has to be a map, e.g. {a: b}", name)
+		}
+
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("Variable %s has the unknown type %s, please use one of string, int, bool, list or map", name, varType)
+}
+
+// validateVar checks that the value can be converted to the type of the variable
+func validateVar(variable *configs.Variable, value string) error {
+	if variable.Type == nil {
+		return nil
+	}
+
+	_, err := convertVar(*variable.Name, *variable.Type, value)
+	return err
+}
+
+// resolveTypedVar resolves the variable and converts the value to the type of the variable if it has one
+func resolveTypedVar(varName string) (interface{}, bool, error) {
+	value, err := resolveVar(varName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	varType, ok := VarTypes[varName]
+	if ok == false {
+		return value, false, nil
+	}
+
+	converted, err := convertVar(varName, varType, value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return converted, true, nil
+}
+
+// resolveDefault replaces the variables in the default value of a variable, e.g. ${DEVSPACE_GIT_COMMIT}
+func resolveDefault(defaultValue string) (string, error) {
+	resolved, err := vars.ParseString(defaultValue, resolveTypedVar)
+	if err != nil {
+		return "", err
+	}
+
+	return formatVar(resolved)
+}
+
+// formatVar returns the value of a variable as string, lists and maps are formatted as yaml
+func formatVar(value interface{}) (string, error) {
+	switch value.(type) {
+	case []interface{}, map[interface{}]interface{}:
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
@@ -0,0 +1,98 @@
+package configutil
+
+import (
+	"testing"
+
+	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	"gotest.tools/assert"
+)
+
+func TestConvertVar(t *testing.T) {
+	testCases := []struct {
+		varType       configspkg.VariableType
+		value         string
+		expected      interface{}
+		expectedError string
+	}{
+		{varType: configspkg.VariableTypeString, value: "0123", expected: "0123"},
+		{varType: configspkg.VariableTypeInt, value: "0123", expected: 123},
+		{varType: configspkg.VariableTypeInt, value: "1.0", expectedError: "Variable TEST has to be an integer"},
+		{varType: configspkg.VariableTypeBool, value: "true", expected: true},
+		{varType: configspkg.VariableTypeBool, value: "yes", expectedError: "Variable TEST has to be a boolean (true or false)"},
+		{varType: configspkg.VariableTypeList, value: "[a, 1]", expected: []interface{}{"a", 1}},
+		{varType: configspkg.VariableTypeList, value: "{a: b}", expectedError: "Variable TEST has to be a list, e.g. [a, b]"},
+		{varType: configspkg.VariableTypeMap, value: "{a: b}", expected: map[interface{}]interface{}{"a": "b"}},
+		{varType: configspkg.VariableTypeMap, value: "[a, b]", expectedError: "Variable TEST has to be a map, e.g. {a: b}"},
+		{varType: "float", value: "1.0", expectedError: "Variable TEST has the unknown type float, please use one of string, int, bool, list or map"},
+	}
+
+	for _, testCase := range testCases {
+		converted, err := convertVar("TEST", testCase.varType, testCase.value)
+		if testCase.expectedError != "" {
+			assert.Error(t, err, testCase.expectedError)
+			continue
+		}
+
+		assert.NilError(t, err, "Error converting %s to %s", testCase.value, testCase.varType)
+		assert.DeepEqual(t, converted, testCase.expected)
+	}
+}
+
+func TestTypedVars(t *testing.T) {
+	resolvedVarsBackup := ResolvedVars
+	varTypesBackup := VarTypes
+	nonInteractiveBackup := survey.IsNonInteractive()
+	defer func() {
+		ResolvedVars = resolvedVarsBackup
+		VarTypes = varTypesBackup
+		survey.SetNonInteractive(nonInteractiveBackup)
+	}()
+
+	ResolvedVars = map[string]string{}
+	VarTypes = map[string]configspkg.VariableType{}
+	survey.SetNonInteractive(true)
+
+	generatedConfig := &generated.Config{ActiveConfig: generated.DefaultConfigName, Configs: map[string]*generated.CacheConfig{}}
+	generated.InitDevSpaceConfig(generatedConfig, generated.DefaultConfigName)
+	generated.SetTestConfig(generatedConfig)
+
+	cache := generatedConfig.GetActive()
+	cache.Vars["REPLICAS"] = "not-a-number"
+
+	stringType := configspkg.VariableTypeString
+	intType := configspkg.VariableTypeInt
+	listType := configspkg.VariableTypeList
+	err := askQuestions(cache, []*configspkg.Variable{
+		{Name: ptr.String("VERSION"), Type: &stringType, Default: ptr.String("0123")},
+		{Name: ptr.String("TAG"), Type: &stringType, Default: ptr.String("${VERSION}-dev")},
+		{Name: ptr.String("REPLICAS"), Type: &intType, Default: ptr.String("2")},
+		{Name: ptr.String("HOSTS"), Type: &listType, Default: ptr.String("[a.com, b.com]")},
+	})
+	assert.NilError(t, err, "Error asking questions")
+
+	// Defaults can reference other variables and invalid cached values are asked again
+	assert.DeepEqual(t, cache.Vars, map[string]string{"VERSION": "0123", "TAG": "0123-dev", "REPLICAS": "2", "HOSTS": "[a.com, b.com]"})
+
+	out, err := CustomResolveVars([]byte(`version: ${VERSION}
+tag: ${TAG}
+replicas: ${REPLICAS}
+hosts: ${HOSTS}
+`), varMatchFn, varReplaceFn)
+	assert.NilError(t, err, "Error resolving vars")
+	assert.Equal(t, string(out), `hosts:
+- a.com
+- b.com
+replicas: 2
+tag: 0123-dev
+version: "0123"
+`)
+
+	_, err = ResolveVarsInString("hosts: ${HOSTS}")
+	assert.Error(t, err, "Variable HOSTS is a list or map and cannot be used within a string")
+
+	err = askQuestions(cache, []*configspkg.Variable{{Name: ptr.String("INVALID"), Type: &intType, Default: ptr.String("1.0")}})
+	assert.Error(t, err, "Variable INVALID has to be an integer")
+}
@@ -6,9 +6,15 @@ const DefaultConfigsPath = "devspace-configs.yaml"
 // DefaultConfigPath is the default config path to use
 const DefaultConfigPath = "devspace.yaml"
 
+// DefaultLocalConfigPath is the path of the per-developer config that is merged last and should not be committed
+const DefaultLocalConfigPath = "devspace.local.yaml"
+
 // DefaultVarsPath is the default vars path to use
 const DefaultVarsPath = "devspace-vars.yaml"
 
+// DefaultImportsLockPath is the path of the lock file that pins the commits of imported config fragments
+const DefaultImportsLockPath = "devspace-lock.yaml"
+
 // DefaultDevSpaceSelectorName is the default app selector
 const DefaultDevSpaceSelectorName = "app-selector"
 
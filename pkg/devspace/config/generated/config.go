@@ -58,6 +58,7 @@ type ImageCache struct {
 
 	ImageName string `yaml:"imageName,omitempty"`
 	Tag       string `yaml:"tag,omitempty"`
+	Digest    string `yaml:"digest,omitempty"`
 }
 
 // DeploymentCache holds the information about a specific deployment
@@ -67,6 +68,21 @@ type DeploymentCache struct {
 	HelmOverridesHash    string `yaml:"helmOverridesHash,omitempty"`
 	HelmChartHash        string `yaml:"helmChartHash,omitempty"`
 	KubectlManifestsHash string `yaml:"kubectlManifestsHash,omitempty"`
+
+	// HelmReleaseRevision is used to roll back a deployment if a later deployment fails
+	HelmReleaseRevision int32 `yaml:"helmReleaseRevision,omitempty"`
+
+	// KubectlAppliedObjects are the objects that were applied during the last deploy. Only references are stored,
+	// because the manifests might contain secrets
+	KubectlAppliedObjects []*ObjectReference `yaml:"kubectlAppliedObjects,omitempty"`
+}
+
+// ObjectReference references a kubernetes object
+type ObjectReference struct {
+	APIVersion string `yaml:"apiVersion,omitempty"`
+	Kind       string `yaml:"kind"`
+	Namespace  string `yaml:"namespace,omitempty"`
+	Name       string `yaml:"name"`
 }
 
 // ConfigPath is the relative generated config path
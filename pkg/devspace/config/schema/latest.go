@@ -0,0 +1,452 @@
+package schema
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// Config returns the json schema of the latest devspace.yaml version
+func Config() *Schema {
+	schema := Generate(latest.Config{}, "devspace.yaml", configMetadata)
+	schema.ID = "https://devspace.cloud/schemas/devspace-" + latest.Version + ".json"
+	schema.Description = "DevSpace configuration (version " + latest.Version + ")"
+
+	return schema
+}
+
+// Configs returns the json schema of devspace-configs.yaml
+func Configs() *Schema {
+	schema := Generate(configs.Configs{}, "devspace-configs.yaml", configMetadata, configs.Variable{}, configs.VarsWrapper{})
+	schema.ID = "https://devspace.cloud/schemas/devspace-configs.json"
+	schema.Description = "Map of config names to DevSpace configurations"
+
+	return schema
+}
+
+var configMetadata = &Metadata{
+	Types: map[string]string{
+		"Config":                      "DevSpace configuration",
+		"ImageConfig":                 "Image that is built and pushed",
+		"BuildConfig":                 "Build configuration for an image",
+		"DockerConfig":                "Options for building images with Docker",
+		"KanikoConfig":                "Options for building images with kaniko",
+		"CustomConfig":                "Options for building images with a custom build script",
+		"BuildOptions":                "General options for building images",
+		"DeploymentConfig":            "Deployment of the project",
+		"RolloutConfig":               "Options for tracking the rollout of Deployments, StatefulSets and DaemonSets after deploying",
+		"ComponentConfig":             "Options for deploying a DevSpace component",
+		"ContainerConfig":             "Container of a component",
+		"VolumeMountConfig":           "Volume mount of a component container",
+		"VolumeMountVolumeConfig":     "Volume that is mounted into a component container",
+		"AutoScalingConfig":           "Auto-Scaling configuration of a component",
+		"AutoScalingHorizontalConfig": "Horizontal auto-scaling configuration of a component",
+		"RollingUpdateConfig":         "Rolling-Update configuration of a component",
+		"VolumeConfig":                "Volume of a component",
+		"ServiceConfig":               "Service of a component",
+		"ServicePortConfig":           "Port of a component service",
+		"IngressConfig":               "Ingress of a component",
+		"IngressRuleConfig":           "Rule of a component ingress",
+		"ComponentConfigOptions":      "Options for deploying a component with helm",
+		"HelmConfig":                  "Options for deploying with Helm",
+		"ImageValuesConfig":           "Value paths a built image is written to",
+		"ChartConfig":                 "Chart to deploy",
+		"KubectlConfig":               "Options for deploying with \"kubectl apply\"",
+		"TemplateConfig":              "Options for rendering go templated manifests and deploying them with \"kubectl apply\"",
+		"DevConfig":                   "Options for \"devspace dev\"",
+		"ImageOverrideConfig":         "Override settings for image building during \"devspace dev\"",
+		"Terminal":                    "Options for the terminal proxy",
+		"PortForwardingConfig":        "Port-forwarding settings for selected pods",
+		"PortMapping":                 "Port that is forwarded",
+		"SyncConfig":                  "File sync settings for selected pods",
+		"BandwidthLimits":             "Bandwidth limits for the synchronization algorithm",
+		"AutoReloadConfig":            "Options for auto-reloading (i.e. re-deploying deployments and re-building images)",
+		"LogsConfig":                  "Options for streaming the logs of several containers during \"devspace dev\"",
+		"LogsSelector":                "Pods and containers to stream the logs of",
+		"SelectorConfig":              "Selector used to select Kubernetes pods (used within terminal, ports and sync)",
+		"DependencyConfig":            "Other project containing a devspace.yaml or devspace-configs.yaml that needs to be deployed before this project",
+		"SourceConfig":                "Defines where to find the dependency or import (exactly one source is allowed)",
+		"ImportConfig":                "Config fragment from a git repository or a local path that is merged into the config",
+		"HookConfig":                  "Hook that is executed before or after building images or deploying",
+		"HookWhenConfig":              "Trigger for executing the hook",
+		"HookWhenAtConfig":            "Execution step the hook is executed at",
+		"Cluster":                     "Cluster configuration",
+		"ProfileConfig":               "Profile that patches the config when it is selected via --profile",
+		"PatchConfig":                 "Patch operation of a profile",
+
+		"ConfigDefinition": "DevSpace configuration with variables and overrides",
+		"ConfigWrapper":    "Config that is loaded from a path or defined inline",
+		"VarsWrapper":      "Variables that are loaded from a path or defined inline",
+		"Variable":         "Variable that can be used as ${NAME} in the config",
+		"KeychainSource":   "Entry in the keychain of the operating system the value of a variable is read from",
+		"VaultSource":      "Secret in a Vault compatible secret store the value of a variable is read from",
+	},
+	Fields: map[string]string{
+		"Config.version":      "Version of the config",
+		"Config.name":         "Name of the project that is used to label the objects of kubectl and template deployments (Default: name of the project directory and a hash of its path)",
+		"Config.imports":      "Array of config fragments that are merged in order before the config itself",
+		"Config.images":       "Images to be built and pushed",
+		"Config.deployments":  "Array of deployments",
+		"Config.dev":          "Options for \"devspace dev\"",
+		"Config.dependencies": "Array of dependencies (other projects containing a devspace.yaml or devspace-configs.yaml) that need to be deployed before this project",
+		"Config.hooks":        "Array of hooks to be executed",
+		"Config.cluster":      "Cluster configuration",
+		"Config.profiles":     "Array of profiles that can be selected via --profile",
+
+		"ImageConfig.image":            "Image repository and name",
+		"ImageConfig.tag":              "Image tag",
+		"ImageConfig.dockerfile":       "Relative path to the Dockerfile used for building (Default: ./Dockerfile)",
+		"ImageConfig.context":          "Relative path to the context used for building (Default: ./)",
+		"ImageConfig.createPullSecret": "Create a pull secret containing your Docker credentials (Default: false)",
+		"ImageConfig.build":            "Build options for this image",
+
+		"BuildConfig.disabled": "Disable image building (Default: false)",
+		"BuildConfig.docker":   "Build image with docker and set options for docker",
+		"BuildConfig.kaniko":   "Build image with kaniko and set options for kaniko",
+		"BuildConfig.custom":   "Build image using a custom build script",
+
+		"DockerConfig.preferMinikube":  "If available, use minikube's in-built docker daemon instead of local docker daemon (Default: true)",
+		"DockerConfig.skipPush":        "Skip pushing image to registry, recommended for minikube (Default: false)",
+		"DockerConfig.disableFallback": "Disable using kaniko as fallback when Docker is not installed (Default: false)",
+		"DockerConfig.options":         "General build options",
+
+		"KanikoConfig.cache":        "Use caching for kaniko build process",
+		"KanikoConfig.snapshotMode": "Type of snapshotMode for kaniko build process (Default: time)",
+		"KanikoConfig.flags":        "Array of flags for kaniko build command",
+		"KanikoConfig.namespace":    "Kubernetes namespace to run kaniko build pod in (Default: \"\" = deployment namespace)",
+		"KanikoConfig.insecure":     "Allow working with an insecure registry by not validating the SSL certificate (Default: false)",
+		"KanikoConfig.pullSecret":   "Mount this Kubernetes secret instead of creating one to authenticate to the registry",
+		"KanikoConfig.options":      "General build options",
+
+		"CustomConfig.command":   "Command to be executed for building (e.g. path to build script or executable)",
+		"CustomConfig.flags":     "Array of arguments for the custom build command",
+		"CustomConfig.imageFlag": "Name of the flag that DevSpace CLI uses to pass the image name + tag to the build script",
+		"CustomConfig.onChange":  "Array of paths (glob format) to check for file changes to see if image needs to be rebuild",
+
+		"BuildOptions.target":    "Target used for multi-stage builds",
+		"BuildOptions.network":   "Network mode used for building the image",
+		"BuildOptions.buildArgs": "Key-value map specifying build arguments that will be passed to the build tool (e.g. docker)",
+
+		"DeploymentConfig.name":      "Name of the deployment",
+		"DeploymentConfig.namespace": "Namespace to deploy to (Default: \"\" = namespace of the active namespace/Space)",
+		"DeploymentConfig.dependsOn": "Names of deployments that need to be deployed before this deployment",
+		"DeploymentConfig.component": "Deploy a DevSpace component chart using helm",
+		"DeploymentConfig.helm":      "Use Helm as deployment tool and set options for Helm",
+		"DeploymentConfig.kubectl":   "Use \"kubectl apply\" as deployment tool and set options for kubectl",
+		"DeploymentConfig.template":  "Render go templated manifests and deploy them using \"kubectl apply\"",
+		"DeploymentConfig.rollout":   "Options for waiting until the deployed workloads are ready",
+
+		"RolloutConfig.disabled": "Do not wait for the deployed workloads to become ready (Default: false)",
+		"RolloutConfig.timeout":  "Seconds to wait for the deployed workloads to become ready, must be greater than 0 (Default: 180)",
+		"RolloutConfig.warnOnly": "Only print a warning instead of failing if the rollout does not succeed (Default: false)",
+
+		"ComponentConfig.containers":          "Containers of the component",
+		"ComponentConfig.replicas":            "Number of replicas (Default: 1)",
+		"ComponentConfig.autoScaling":         "AutoScaling configuration",
+		"ComponentConfig.rollingUpdate":       "RollingUpdate configuration",
+		"ComponentConfig.labels":              "Labels of the component pods",
+		"ComponentConfig.annotations":         "Annotations of the component pods",
+		"ComponentConfig.volumes":             "Component volumes",
+		"ComponentConfig.service":             "Component service",
+		"ComponentConfig.serviceName":         "Service name for headless service (for StatefulSets)",
+		"ComponentConfig.ingress":             "Component ingress",
+		"ComponentConfig.podManagementPolicy": "Pod management policy (for StatefulSets)",
+		"ComponentConfig.pullSecrets":         "Array of PullSecret names",
+		"ComponentConfig.options":             "Options for deploying this component with helm",
+
+		"ContainerConfig.name":           "Container name",
+		"ContainerConfig.image":          "Image name (optionally with registry URL)",
+		"ContainerConfig.command":        "ENTRYPOINT override",
+		"ContainerConfig.args":           "ARGS override",
+		"ContainerConfig.env":            "Kubernetes env definition for containers",
+		"ContainerConfig.volumeMounts":   "VolumeMount configuration",
+		"ContainerConfig.resources":      "Kubernetes resource limits and requests",
+		"ContainerConfig.livenessProbe":  "Kubernetes livenessProbe",
+		"ContainerConfig.readinessProbe": "Kubernetes readinessProbe",
+
+		"VolumeMountConfig.containerPath": "Mount path within the container",
+		"VolumeMountConfig.volume":        "Volume to mount",
+
+		"VolumeMountVolumeConfig.name":     "Name of the volume to be mounted",
+		"VolumeMountVolumeConfig.subPath":  "Path inside to volume to be mounted to the containerPath",
+		"VolumeMountVolumeConfig.readOnly": "Mount volume as read-only (Default: false)",
+
+		"AutoScalingConfig.horizontal": "Configuration for horizontal auto-scaling",
+
+		"AutoScalingHorizontalConfig.maxReplicas":   "Max replicas to deploy",
+		"AutoScalingHorizontalConfig.averageCPU":    "Target value for CPU usage",
+		"AutoScalingHorizontalConfig.averageMemory": "Target value for memory (RAM) usage",
+
+		"RollingUpdateConfig.enabled":        "Enable/Disable rolling update (Default: disabled)",
+		"RollingUpdateConfig.maxSurge":       "Max number of pods to be created above the pod replica limit",
+		"RollingUpdateConfig.maxUnavailable": "Max number of pods unavailable during update process",
+		"RollingUpdateConfig.partition":      "For partitioned updates of StatefulSets",
+
+		"VolumeConfig.name":        "Volume name",
+		"VolumeConfig.size":        "Size of the volume in Gi (Gigabytes)",
+		"VolumeConfig.configMap":   "Kubernetes ConfigMapVolumeSource",
+		"VolumeConfig.secret":      "Kubernetes SecretVolumeSource",
+		"VolumeConfig.labels":      "Labels of the volume",
+		"VolumeConfig.annotations": "Annotations of the volume",
+
+		"ServiceConfig.name":        "Name of the service",
+		"ServiceConfig.type":        "Type of the service (Default: NodePort)",
+		"ServiceConfig.ports":       "Array of service ports",
+		"ServiceConfig.externalIPs": "Array of externalIPs for the service (discouraged)",
+		"ServiceConfig.labels":      "Labels of the service",
+		"ServiceConfig.annotations": "Annotations of the service",
+
+		"ServicePortConfig.port":          "Port exposed by the service",
+		"ServicePortConfig.containerPort": "Port of the container/pod to redirect traffic to",
+		"ServicePortConfig.protocol":      "Traffic protocol (tcp, udp)",
+
+		"IngressConfig.name":        "Name of the ingress",
+		"IngressConfig.tls":         "Name of the TLS secret",
+		"IngressConfig.labels":      "Labels of the ingress",
+		"IngressConfig.annotations": "Annotations of the ingress",
+		"IngressConfig.rules":       "Array of ingress rules",
+
+		"IngressRuleConfig.host":        "Host the rule applies to",
+		"IngressRuleConfig.servicePort": "Port of the component service the traffic is routed to",
+		"IngressRuleConfig.path":        "Path the rule applies to",
+		"IngressRuleConfig.tls":         "Name of the TLS secret for this host",
+
+		"ComponentConfigOptions.wait":            "Wait for pods to start after deployment (Default: false)",
+		"ComponentConfigOptions.rollback":        "Rollback if deployment failed (Default: false)",
+		"ComponentConfigOptions.force":           "Force deleting and re-creating Kubernetes resources during deployment (Default: false)",
+		"ComponentConfigOptions.timeout":         "Timeout to wait for pods to start after deployment (Default: 180)",
+		"ComponentConfigOptions.tillerNamespace": "Kubernetes namespace to run Tiller in (Default: \"\" = same a deployment namespace)",
+
+		"HelmConfig.chart":           "Chart to deploy",
+		"HelmConfig.wait":            "Wait for pods to start after deployment (Default: false)",
+		"HelmConfig.rollback":        "Rollback if deployment failed (Default: false)",
+		"HelmConfig.force":           "Force deleting and re-creating Kubernetes resources during deployment (Default: false)",
+		"HelmConfig.timeout":         "Timeout to wait for pods to start after deployment (Default: 180)",
+		"HelmConfig.tillerNamespace": "Kubernetes namespace to run Tiller in (Default: \"\" = same a deployment namespace)",
+		"HelmConfig.devSpaceValues":  "If DevSpace CLI should replace all values that look like a built image name before deploying (Default: false)",
+		"HelmConfig.imageValues":     "Map from image names (keys of the images section) to the value paths the built image is written to",
+		"HelmConfig.valuesFiles":     "Array of paths to values files",
+		"HelmConfig.values":          "Any object with Helm values to override values.yaml during deployment",
+
+		"ImageValuesConfig.image":      "Value path for the full image name including the built tag (e.g. dscr.io/user/image:tag)",
+		"ImageValuesConfig.repository": "Value path for the image name without tag",
+		"ImageValuesConfig.tag":        "Value path for the built tag",
+		"ImageValuesConfig.digest":     "Value path for the digest of the pushed image (only available for images pushed with docker)",
+
+		"ChartConfig.name":     "Chart name, path to a local chart or OCI chart reference (e.g. oci://my-registry.tld/charts/my-chart)",
+		"ChartConfig.version":  "Chart version (tag for OCI charts)",
+		"ChartConfig.repo":     "Helm chart repository",
+		"ChartConfig.username": "Username for Helm chart repository or OCI registry",
+		"ChartConfig.password": "Password for Helm chart repository or OCI registry",
+
+		"KubectlConfig.cmdPath":   "Path to the kubectl binary (Default: \"\" = detect automatically)",
+		"KubectlConfig.manifests": "Array containing glob patterns for the Kubernetes manifests to deploy using \"kubectl apply\" (e.g. kube or manifests/service.yaml)",
+		"KubectlConfig.kustomize": "Render the manifests as kustomizations before deploying them via \"kubectl apply\" (Default: false)",
+		"KubectlConfig.flags":     "Array of flags for the \"kubectl apply\" command",
+
+		"TemplateConfig.path":        "Directory containing the templates (all .yaml and .yml files, also in subdirectories)",
+		"TemplateConfig.valuesFiles": "Array of paths to values files (available as .Values in the templates)",
+		"TemplateConfig.values":      "Any object with values (merged into the values of the valuesFiles)",
+		"TemplateConfig.cmdPath":     "Path to the kubectl binary (Default: \"\" = detect automatically)",
+		"TemplateConfig.flags":       "Array of flags for the \"kubectl apply\" command",
+
+		"DevConfig.overrideImages": "Array of override settings for image building",
+		"DevConfig.terminal":       "Options for the terminal proxy",
+		"DevConfig.ports":          "Array of port-forwarding settings for selected pods",
+		"DevConfig.sync":           "Array of file sync settings for selected pods",
+		"DevConfig.autoReload":     "Options for auto-reloading (i.e. re-deploying deployments and re-building images)",
+		"DevConfig.logs":           "Options for streaming the logs of several containers instead of opening a terminal",
+		"DevConfig.selectors":      "Array of selectors used to select Kubernetes pods (used within terminal, ports and sync)",
+
+		"ImageOverrideConfig.name":       "Name of the image to apply this override rule to",
+		"ImageOverrideConfig.entrypoint": "Array defining with the entrypoint that should be used instead of the entrypoint defined in the Dockerfile",
+		"ImageOverrideConfig.dockerfile": "Relative path of the Dockerfile that should be used instead of the one originally defined",
+		"ImageOverrideConfig.context":    "Relative path of the context directory that should be used instead of the one originally defined",
+
+		"Terminal.disabled":      "Disable terminal proxy / only start port-forwarding and code sync if defined (Default: false)",
+		"Terminal.selector":      "Name of a selector of the selectors section",
+		"Terminal.labelSelector": "Key-value map of labels and values to select pods from",
+		"Terminal.namespace":     "Namespace to select pods in",
+		"Terminal.containerName": "Container name to use",
+		"Terminal.command":       "Array defining the shell command to start the terminal with",
+
+		"PortForwardingConfig.selector":       "Name of a selector of the selectors section",
+		"PortForwardingConfig.namespace":      "Namespace to select pods in",
+		"PortForwardingConfig.labelSelector":  "Key-value map of labels and values to select pods from",
+		"PortForwardingConfig.service":        "Name of a service whose ready endpoint pods are selected",
+		"PortForwardingConfig.deployment":     "Name of a deployment whose ready pods are selected",
+		"PortForwardingConfig.forward":        "Array of ports to be forwarded",
+		"PortForwardingConfig.reverseForward": "Array of ports in the selected pod that are forwarded to your local computer (e.g. to reach a debugger or a mock service)",
+
+		"PortMapping.port":        "Forward this port on your local computer, auto selects a free port and a range like 8080-8090 selects the first free port of the range",
+		"PortMapping.remotePort":  "Forward traffic to this port exposed by the selected pod, or listen on this port in the selected pod for reverseForward (Default: port)",
+		"PortMapping.bindAddress": "Address used for binding / use 0.0.0.0 to bind on all interfaces (Default: \"localhost\" = 127.0.0.1)",
+
+		"SyncConfig.selector":             "Name of a selector of the selectors section",
+		"SyncConfig.namespace":            "Namespace to select pods in",
+		"SyncConfig.labelSelector":        "Key-value map of labels and values to select pods from",
+		"SyncConfig.containerName":        "Container name to use",
+		"SyncConfig.localSubPath":         "Relative path to a local folder that should be synchronized (Default: \"./\" = entire project)",
+		"SyncConfig.containerPath":        "Path in the container that should be synchronized with localSubPath (Default is working directory of container (\".\"))",
+		"SyncConfig.waitInitialSync":      "Wait until initial sync is completed before continuing (Default: false)",
+		"SyncConfig.excludePaths":         "Paths to exclude files/folders from sync in .gitignore syntax",
+		"SyncConfig.downloadExcludePaths": "Paths to exclude files/folders from download in .gitignore syntax",
+		"SyncConfig.uploadExcludePaths":   "Paths to exclude files/folders from upload in .gitignore syntax",
+		"SyncConfig.bandwidthLimits":      "Bandwidth limits for the synchronization algorithm",
+
+		"BandwidthLimits.download": "Max file download speed in kilobytes / second (e.g. 100 means 100 KB/s)",
+		"BandwidthLimits.upload":   "Max file upload speed in kilobytes / second (e.g. 100 means 100 KB/s)",
+
+		"AutoReloadConfig.paths":       "Array containing glob patterns of files that are watched for auto-reloading (i.e. reload when a file matching any of the patterns changes)",
+		"AutoReloadConfig.deployments": "Array containing names of deployments to watch for auto-reloading (i.e. reload when kubectl manifests or files within the Helm chart change)",
+		"AutoReloadConfig.images":      "Array containing names of images to watch for auto-reloading (i.e. reload when the Dockerfile changes)",
+		"AutoReloadConfig.debounce":    "Milliseconds without further changes after which DevSpace reloads (Default: 2000)",
+
+		"LogsConfig.disabled":  "Disable streaming the logs (Default: false)",
+		"LogsConfig.selectors": "Array of pods and containers to stream the logs of (Default: all selectors of the selectors section)",
+		"LogsConfig.lines":     "Max amount of lines to print from the logs of containers that started before (Default: 100)",
+
+		"LogsSelector.selector":      "Name of a selector of the selectors section",
+		"LogsSelector.namespace":     "Namespace to select pods in",
+		"LogsSelector.labelSelector": "Key-value map of labels and values to select pods from",
+		"LogsSelector.containerName": "Container name to stream the logs of (Default: \"\" = all containers of the selected pods)",
+
+		"SelectorConfig.name":          "Name of this pod selector (used to reference this selector within terminal, ports and sync)",
+		"SelectorConfig.namespace":     "Namespace to select pods in (Default: \"\" = namespace of the active Space)",
+		"SelectorConfig.labelSelector": "Key-value map of Kubernetes labels used to select pods",
+		"SelectorConfig.containerName": "Name of the container within the selected pod (Default: \"\" = first container in the pod)",
+
+		"DependencyConfig.source":             "Defines where to find the dependency (exactly one source is allowed)",
+		"DependencyConfig.config":             "Name of the config used to deploy this dependency (when multiple configs are defined via devspace-configs.yaml)",
+		"DependencyConfig.skipBuild":          "Do not build images of this dependency (= only start deployments)",
+		"DependencyConfig.ignoreDependencies": "Do not build and deploy dependencies of this dependency",
+		"DependencyConfig.namespace":          "Namespace to deploy the dependency to",
+
+		"SourceConfig.git":      "HTTP(S) URL of the git repository",
+		"SourceConfig.branch":   "Git branch to check out",
+		"SourceConfig.tag":      "Git tag to check out",
+		"SourceConfig.revision": "Git commit to check out",
+		"SourceConfig.path":     "Path to a project on your local computer (not recommended)",
+
+		"ImportConfig.source": "Defines where to find the config fragment (exactly one source is allowed)",
+		"ImportConfig.file":   "Path of the config fragment relative to the source (Default: devspace.yaml)",
+
+		"HookConfig.command": "Command to be executed when this hook is triggered",
+		"HookConfig.args":    "Array of arguments for the command of this hook",
+		"HookConfig.when":    "Trigger for executing this hook",
+
+		"HookWhenConfig.before": "Run hook before a certain execution step",
+		"HookWhenConfig.after":  "Run hook after a certain execution step",
+
+		"HookWhenAtConfig.images":      "Name of the image OR \"all\" for all images",
+		"HookWhenAtConfig.deployments": "Name of the deployment OR \"all\" for all deployments",
+
+		"Cluster.kubeContext": "Name of the Kubernetes context to use (Default: \"\" = current Kubernetes context used by kubectl)",
+		"Cluster.namespace":   "Namespace for deploying applications",
+
+		"ProfileConfig.name":    "Name of the profile",
+		"ProfileConfig.patches": "Array of patches that are applied in order when the profile is selected",
+
+		"PatchConfig.op":    "Operation of the patch",
+		"PatchConfig.path":  "Path of the value to patch, e.g. images.default.image or deployments[name=backend].helm.values",
+		"PatchConfig.value": "Value to add or to replace the value at path with",
+
+		"ConfigDefinition.config":    "Config that is loaded from a path or defined inline",
+		"ConfigDefinition.vars":      "Array of variables or variables that are loaded from a path",
+		"ConfigDefinition.overrides": "Array of configs that are merged into the config",
+
+		"ConfigWrapper.path": "Path to the config file",
+		"ConfigWrapper.data": "Inline config",
+
+		"VarsWrapper.path": "Path to a file containing an array of variables",
+		"VarsWrapper.data": "Array of variables",
+
+		"Variable.name":              "Name of the variable",
+		"Variable.type":              "Type of the value of the variable, lists and maps are entered in yaml format, e.g. [a, b] or {a: b} (Default: the value is converted to an integer or boolean if possible)",
+		"Variable.source":            "Where the value of the variable is taken from (Default: all = environment variable or question)",
+		"Variable.options":           "Array of values the user can choose from",
+		"Variable.default":           "Default value of the variable, can reference other variables, e.g. ${DEVSPACE_GIT_COMMIT}",
+		"Variable.question":          "Question that is asked if the variable is not defined",
+		"Variable.validationPattern": "Regular expression the value has to match",
+		"Variable.validationMessage": "Message that is shown if the value does not match the validationPattern",
+		"Variable.secret":            "Never save the value of this variable in .devspace/generated.yaml and mask it in logs and \"devspace print config\" (Default: false)",
+		"Variable.file":              "Path of the file the value is read from (source: file)",
+		"Variable.command":           "Command whose output is used as value (source: command)",
+		"Variable.args":              "Array of arguments for the command (source: command)",
+		"Variable.keychain":          "Keychain entry the value is read from (source: keychain)",
+		"Variable.vault":             "Vault secret the value is read from (source: vault)",
+
+		"KeychainSource.service": "Service name of the keychain entry",
+		"KeychainSource.account": "Account name of the keychain entry",
+
+		"VaultSource.address": "Address of the Vault server (Default: $VAULT_ADDR or http://127.0.0.1:8200)",
+		"VaultSource.path":    "Path of the secret, e.g. secret/data/my-app",
+		"VaultSource.key":     "Key of the value within the secret",
+	},
+	Enums: map[string][]interface{}{
+		"Config.version":                      []interface{}{latest.Version},
+		"KanikoConfig.snapshotMode":           []interface{}{"full", "time"},
+		"ComponentConfig.podManagementPolicy": []interface{}{"OrderedReady", "Parallel"},
+		"ServiceConfig.type":                  []interface{}{"ClusterIP", "NodePort", "LoadBalancer"},
+		"PatchConfig.op":                      []interface{}{"add", "replace", "remove"},
+		"Variable.type": []interface{}{
+			string(configs.VariableTypeString),
+			string(configs.VariableTypeInt),
+			string(configs.VariableTypeBool),
+			string(configs.VariableTypeList),
+			string(configs.VariableTypeMap),
+		},
+		"Variable.source": []interface{}{
+			string(configs.VariableSourceAll),
+			string(configs.VariableSourceEnv),
+			string(configs.VariableSourceInput),
+			string(configs.VariableSourceFile),
+			string(configs.VariableSourceCommand),
+			string(configs.VariableSourceKeychain),
+			string(configs.VariableSourceVault),
+		},
+	},
+	Required: map[string]bool{
+		"Config.version":           true,
+		"CustomConfig.command":     true,
+		"DeploymentConfig.name":    true,
+		"HelmConfig.chart":         true,
+		"ChartConfig.name":         true,
+		"KubectlConfig.manifests":  true,
+		"TemplateConfig.path":      true,
+		"ImageOverrideConfig.name": true,
+		"PortMapping.port":         true,
+		"SelectorConfig.name":      true,
+		"DependencyConfig.source":  true,
+		"ImportConfig.source":      true,
+		"HookConfig.command":       true,
+		"ProfileConfig.name":       true,
+		"PatchConfig.op":           true,
+		"PatchConfig.path":         true,
+		"Variable.name":            true,
+		"KeychainSource.service":   true,
+		"VaultSource.path":         true,
+		"VaultSource.key":          true,
+	},
+	Overrides: map[string]*Schema{
+		"ConfigDefinition.vars": &Schema{
+			OneOf: []*Schema{
+				&Schema{Type: "array", Items: &Schema{Ref: "#/definitions/Variable"}},
+				&Schema{Ref: "#/definitions/VarsWrapper"},
+			},
+		},
+		"ConfigWrapper.data": &Schema{Type: "object"},
+		"PortMapping.port": &Schema{
+			OneOf: []*Schema{
+				&Schema{Type: "integer"},
+				&Schema{Type: "string"},
+			},
+		},
+		"Variable.default": &Schema{
+			OneOf: []*Schema{
+				&Schema{Type: "string"},
+				&Schema{Type: "integer"},
+				&Schema{Type: "boolean"},
+			},
+		},
+	},
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"strings"
+)
+
+// yamlLine is a line of a block style yaml document. Sequence entries are split into a line that only holds the
+// dash and a line with the content after the dash, e.g. "- name: test" becomes "-" and "name: test"
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// Locate returns the line and column (starting with 1) of the value with the given path in the yaml content.
+// If the value cannot be found, the position of the closest parent that can be found is returned. Only block
+// style yaml is supported, values in flow style (e.g. {a: b}) are located at their parent
+func Locate(content []byte, path []interface{}) (int, int) {
+	lines := splitYamlLines(string(content))
+	if len(lines) == 0 {
+		return 0, 0
+	}
+
+	line, column := lines[0].number, lines[0].indent+1
+	start, end := 0, len(lines)
+	for _, segment := range path {
+		var index int
+
+		switch segment := segment.(type) {
+		case int:
+			index = findSequenceEntry(lines, start, end, segment)
+		default:
+			index = findKey(lines, start, end, segment.(string))
+		}
+		if index == -1 {
+			break
+		}
+
+		line, column = lines[index].number, lines[index].indent+1
+
+		// Continue with the children of the found key or sequence entry
+		start, end = index+1, childrenEnd(lines, index)
+	}
+
+	return line, column
+}
+
+// splitYamlLines returns the lines of the yaml content without comments, empty lines and document separators
+func splitYamlLines(content string) []yamlLine {
+	lines := []yamlLine{}
+	blockScalarIndent := -1
+
+	for i, text := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n") {
+		trimmed := strings.TrimLeft(text, " ")
+		indent := len(text) - len(trimmed)
+
+		// Skip the content of block scalars (e.g. key: |)
+		if blockScalarIndent >= 0 {
+			if strings.TrimSpace(trimmed) == "" || indent > blockScalarIndent {
+				continue
+			}
+
+			blockScalarIndent = -1
+		}
+
+		if strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "---") {
+			continue
+		}
+
+		for trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			lines = append(lines, yamlLine{number: i + 1, indent: indent, text: "-"})
+
+			rest := strings.TrimLeft(trimmed[1:], " ")
+			indent += len(trimmed) - len(rest)
+			trimmed = rest
+		}
+
+		if trimmed != "" && strings.HasPrefix(trimmed, "#") == false {
+			lines = append(lines, yamlLine{number: i + 1, indent: indent, text: trimmed})
+
+			value := strings.TrimSpace(stripComment(trimmed))
+			if strings.HasSuffix(value, "|") || strings.HasSuffix(value, ">") || strings.HasSuffix(value, "|-") || strings.HasSuffix(value, ">-") {
+				blockScalarIndent = lines[len(lines)-1].indent
+			}
+		}
+	}
+
+	return lines
+}
+
+// stripComment removes a trailing comment from a line
+func stripComment(text string) string {
+	if idx := strings.Index(text, " #"); idx != -1 {
+		return text[:idx]
+	}
+
+	return text
+}
+
+// childIndent returns the indentation of the first line in the range, which is the indentation of all children
+func childIndent(lines []yamlLine, start, end int) int {
+	if start >= end {
+		return -1
+	}
+
+	return lines[start].indent
+}
+
+// childrenEnd returns the index of the first line after the children of the line with the given index
+func childrenEnd(lines []yamlLine, index int) int {
+	for i := index + 1; i < len(lines); i++ {
+		if lines[i].indent < lines[index].indent {
+			return i
+		}
+
+		// A key can be followed by sequence entries on the same indentation
+		if lines[i].indent == lines[index].indent && (lines[index].text == "-" || lines[i].text != "-") {
+			return i
+		}
+	}
+
+	return len(lines)
+}
+
+func findKey(lines []yamlLine, start, end int, key string) int {
+	indent := childIndent(lines, start, end)
+	for i := start; i < end; i++ {
+		if lines[i].indent != indent {
+			continue
+		}
+
+		text := lines[i].text
+		for _, candidate := range []string{key, `"` + key + `"`, `'` + key + `'`} {
+			if strings.HasPrefix(text, candidate) && strings.HasPrefix(strings.TrimLeft(text[len(candidate):], " "), ":") {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func findSequenceEntry(lines []yamlLine, start, end int, index int) int {
+	indent := childIndent(lines, start, end)
+	for i := start; i < end; i++ {
+		if lines[i].indent == indent && lines[i].text == "-" {
+			if index == 0 {
+				return i
+			}
+
+			index--
+		}
+	}
+
+	return -1
+}
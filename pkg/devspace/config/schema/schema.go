@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// DraftURI is the json schema draft the generated schemas conform to
+const DraftURI = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a json schema that describes a yaml config
+type Schema struct {
+	SchemaURI   string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Metadata holds the information about the config types that cannot be retrieved via reflection
+type Metadata struct {
+	// Types maps the go type names to their description
+	Types map[string]string
+
+	// Fields maps type.field (e.g. ImageConfig.image, the field is the yaml name) to the field description
+	Fields map[string]string
+
+	// Enums maps type.field to the values the field can take
+	Enums map[string][]interface{}
+
+	// Required holds the type.field of all fields that have to be specified
+	Required map[string]bool
+
+	// Overrides maps type.field to a schema that is used instead of the reflected one (e.g. for interface{} fields)
+	Overrides map[string]*Schema
+}
+
+// Generate creates the json schema for the type of the given value. Structs are added as definitions and referenced.
+// The types of the additional values are added as definitions as well, so that they can be referenced in overrides
+func Generate(value interface{}, title string, metadata *Metadata, additional ...interface{}) *Schema {
+	generator := &generator{
+		metadata:    metadata,
+		definitions: map[string]*Schema{},
+	}
+	for _, value := range additional {
+		generator.reflect(reflect.TypeOf(value))
+	}
+
+	schema := generator.reflect(reflect.TypeOf(value))
+	if schema.Ref != "" {
+		// Inline the root type
+		name := strings.TrimPrefix(schema.Ref, "#/definitions/")
+		schema = generator.definitions[name]
+		delete(generator.definitions, name)
+	}
+
+	schema.SchemaURI = DraftURI
+	schema.Title = title
+	if len(generator.definitions) > 0 {
+		schema.Definitions = generator.definitions
+	}
+
+	return schema
+}
+
+type generator struct {
+	metadata    *Metadata
+	definitions map[string]*Schema
+}
+
+func (g *generator) reflect(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; ok == false {
+			// Add placeholder first to support recursive types
+			g.definitions[t.Name()] = &Schema{}
+			g.definitions[t.Name()] = g.reflectStruct(t)
+		}
+
+		return &Schema{Ref: "#/definitions/" + t.Name()}
+	case reflect.Map:
+		schema := &Schema{Type: "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			schema.AdditionalProperties = g.reflect(t.Elem())
+		}
+
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  "array",
+			Items: g.reflect(t.Elem()),
+		}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+
+	// interface{} allows any value
+	return &Schema{}
+}
+
+func (g *generator) reflectStruct(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Description:          g.metadata.Types[t.Name()],
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		name := FieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
+
+		key := t.Name() + "." + name
+		fieldSchema := g.reflect(t.Field(i).Type)
+		if override, ok := g.metadata.Overrides[key]; ok {
+			copied := *override
+			fieldSchema = &copied
+		}
+		if fieldSchema.Ref != "" {
+			// $ref does not allow sibling keywords in draft 07, so the reference is wrapped to add a description
+			fieldSchema = &Schema{AllOf: []*Schema{fieldSchema}}
+		}
+
+		fieldSchema.Description = g.metadata.Fields[key]
+		if enum, ok := g.metadata.Enums[key]; ok {
+			fieldSchema.Enum = enum
+		}
+		if g.metadata.Required[key] {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = fieldSchema
+	}
+
+	return schema
+}
+
+// FieldName returns the yaml name of a struct field or an empty string if the field is not serialized
+func FieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "-" {
+		return ""
+	} else if name == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	return name
+}
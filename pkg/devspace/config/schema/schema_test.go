@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestMetadataComplete(t *testing.T) {
+	visited := map[string]bool{}
+
+	var visit func(typ reflect.Type)
+	visit = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || visited[typ.Name()] {
+			return
+		}
+
+		visited[typ.Name()] = true
+		if _, ok := configMetadata.Types[typ.Name()]; ok == false {
+			t.Errorf("Missing description for type %s", typ.Name())
+		}
+
+		for i := 0; i < typ.NumField(); i++ {
+			name := FieldName(typ.Field(i))
+			if name == "" {
+				continue
+			}
+
+			if _, ok := configMetadata.Fields[typ.Name()+"."+name]; ok == false {
+				t.Errorf("Missing description for field %s.%s", typ.Name(), name)
+			}
+
+			visit(typ.Field(i).Type)
+		}
+	}
+
+	visit(reflect.TypeOf(latest.Config{}))
+	visit(reflect.TypeOf(configs.Configs{}))
+	visit(reflect.TypeOf(configs.VarsWrapper{}))
+}
+
+func TestGenerate(t *testing.T) {
+	schema := Config()
+	assert.Equal(t, schema.SchemaURI, DraftURI)
+	assert.Equal(t, schema.AdditionalProperties, false)
+	assert.DeepEqual(t, schema.Required, []string{"version"})
+
+	kaniko := schema.Definitions["KanikoConfig"]
+	assert.Assert(t, kaniko != nil, "Missing definition for KanikoConfig")
+	assert.DeepEqual(t, kaniko.Properties["snapshotMode"].Enum, []interface{}{"full", "time"})
+	assert.Equal(t, kaniko.Properties["flags"].Type, "array")
+	assert.Equal(t, kaniko.Properties["flags"].Items.Type, "string")
+
+	images := schema.Properties["images"]
+	assert.Equal(t, images.Type, "object")
+	assert.Equal(t, images.AdditionalProperties.(*Schema).Ref, "#/definitions/ImageConfig")
+
+	deployment := schema.Definitions["DeploymentConfig"]
+	assert.Equal(t, deployment.Properties["helm"].AllOf[0].Ref, "#/definitions/HelmConfig")
+	assert.Equal(t, deployment.Properties["helm"].Description, configMetadata.Fields["DeploymentConfig.helm"])
+
+	configsSchema := Configs()
+	assert.Assert(t, configsSchema.Definitions["Variable"] != nil, "Missing definition for Variable")
+	assert.DeepEqual(t, configsSchema.Definitions["Variable"].Properties["source"].Enum, []interface{}{"all", "env", "input", "file", "command", "keychain", "vault"})
+	assert.DeepEqual(t, configsSchema.Definitions["Variable"].Properties["type"].Enum, []interface{}{"string", "int", "bool", "list", "map"})
+	assert.Equal(t, len(configsSchema.Definitions["Variable"].Properties["default"].OneOf), 3)
+
+	_, err := json.Marshal(schema)
+	assert.NilError(t, err, "Error marshalling schema")
+}
+
+const testConfig = `version: v1beta3
+images:
+  default:
+    image: my-image
+    build:
+      kaniko:
+        snapshotMode: fast
+deployments:
+- name: my-deployment
+  helm:
+    chart:
+      nme: my-chart
+    values:
+      # Values are not validated
+      any: thing
+- name: other
+  kubectl:
+    manifests:
+    - kube/*
+  rollout:
+    timeout: soon
+dev:
+  ports:
+  - labelSelector:
+      app: test
+    forward:
+    - port: 8080
+      bindAddress: |
+        not a key:
+`
+
+func TestValidateFile(t *testing.T) {
+	rawConfig := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(testConfig), rawConfig)
+	assert.NilError(t, err, "Error parsing config")
+
+	err = ValidateFile(Config(), "devspace.yaml", []byte(testConfig), rawConfig)
+	assert.Assert(t, err != nil, "No error for invalid config")
+
+	violations := err.(*ValidationError).Violations
+	messages := []string{}
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+
+	assert.DeepEqual(t, messages, []string{
+		`line 7, column 9: images.default.build.kaniko.snapshotMode: invalid value string "fast", allowed values are "full", "time"`,
+		`line 11, column 5: deployments[0].helm.chart: missing required field "name"`,
+		`line 12, column 7: deployments[0].helm.chart.nme: unknown field, did you mean "name"?`,
+		`line 21, column 5: deployments[1].rollout.timeout: expected integer but got string "soon"`,
+	})
+
+	rawConfig = map[interface{}]interface{}{
+		"version": latest.Version,
+		"dev": map[interface{}]interface{}{
+			"ports": []interface{}{
+				map[interface{}]interface{}{
+					"forward": []interface{}{
+						map[interface{}]interface{}{"port": 8080},
+					},
+				},
+			},
+		},
+	}
+	assert.NilError(t, ValidateFile(Config(), "devspace.yaml", nil, rawConfig))
+}
+
+func TestLocate(t *testing.T) {
+	content := []byte(testConfig)
+
+	testCases := []struct {
+		path         []interface{}
+		line, column int
+	}{
+		{path: []interface{}{}, line: 1, column: 1},
+		{path: []interface{}{"deployments"}, line: 8, column: 1},
+		{path: []interface{}{"deployments", 1}, line: 16, column: 1},
+		{path: []interface{}{"deployments", 1, "kubectl", "manifests", 0}, line: 19, column: 5},
+		{path: []interface{}{"dev", "ports", 0, "forward", 0, "bindAddress"}, line: 28, column: 7},
+		{path: []interface{}{"dev", "ports", 0, "forward", 1}, line: 26, column: 5},
+		{path: []interface{}{"dev", "ports", 0, "labelSelector", "app"}, line: 25, column: 7},
+		{path: []interface{}{"missing"}, line: 1, column: 1},
+	}
+
+	for _, testCase := range testCases {
+		line, column := Locate(content, testCase.path)
+		assert.Equal(t, line, testCase.line, "Unexpected line for %s", PathString(testCase.path))
+		assert.Equal(t, column, testCase.column, "Unexpected column for %s", PathString(testCase.path))
+	}
+}
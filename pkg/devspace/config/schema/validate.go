@@ -0,0 +1,342 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Violation is a value in a config that does not match the schema
+type Violation struct {
+	// Path holds the keys (strings) and indexes (ints) of the invalid value
+	Path    []interface{}
+	Message string
+
+	// Line and Column are the position of the invalid value in the config file, starting with 1. They are 0 if unknown
+	Line   int
+	Column int
+}
+
+// String returns the violation with its position and path
+func (v *Violation) String() string {
+	message := v.Message
+	if len(v.Path) > 0 {
+		message = PathString(v.Path) + ": " + message
+	}
+	if v.Line > 0 {
+		message = fmt.Sprintf("line %d, column %d: %s", v.Line, v.Column, message)
+	}
+
+	return message
+}
+
+// ValidationError is returned if a config file does not match the schema
+type ValidationError struct {
+	File       string
+	Violations []*Violation
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	lines := []string{fmt.Sprintf("Invalid config %s:", e.File)}
+	for _, violation := range e.Violations {
+		lines = append(lines, "  "+violation.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// PathString returns the path in the format deployments[0].helm.chart
+func PathString(path []interface{}) string {
+	str := ""
+	for _, segment := range path {
+		switch segment := segment.(type) {
+		case int:
+			str += "[" + strconv.Itoa(segment) + "]"
+		default:
+			if str != "" {
+				str += "."
+			}
+
+			str += fmt.Sprintf("%v", segment)
+		}
+	}
+
+	return str
+}
+
+// ValidateFile validates the parsed content of a config file against the schema. If the violations can be located in content,
+// their line and column are set. Returns nil if the config is valid and a *ValidationError otherwise
+func ValidateFile(schema *Schema, file string, content []byte, value interface{}) error {
+	violations := Validate(schema, value)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if content != nil {
+		for _, violation := range violations {
+			violation.Line, violation.Column = Locate(content, violation.Path)
+		}
+
+		sort.SliceStable(violations, func(i, j int) bool {
+			return violations[i].Line < violations[j].Line
+		})
+	}
+
+	return &ValidationError{
+		File:       file,
+		Violations: violations,
+	}
+}
+
+// Validate validates a value that was unmarshalled from yaml against the schema and returns all violations
+func Validate(schema *Schema, value interface{}) []*Violation {
+	v := &validator{root: schema}
+	v.validate(schema, value, []interface{}{})
+
+	return v.violations
+}
+
+type validator struct {
+	root       *Schema
+	violations []*Violation
+}
+
+func (v *validator) addViolation(path []interface{}, format string, args ...interface{}) {
+	v.violations = append(v.violations, &Violation{
+		Path:    append([]interface{}{}, path...),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) resolve(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		schema = v.root.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+		if schema == nil {
+			return &Schema{}
+		}
+	}
+
+	return schema
+}
+
+func (v *validator) validate(schema *Schema, value interface{}, path []interface{}) {
+	// A null value is the same as an omitted value
+	if value == nil {
+		return
+	}
+
+	schema = v.resolve(schema)
+	for _, subSchema := range schema.AllOf {
+		v.validate(subSchema, value, path)
+	}
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(schema.OneOf, value, path)
+	}
+
+	if schema.Type != "" && isType(schema.Type, value) == false {
+		v.addViolation(path, "expected %s but got %s", schema.Type, describe(value))
+		return
+	}
+	if len(schema.Enum) > 0 && isEnum(schema.Enum, value) == false {
+		v.addViolation(path, "invalid value %s, allowed values are %s", describe(value), enumString(schema.Enum))
+		return
+	}
+
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		v.validateObject(schema, value, path)
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validate(schema.Items, item, append(path, i))
+			}
+		}
+	}
+}
+
+func (v *validator) validateOneOf(schemas []*Schema, value interface{}, path []interface{}) {
+	var matching *Schema
+	for _, subSchema := range schemas {
+		if len(Validate(&Schema{AllOf: []*Schema{subSchema}, Definitions: v.root.Definitions}, value)) == 0 {
+			return
+		}
+
+		if matching == nil && isType(v.resolve(subSchema).Type, value) {
+			matching = subSchema
+		}
+	}
+
+	if matching != nil {
+		v.validate(matching, value, path)
+		return
+	}
+
+	types := []string{}
+	for _, subSchema := range schemas {
+		types = append(types, v.resolve(subSchema).Type)
+	}
+
+	v.addViolation(path, "expected %s but got %s", strings.Join(types, " or "), describe(value))
+}
+
+func (v *validator) validateObject(schema *Schema, value map[interface{}]interface{}, path []interface{}) {
+	for _, required := range schema.Required {
+		if value[required] == nil {
+			v.addViolation(path, "missing required field %q", required)
+		}
+	}
+
+	keys := make([]string, 0, len(value))
+	values := make(map[string]interface{}, len(value))
+	for key, fieldValue := range value {
+		keys = append(keys, fmt.Sprintf("%v", key))
+		values[fmt.Sprintf("%v", key)] = fieldValue
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := append(path, key)
+		if propertySchema, ok := schema.Properties[key]; ok {
+			v.validate(propertySchema, values[key], fieldPath)
+			continue
+		}
+
+		switch additional := schema.AdditionalProperties.(type) {
+		case bool:
+			if additional == false {
+				if suggestion := suggest(key, schema.Properties); suggestion != "" {
+					v.addViolation(fieldPath, "unknown field, did you mean %q?", suggestion)
+				} else {
+					v.addViolation(fieldPath, "unknown field")
+				}
+			}
+		case *Schema:
+			v.validate(additional, values[key], fieldPath)
+		}
+	}
+}
+
+func isType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "":
+		return true
+	case "object":
+		_, ok := value.(map[interface{}]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		// Scalars are converted to strings when the config is loaded, e.g. tag: 123
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return false
+		}
+
+		return true
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		switch value.(type) {
+		case int, int64, uint64:
+			return true
+		}
+	case "number":
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprintf("%v", allowed) == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func enumString(enum []interface{}) string {
+	values := []string{}
+	for _, value := range enum {
+		values = append(values, fmt.Sprintf("%q", fmt.Sprintf("%v", value)))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func describe(value interface{}) string {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return fmt.Sprintf("string %q", value)
+	case bool:
+		return fmt.Sprintf("boolean %v", value)
+	case float64:
+		return fmt.Sprintf("number %v", value)
+	}
+
+	return fmt.Sprintf("integer %v", value)
+}
+
+// suggest returns the property name that is most similar to the given unknown key
+func suggest(key string, properties map[string]*Schema) string {
+	suggestion := ""
+	bestDistance := 3
+	for property := range properties {
+		distance := levenshtein(strings.ToLower(key), strings.ToLower(property))
+		if distance < bestDistance || (distance == bestDistance && suggestion != "" && property < suggestion) {
+			suggestion = property
+			bestDistance = distance
+		}
+	}
+
+	return suggestion
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package varsource
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/util/command"
+)
+
+// commandSource uses the output of a command as value of a variable
+type commandSource struct{}
+
+// Resolve implements interface
+func (c *commandSource) Resolve(variable *configs.Variable) (string, error) {
+	if variable.Command == nil {
+		return "", errors.New("command is required")
+	}
+
+	args := []string{}
+	if variable.Args != nil {
+		args = *variable.Args
+	}
+
+	return runCommand(*variable.Command, args)
+}
+
+// runCommand executes the command and returns its output without the trailing newline, it is a variable so that
+// it can be replaced in tests
+var runCommand = func(name string, args []string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	err := command.NewStreamCommand(name, args).Run(stdout, stderr, nil)
+	if err != nil {
+		if stderr.Len() > 0 {
+			return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return "", err
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
@@ -0,0 +1,27 @@
+package varsource
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+)
+
+// fileSource reads the value of a variable from a file
+type fileSource struct{}
+
+// Resolve implements interface
+func (f *fileSource) Resolve(variable *configs.Variable) (string, error) {
+	if variable.File == nil {
+		return "", errors.New("file is required")
+	}
+
+	content, err := ioutil.ReadFile(*variable.File)
+	if err != nil {
+		return "", err
+	}
+
+	// Editors usually add a trailing newline that is not part of the value
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
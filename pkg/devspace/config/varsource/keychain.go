@@ -0,0 +1,52 @@
+package varsource
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+)
+
+// keychainSource reads the value of a variable from the keychain of the operating system. On macOS the login keychain
+// is used via the security tool and on linux the secret service (e.g. gnome-keyring) via secret-tool
+type keychainSource struct{}
+
+// Resolve implements interface
+func (k *keychainSource) Resolve(variable *configs.Variable) (string, error) {
+	if variable.Keychain == nil || variable.Keychain.Service == nil {
+		return "", errors.New("keychain.service is required")
+	}
+
+	account := ""
+	if variable.Keychain.Account != nil {
+		account = *variable.Keychain.Account
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{"find-generic-password", "-s", *variable.Keychain.Service, "-w"}
+		if account != "" {
+			args = append(args, "-a", account)
+		}
+
+		return runCommand("security", args)
+	case "linux":
+		args := []string{"lookup", "service", *variable.Keychain.Service}
+		if account != "" {
+			args = append(args, "account", account)
+		}
+
+		value, err := runCommand("secret-tool", args)
+		if err != nil {
+			return "", err
+		} else if value == "" {
+			// secret-tool exits without error if nothing was found
+			return "", fmt.Errorf("No keychain entry found for service %s", *variable.Keychain.Service)
+		}
+
+		return value, nil
+	default:
+		return "", fmt.Errorf("Reading from the keychain is not supported on %s", runtime.GOOS)
+	}
+}
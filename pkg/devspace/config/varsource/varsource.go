@@ -0,0 +1,44 @@
+package varsource
+
+import (
+	"fmt"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+)
+
+// Source resolves the value of a variable from somewhere else than the environment or user input
+type Source interface {
+	Resolve(variable *configs.Variable) (string, error)
+}
+
+// Sources holds all sources that can be selected via the source option of a variable
+var Sources = map[configs.VariableSource]Source{
+	configs.VariableSourceFile:     &fileSource{},
+	configs.VariableSourceCommand:  &commandSource{},
+	configs.VariableSourceKeychain: &keychainSource{},
+	configs.VariableSourceVault:    &vaultSource{},
+}
+
+// Get returns the source of the variable or nil if the variable is taken from the environment or user input
+func Get(variable *configs.Variable) Source {
+	if variable.Source == nil {
+		return nil
+	}
+
+	return Sources[*variable.Source]
+}
+
+// Resolve resolves the value of the variable with its source
+func Resolve(variable *configs.Variable) (string, error) {
+	source := Get(variable)
+	if source == nil {
+		return "", fmt.Errorf("Variable %s has no source to resolve it from", *variable.Name)
+	}
+
+	value, err := source.Resolve(variable)
+	if err != nil {
+		return "", fmt.Errorf("Error resolving variable %s from %s: %v", *variable.Name, *variable.Source, err)
+	}
+
+	return value, nil
+}
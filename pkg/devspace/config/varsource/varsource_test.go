@@ -0,0 +1,147 @@
+package varsource
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+func newVariable(source configs.VariableSource) *configs.Variable {
+	return &configs.Variable{
+		Name:   ptr.String("TEST"),
+		Source: &source,
+	}
+}
+
+func TestGet(t *testing.T) {
+	assert.Assert(t, Get(&configs.Variable{Name: ptr.String("TEST")}) == nil, "Source for variable without source")
+	assert.Assert(t, Get(newVariable(configs.VariableSourceInput)) == nil, "Source for input variable")
+	assert.Assert(t, Get(newVariable(configs.VariableSourceFile)) != nil, "No source for file variable")
+
+	_, err := Resolve(newVariable(configs.VariableSourceEnv))
+	assert.Error(t, err, "Variable TEST has no source to resolve it from")
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "value")
+	err = ioutil.WriteFile(file, []byte("my-value\n"), 0644)
+	assert.NilError(t, err, "Error writing file")
+
+	variable := newVariable(configs.VariableSourceFile)
+	_, err = Resolve(variable)
+	assert.Error(t, err, "Error resolving variable TEST from file: file is required")
+
+	variable.File = &file
+	value, err := Resolve(variable)
+	assert.NilError(t, err, "Error resolving variable")
+	assert.Equal(t, value, "my-value")
+}
+
+func TestCommandSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("echo is not available on windows")
+	}
+
+	variable := newVariable(configs.VariableSourceCommand)
+	variable.Command = ptr.String("echo")
+	variable.Args = &[]string{"hello", "world"}
+
+	value, err := Resolve(variable)
+	assert.NilError(t, err, "Error resolving variable")
+	assert.Equal(t, value, "hello world")
+}
+
+func TestKeychainSource(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Test only covers secret-tool")
+	}
+
+	runCommandBackup := runCommand
+	defer func() { runCommand = runCommandBackup }()
+
+	executed := ""
+	runCommand = func(name string, args []string) (string, error) {
+		executed = name + " " + strings.Join(args, " ")
+		return "keychain-value", nil
+	}
+
+	variable := newVariable(configs.VariableSourceKeychain)
+	variable.Keychain = &configs.KeychainSource{
+		Service: ptr.String("my-service"),
+		Account: ptr.String("me"),
+	}
+
+	value, err := Resolve(variable)
+	assert.NilError(t, err, "Error resolving variable")
+	assert.Equal(t, value, "keychain-value")
+	assert.Equal(t, executed, "secret-tool lookup service my-service account me")
+}
+
+func TestVaultSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/my-app":
+			w.Write([]byte(`{"data":{"password":"v1-password"}}`))
+		case "/v1/secret/data/my-app":
+			w.Write([]byte(`{"data":{"data":{"password":"v2-password"},"metadata":{"version":1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	tokenBackup := os.Getenv("VAULT_TOKEN")
+	defer os.Setenv("VAULT_TOKEN", tokenBackup)
+	os.Setenv("VAULT_TOKEN", "my-token")
+
+	variable := newVariable(configs.VariableSourceVault)
+	variable.Vault = &configs.VaultSource{
+		Address: ptr.String(server.URL),
+		Path:    ptr.String("secret/my-app"),
+		Key:     ptr.String("password"),
+	}
+
+	value, err := Resolve(variable)
+	assert.NilError(t, err, "Error resolving variable from key value engine v1")
+	assert.Equal(t, value, "v1-password")
+
+	variable.Vault.Path = ptr.String("secret/data/my-app")
+	value, err = Resolve(variable)
+	assert.NilError(t, err, "Error resolving variable from key value engine v2")
+	assert.Equal(t, value, "v2-password")
+
+	variable.Vault.Key = ptr.String("missing")
+	_, err = Resolve(variable)
+	assert.Error(t, err, "Error resolving variable TEST from vault: Secret secret/data/my-app has no key missing")
+
+	variable.Vault.Path = ptr.String("secret/missing")
+	_, err = Resolve(variable)
+	assert.Error(t, err, "Error resolving variable TEST from vault: Error reading secret/missing (status 404)")
+
+	os.Setenv("VAULT_TOKEN", "wrong-token")
+	_, err = Resolve(variable)
+	assert.Error(t, err, "Error resolving variable TEST from vault: Error reading secret/missing (status 403): permission denied")
+}
@@ -0,0 +1,123 @@
+package varsource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// DefaultVaultAddress is the address of the Vault server if neither vault.address nor VAULT_ADDR is set
+const DefaultVaultAddress = "http://127.0.0.1:8200"
+
+// vaultSource reads the value of a variable from a Vault compatible http api. The token is taken from VAULT_TOKEN or
+// ~/.vault-token (the file written by vault login)
+type vaultSource struct{}
+
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// Resolve implements interface
+func (v *vaultSource) Resolve(variable *configs.Variable) (string, error) {
+	if variable.Vault == nil || variable.Vault.Path == nil || variable.Vault.Key == nil {
+		return "", errors.New("vault.path and vault.key are required")
+	}
+
+	address := os.Getenv("VAULT_ADDR")
+	if variable.Vault.Address != nil {
+		address = *variable.Vault.Address
+	}
+	if address == "" {
+		address = DefaultVaultAddress
+	}
+
+	token, err := vaultToken()
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(address, "/") + "/v1/" + strings.TrimPrefix(*variable.Vault.Path, "/")
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	secret := &vaultResponse{}
+	err = json.Unmarshal(body, secret)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing response of %s (status %d): %v", url, response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		if len(secret.Errors) > 0 {
+			return "", fmt.Errorf("Error reading %s (status %d): %s", *variable.Vault.Path, response.StatusCode, strings.Join(secret.Errors, ", "))
+		}
+
+		return "", fmt.Errorf("Error reading %s (status %d)", *variable.Vault.Path, response.StatusCode)
+	}
+
+	// The key value engine v2 wraps the values in data.data
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	value, ok := data[*variable.Vault.Key]
+	if ok == false {
+		return "", fmt.Errorf("Secret %s has no key %s", *variable.Vault.Path, *variable.Vault.Key)
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
@@ -0,0 +1,95 @@
+package latest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AutoPort is the value of port that selects any free local port
+const AutoPort = "auto"
+
+// portMapping is the yaml representation of a PortMapping, where port can be a number or a string
+type portMapping struct {
+	Port        interface{} `yaml:"port"`
+	RemotePort  *int        `yaml:"remotePort,omitempty"`
+	BindAddress *string     `yaml:"bindAddress,omitempty"`
+}
+
+// UnmarshalYAML allows port to be a number, auto or a range like 8080-8090
+func (p *PortMapping) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := &portMapping{}
+	err := unmarshal(raw)
+	if err != nil {
+		return err
+	}
+
+	p.LocalPort = nil
+	p.LocalPortRange = nil
+	p.RemotePort = raw.RemotePort
+	p.BindAddress = raw.BindAddress
+
+	switch port := raw.Port.(type) {
+	case nil:
+	case int:
+		p.LocalPort = &port
+	case string:
+		_, _, err := ParsePortRange(port)
+		if err != nil {
+			return err
+		}
+
+		p.LocalPortRange = &port
+	default:
+		return fmt.Errorf("port %v must be a number, %s or a range like 8080-8090", port, AutoPort)
+	}
+
+	return nil
+}
+
+// MarshalYAML writes port as number or as string if it is auto or a range
+func (p *PortMapping) MarshalYAML() (interface{}, error) {
+	raw := &portMapping{
+		RemotePort:  p.RemotePort,
+		BindAddress: p.BindAddress,
+	}
+
+	if p.LocalPort != nil {
+		raw.Port = *p.LocalPort
+	} else if p.LocalPortRange != nil {
+		raw.Port = *p.LocalPortRange
+	}
+
+	return raw, nil
+}
+
+// ParsePortRange returns the first and last port of a port range like 8080-8090. For auto both ports are 0
+func ParsePortRange(portRange string) (int, int, error) {
+	if portRange == AutoPort {
+		return 0, 0, nil
+	}
+
+	bounds := strings.Split(portRange, "-")
+	if len(bounds) > 2 {
+		return 0, 0, fmt.Errorf("port %s must be a number, %s or a range like 8080-8090", portRange, AutoPort)
+	}
+
+	first, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("port %s must be a number, %s or a range like 8080-8090", portRange, AutoPort)
+	}
+
+	last := first
+	if len(bounds) == 2 {
+		last, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return 0, 0, fmt.Errorf("port %s must be a number, %s or a range like 8080-8090", portRange, AutoPort)
+		}
+	}
+
+	if first < 1 || last > 65535 || first > last {
+		return 0, 0, fmt.Errorf("port range %s must be between 1 and 65535 and start with the lower port", portRange)
+	}
+
+	return first, last, nil
+}
@@ -0,0 +1,50 @@
+package latest
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestParsePortRange(t *testing.T) {
+	first, last, err := ParsePortRange(AutoPort)
+	assert.NilError(t, err, "Error parsing auto")
+	assert.Equal(t, first, 0)
+	assert.Equal(t, last, 0)
+
+	first, last, err = ParsePortRange("8080-8090")
+	assert.NilError(t, err, "Error parsing range")
+	assert.Equal(t, first, 8080)
+	assert.Equal(t, last, 8090)
+
+	first, last, err = ParsePortRange("3000")
+	assert.NilError(t, err, "Error parsing single port")
+	assert.Equal(t, first, 3000)
+	assert.Equal(t, last, 3000)
+
+	for _, portRange := range []string{"", "abc", "8090-8080", "0-10", "65535-65536", "1-2-3"} {
+		_, _, err = ParsePortRange(portRange)
+		assert.Assert(t, err != nil, "No error parsing %s", portRange)
+	}
+}
+
+func TestPortMappingYAML(t *testing.T) {
+	portMappings := []*PortMapping{}
+	err := yaml.UnmarshalStrict([]byte("- port: 8080\n- port: auto\n  remotePort: 3000\n- port: 9000-9010\n  remotePort: 80\n"), &portMappings)
+	assert.NilError(t, err, "Error unmarshaling port mappings")
+
+	assert.Equal(t, *portMappings[0].LocalPort, 8080)
+	assert.Assert(t, portMappings[0].LocalPortRange == nil, "Port range set for a number")
+	assert.Assert(t, portMappings[1].LocalPort == nil, "Port set for auto")
+	assert.Equal(t, *portMappings[1].LocalPortRange, AutoPort)
+	assert.Equal(t, *portMappings[1].RemotePort, 3000)
+	assert.Equal(t, *portMappings[2].LocalPortRange, "9000-9010")
+
+	out, err := yaml.Marshal(portMappings)
+	assert.NilError(t, err, "Error marshaling port mappings")
+	assert.Equal(t, string(out), "- port: 8080\n- port: auto\n  remotePort: 3000\n- port: 9000-9010\n  remotePort: 80\n")
+
+	err = yaml.UnmarshalStrict([]byte("- port: 10-5\n"), &portMappings)
+	assert.Assert(t, err != nil, "No error unmarshaling an invalid range")
+}
@@ -6,7 +6,7 @@ import (
 )
 
 // Version is the current api version
-const Version string = "v1beta2"
+const Version string = "v1beta3"
 
 // GetVersion returns the version
 func (c *Config) GetVersion() string {
@@ -31,12 +31,15 @@ func NewRaw() *Config {
 // Config defines the configuration
 type Config struct {
 	Version      *string                  `yaml:"version"`
+	Name         *string                  `yaml:"name,omitempty"`
+	Imports      *[]*ImportConfig         `yaml:"imports,omitempty"`
 	Images       *map[string]*ImageConfig `yaml:"images,omitempty"`
 	Deployments  *[]*DeploymentConfig     `yaml:"deployments,omitempty"`
 	Dev          *DevConfig               `yaml:"dev,omitempty"`
 	Dependencies *[]*DependencyConfig     `yaml:"dependencies,omitempty"`
 	Hooks        *[]*HookConfig           `yaml:"hooks,omitempty"`
 	Cluster      *Cluster                 `yaml:"cluster,omitempty"`
+	Profiles     *[]*ProfileConfig        `yaml:"profiles,omitempty"`
 }
 
 // ImageConfig defines the image specification
@@ -95,9 +98,19 @@ type BuildOptions struct {
 type DeploymentConfig struct {
 	Name      *string          `yaml:"name"`
 	Namespace *string          `yaml:"namespace,omitempty"`
+	DependsOn *[]*string       `yaml:"dependsOn,omitempty"`
 	Component *ComponentConfig `yaml:"component,omitempty"`
 	Helm      *HelmConfig      `yaml:"helm,omitempty"`
 	Kubectl   *KubectlConfig   `yaml:"kubectl,omitempty"`
+	Template  *TemplateConfig  `yaml:"template,omitempty"`
+	Rollout   *RolloutConfig   `yaml:"rollout,omitempty"`
+}
+
+// RolloutConfig defines how the rollout of the workloads of a deployment is tracked after deploying
+type RolloutConfig struct {
+	Disabled *bool  `yaml:"disabled,omitempty"`
+	Timeout  *int64 `yaml:"timeout,omitempty"`
+	WarnOnly *bool  `yaml:"warnOnly,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -218,15 +231,24 @@ type ComponentConfigOptions struct {
 
 // HelmConfig defines the specific helm options used during deployment
 type HelmConfig struct {
-	Chart           *ChartConfig                 `yaml:"chart,omitempty"`
-	Wait            *bool                        `yaml:"wait,omitempty"`
-	Rollback        *bool                        `yaml:"rollback,omitempty"`
-	Force           *bool                        `yaml:"force,omitempty"`
-	Timeout         *int64                       `yaml:"timeout,omitempty"`
-	TillerNamespace *string                      `yaml:"tillerNamespace,omitempty"`
-	DevSpaceValues  *bool                        `yaml:"devSpaceValues,omitempty"`
-	ValuesFiles     *[]*string                   `yaml:"valuesFiles,omitempty"`
-	Values          *map[interface{}]interface{} `yaml:"values,omitempty"`
+	Chart           *ChartConfig                   `yaml:"chart,omitempty"`
+	Wait            *bool                          `yaml:"wait,omitempty"`
+	Rollback        *bool                          `yaml:"rollback,omitempty"`
+	Force           *bool                          `yaml:"force,omitempty"`
+	Timeout         *int64                         `yaml:"timeout,omitempty"`
+	TillerNamespace *string                        `yaml:"tillerNamespace,omitempty"`
+	DevSpaceValues  *bool                          `yaml:"devSpaceValues,omitempty"`
+	ImageValues     *map[string]*ImageValuesConfig `yaml:"imageValues,omitempty"`
+	ValuesFiles     *[]*string                     `yaml:"valuesFiles,omitempty"`
+	Values          *map[interface{}]interface{}   `yaml:"values,omitempty"`
+}
+
+// ImageValuesConfig defines the chart value paths a built image is written to
+type ImageValuesConfig struct {
+	Image      *string `yaml:"image,omitempty"`
+	Repository *string `yaml:"repository,omitempty"`
+	Tag        *string `yaml:"tag,omitempty"`
+	Digest     *string `yaml:"digest,omitempty"`
 }
 
 // ChartConfig defines the helm chart options
@@ -246,6 +268,15 @@ type KubectlConfig struct {
 	Flags     *[]*string `yaml:"flags,omitempty"`
 }
 
+// TemplateConfig defines a directory of go templated manifests that is rendered by devspace and applied with kubectl
+type TemplateConfig struct {
+	Path        *string                      `yaml:"path,omitempty"`
+	ValuesFiles *[]*string                   `yaml:"valuesFiles,omitempty"`
+	Values      *map[interface{}]interface{} `yaml:"values,omitempty"`
+	CmdPath     *string                      `yaml:"cmdPath,omitempty"`
+	Flags       *[]*string                   `yaml:"flags,omitempty"`
+}
+
 // DevConfig defines the devspace deployment
 type DevConfig struct {
 	OverrideImages *[]*ImageOverrideConfig  `yaml:"overrideImages,omitempty"`
@@ -253,6 +284,7 @@ type DevConfig struct {
 	Ports          *[]*PortForwardingConfig `yaml:"ports,omitempty"`
 	Sync           *[]*SyncConfig           `yaml:"sync,omitempty"`
 	AutoReload     *AutoReloadConfig        `yaml:"autoReload,omitempty"`
+	Logs           *LogsConfig              `yaml:"logs,omitempty"`
 	Selectors      *[]*SelectorConfig       `yaml:"selectors,omitempty"`
 }
 
@@ -276,10 +308,13 @@ type Terminal struct {
 
 // PortForwardingConfig defines the ports for a port forwarding to a DevSpace
 type PortForwardingConfig struct {
-	Selector      *string             `yaml:"selector,omitempty"`
-	Namespace     *string             `yaml:"namespace,omitempty"`
-	LabelSelector *map[string]*string `yaml:"labelSelector,omitempty"`
-	PortMappings  *[]*PortMapping     `yaml:"forward"`
+	Selector            *string             `yaml:"selector,omitempty"`
+	Namespace           *string             `yaml:"namespace,omitempty"`
+	LabelSelector       *map[string]*string `yaml:"labelSelector,omitempty"`
+	Service             *string             `yaml:"service,omitempty"`
+	Deployment          *string             `yaml:"deployment,omitempty"`
+	PortMappings        *[]*PortMapping     `yaml:"forward,omitempty"`
+	ReversePortMappings *[]*PortMapping     `yaml:"reverseForward,omitempty"`
 }
 
 // PortMapping defines the ports for a PortMapping
@@ -287,6 +322,9 @@ type PortMapping struct {
 	LocalPort   *int    `yaml:"port"`
 	RemotePort  *int    `yaml:"remotePort,omitempty"`
 	BindAddress *string `yaml:"bindAddress,omitempty"`
+
+	// LocalPortRange is set instead of LocalPort if port is auto or a range like 8080-8090
+	LocalPortRange *string `yaml:"-"`
 }
 
 // SyncConfig defines the paths for a SyncFolder
@@ -315,6 +353,22 @@ type AutoReloadConfig struct {
 	Paths       *[]*string `yaml:"paths,omitempty"`
 	Deployments *[]*string `yaml:"deployments,omitempty"`
 	Images      *[]*string `yaml:"images,omitempty"`
+	Debounce    *int64     `yaml:"debounce,omitempty"`
+}
+
+// LogsConfig defines the pods whose logs are streamed during devspace dev
+type LogsConfig struct {
+	Disabled  *bool            `yaml:"disabled,omitempty"`
+	Selectors *[]*LogsSelector `yaml:"selectors,omitempty"`
+	Lines     *int64           `yaml:"lines,omitempty"`
+}
+
+// LogsSelector defines the pods and containers to stream the logs of
+type LogsSelector struct {
+	Selector      *string             `yaml:"selector,omitempty"`
+	Namespace     *string             `yaml:"namespace,omitempty"`
+	LabelSelector *map[string]*string `yaml:"labelSelector,omitempty"`
+	ContainerName *string             `yaml:"containerName,omitempty"`
 }
 
 // SelectorConfig defines the selectors that belong to the devspace
@@ -344,6 +398,12 @@ type SourceConfig struct {
 	Path *string `yaml:"path,omitempty"`
 }
 
+// ImportConfig defines a config fragment that is merged into the config
+type ImportConfig struct {
+	Source *SourceConfig `yaml:"source"`
+	File   *string       `yaml:"file,omitempty"`
+}
+
 // HookConfig defines a hook
 type HookConfig struct {
 	Command *string    `yaml:"command"`
@@ -369,3 +429,16 @@ type Cluster struct {
 	KubeContext *string `yaml:"kubeContext,omitempty"`
 	Namespace   *string `yaml:"namespace,omitempty"`
 }
+
+// ProfileConfig defines a profile that patches the config when it is selected via --profile
+type ProfileConfig struct {
+	Name    *string         `yaml:"name"`
+	Patches *[]*PatchConfig `yaml:"patches,omitempty"`
+}
+
+// PatchConfig describes a single patch operation of a profile
+type PatchConfig struct {
+	Operation *string     `yaml:"op"`
+	Path      *string     `yaml:"path"`
+	Value     interface{} `yaml:"value,omitempty"`
+}
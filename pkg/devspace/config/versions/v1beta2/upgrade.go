@@ -0,0 +1,28 @@
+package v1beta2
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/config"
+	next "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+// Upgrade upgrades the config
+func (c *Config) Upgrade() (config.Config, error) {
+	nextConfig := &next.Config{}
+	err := util.Convert(c, nextConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Built images were replaced in the values of helm deployments by default, which is opt-in now
+	if nextConfig.Deployments != nil {
+		for _, deployConfig := range *nextConfig.Deployments {
+			if deployConfig.Helm != nil && deployConfig.Helm.DevSpaceValues == nil && deployConfig.Helm.ImageValues == nil {
+				deployConfig.Helm.DevSpaceValues = ptr.Bool(true)
+			}
+		}
+	}
+
+	return nextConfig, nil
+}
@@ -0,0 +1,103 @@
+package v1beta2
+
+import (
+	"reflect"
+	"testing"
+
+	next "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type testCase struct {
+	in       *Config
+	expected *next.Config
+}
+
+func TestSimple(t *testing.T) {
+	testCases := []*testCase{
+		{
+			in: &Config{
+				Deployments: &[]*DeploymentConfig{
+					&DeploymentConfig{
+						Name: ptr.String("helm"),
+						Helm: &HelmConfig{
+							Chart: &ChartConfig{Name: ptr.String("chart")},
+						},
+					},
+					&DeploymentConfig{
+						Name: ptr.String("disabled"),
+						Helm: &HelmConfig{
+							Chart:          &ChartConfig{Name: ptr.String("chart")},
+							DevSpaceValues: ptr.Bool(false),
+						},
+					},
+					&DeploymentConfig{
+						Name: ptr.String("image-values"),
+						Helm: &HelmConfig{
+							Chart: &ChartConfig{Name: ptr.String("chart")},
+							ImageValues: &map[string]*ImageValuesConfig{
+								"default": &ImageValuesConfig{Image: ptr.String("image")},
+							},
+						},
+					},
+					&DeploymentConfig{
+						Name: ptr.String("kubectl"),
+						Kubectl: &KubectlConfig{
+							Manifests: &[]*string{ptr.String("kube")},
+						},
+					},
+				},
+			},
+			expected: &next.Config{
+				Deployments: &[]*next.DeploymentConfig{
+					&next.DeploymentConfig{
+						Name: ptr.String("helm"),
+						Helm: &next.HelmConfig{
+							Chart:          &next.ChartConfig{Name: ptr.String("chart")},
+							DevSpaceValues: ptr.Bool(true),
+						},
+					},
+					&next.DeploymentConfig{
+						Name: ptr.String("disabled"),
+						Helm: &next.HelmConfig{
+							Chart:          &next.ChartConfig{Name: ptr.String("chart")},
+							DevSpaceValues: ptr.Bool(false),
+						},
+					},
+					&next.DeploymentConfig{
+						Name: ptr.String("image-values"),
+						Helm: &next.HelmConfig{
+							Chart: &next.ChartConfig{Name: ptr.String("chart")},
+							ImageValues: &map[string]*next.ImageValuesConfig{
+								"default": &next.ImageValuesConfig{Image: ptr.String("image")},
+							},
+						},
+					},
+					&next.DeploymentConfig{
+						Name: ptr.String("kubectl"),
+						Kubectl: &next.KubectlConfig{
+							Manifests: &[]*string{ptr.String("kube")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Run test cases
+	for index, testCase := range testCases {
+		newConfig, err := testCase.in.Upgrade()
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		isEqual := reflect.DeepEqual(newConfig, testCase.expected)
+		if !isEqual {
+			newConfigYaml, _ := yaml.Marshal(newConfig)
+			expectedYaml, _ := yaml.Marshal(testCase.expected)
+
+			t.Fatalf("TestCase %d: Got %s, but expected %s", index, newConfigYaml, expectedYaml)
+		}
+	}
+}
@@ -30,7 +30,10 @@ func GetDockerfileComponentDeployment(config *latest.Config, generatedConfig *ge
 			return nil, nil, errors.Wrap(err, "get image config")
 		}
 	} else {
-		imageConfig = GetImageConfigFromImageName(imageName, dockerfile, context)
+		imageConfig, err = GetImageConfigFromImageName(imageName, dockerfile, context)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "get image config")
+		}
 	}
 
 	if imageName == "" {
@@ -56,19 +59,26 @@ func GetDockerfileComponentDeployment(config *latest.Config, generatedConfig *ge
 		if len(ports) == 1 {
 			port = strconv.Itoa(ports[0])
 		} else if len(ports) > 1 {
-			port = survey.Question(&survey.QuestionOptions{
+			port, err = survey.Question(&survey.QuestionOptions{
 				Question:     "Which port is the container listening on?",
 				DefaultValue: strconv.Itoa(ports[0]),
 			})
+			if err != nil {
+				return nil, nil, err
+			}
 			if port == "" {
 				port = strconv.Itoa(ports[0])
 			}
 		}
 	}
 	if port == "" {
-		port = survey.Question(&survey.QuestionOptions{
+		port, err = survey.Question(&survey.QuestionOptions{
 			Question: "Which port is the container listening on? (Enter to skip)",
+			Optional: true,
 		})
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	if port != "" {
 		port, err := strconv.Atoi(port)
@@ -102,9 +112,13 @@ func GetImageComponentDeployment(name, imageName string) (*latest.ImageConfig, *
 	}
 
 	// Configure port
-	port := survey.Question(&survey.QuestionOptions{
+	port, err := survey.Question(&survey.QuestionOptions{
 		Question: "Which port do you want to expose for this image? (Enter to skip)",
+		Optional: true,
 	})
+	if err != nil {
+		return nil, nil, err
+	}
 	if port != "" {
 		port, err := strconv.Atoi(port)
 		if err != nil {
@@ -121,7 +135,11 @@ func GetImageComponentDeployment(name, imageName string) (*latest.ImageConfig, *
 	}
 
 	// Check if we should create pull secret
-	retImageConfig := GetImageConfigFromImageName(imageName, "", "")
+	retImageConfig, err := GetImageConfigFromImageName(imageName, "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return retImageConfig, retDeploymentConfig, nil
 }
 
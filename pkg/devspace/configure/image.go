@@ -23,12 +23,21 @@ import (
 const DefaultImageName = "devspace"
 
 // GetImageConfigFromImageName returns an image config based on the image
-func GetImageConfigFromImageName(imageName, dockerfile, context string) *latest.ImageConfig {
+func GetImageConfigFromImageName(imageName, dockerfile, context string) (*latest.ImageConfig, error) {
 	// Configure pull secret
-	createPullSecret := dockerfile != "" || survey.Question(&survey.QuestionOptions{
-		Question: "Do you want to enable automatic creation of pull secrets for this image?",
-		Options:  []string{"no", "yes"},
-	}) == "yes"
+	createPullSecret := dockerfile != ""
+	if createPullSecret == false {
+		createPullSecretAnswer, err := survey.Question(&survey.QuestionOptions{
+			Question:     "Do you want to enable automatic creation of pull secrets for this image?",
+			DefaultValue: "no",
+			Options:      []string{"no", "yes"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		createPullSecret = createPullSecretAnswer == "yes"
+	}
 
 	if createPullSecret {
 		// Figure out tag
@@ -62,10 +71,10 @@ func GetImageConfigFromImageName(imageName, dockerfile, context string) *latest.
 			}
 		}
 
-		return retImageConfig
+		return retImageConfig, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 // GetImageConfigFromDockerfile gets the image config based on the configured cloud provider or asks the user where to push to
@@ -106,11 +115,14 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 
 	// Check which registry to use
 	if cloudProvider == nil {
-		registryURL = survey.Question(&survey.QuestionOptions{
+		registryURL, err = survey.Question(&survey.QuestionOptions{
 			Question:               "Which registry do you want to push to? ('hub.docker.com' or URL)",
 			DefaultValue:           "hub.docker.com",
 			ValidationRegexPattern: "^.*$",
 		})
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		// Get default registry
 		provider, err := cloud.GetProvider(cloudProvider, log.GetInstance())
@@ -145,18 +157,25 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 		log.Warn("Installing docker is NOT required\n")
 
 		for {
-			dockerUsername = survey.Question(&survey.QuestionOptions{
+			dockerUsername, err = survey.Question(&survey.QuestionOptions{
 				Question:               "What is your docker hub username?",
 				DefaultValue:           "",
 				ValidationRegexPattern: "^.*$",
+				NonInteractiveHint:     "Please login to docker hub with 'docker login'",
 			})
+			if err != nil {
+				return nil, err
+			}
 
-			dockerPassword := survey.Question(&survey.QuestionOptions{
+			dockerPassword, err := survey.Question(&survey.QuestionOptions{
 				Question:               "What is your docker hub password?",
 				DefaultValue:           "",
 				ValidationRegexPattern: "^.*$",
 				IsPassword:             true,
 			})
+			if err != nil {
+				return nil, err
+			}
 
 			_, err = docker.Login(client, registryURL, dockerUsername, dockerPassword, false, true, true)
 			if err != nil {
@@ -173,7 +192,7 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 
 	// Is docker hub?
 	if registryURL == "hub.docker.com" {
-		defaultImageName = survey.Question(&survey.QuestionOptions{
+		defaultImageName, err = survey.Question(&survey.QuestionOptions{
 			Question:          "Which image name do you want to use on Docker Hub?",
 			DefaultValue:      dockerUsername + "/devspace",
 			ValidationMessage: "Please enter a valid docker image name (e.g. myregistry.com/user/repository)",
@@ -182,6 +201,10 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 				return err
 			},
 		})
+		if err != nil {
+			return nil, err
+		}
+
 		defaultImageName, _ = registry.GetStrippedDockerImageName(defaultImageName)
 	} else if regexp.MustCompile("^(.+\\.)?gcr.io$").Match([]byte(registryURL)) { // Is google registry?
 		project, err := exec.Command("gcloud", "config", "get-value", "project").Output()
@@ -191,7 +214,7 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 			gcloudProject = strings.TrimSpace(string(project))
 		}
 
-		defaultImageName = survey.Question(&survey.QuestionOptions{
+		defaultImageName, err = survey.Question(&survey.QuestionOptions{
 			Question:          "Which image name do you want to push to?",
 			DefaultValue:      registryURL + "/" + gcloudProject + "/devspace",
 			ValidationMessage: "Please enter a valid docker image name (e.g. myregistry.com/user/repository)",
@@ -200,6 +223,10 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 				return err
 			},
 		})
+		if err != nil {
+			return nil, err
+		}
+
 		defaultImageName, _ = registry.GetStrippedDockerImageName(defaultImageName)
 	} else if cloudProvider != nil {
 		// Is DevSpace Cloud?
@@ -209,7 +236,7 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 			dockerUsername = "myuser"
 		}
 
-		defaultImageName = survey.Question(&survey.QuestionOptions{
+		defaultImageName, err = survey.Question(&survey.QuestionOptions{
 			Question:          "Which image name do you want to push to?",
 			DefaultValue:      registryURL + "/" + dockerUsername + "/devspace",
 			ValidationMessage: "Please enter a valid docker image name (e.g. myregistry.com/user/repository)",
@@ -218,16 +245,26 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 				return err
 			},
 		})
+		if err != nil {
+			return nil, err
+		}
+
 		defaultImageName, _ = registry.GetStrippedDockerImageName(defaultImageName)
 	}
 
 	// Check if we should create pull secrets for the image
 	createPullSecret := true
 	if cloudProvider == nil {
-		createPullSecret = survey.Question(&survey.QuestionOptions{
-			Question: "Do you want to enable automatic creation of pull secrets for this image?",
-			Options:  []string{"yes", "no"},
-		}) == "yes"
+		createPullSecretAnswer, err := survey.Question(&survey.QuestionOptions{
+			Question:     "Do you want to enable automatic creation of pull secrets for this image?",
+			DefaultValue: "yes",
+			Options:      []string{"yes", "no"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		createPullSecret = createPullSecretAnswer == "yes"
 	}
 
 	// Set image name
@@ -112,6 +112,10 @@ func RemovePort(removeAll bool, labelSelector string, args []string) error {
 			}
 
 			newPortMappings := []*latest.PortMapping{}
+			if v.PortMappings == nil {
+				v.PortMappings = &newPortMappings
+			}
+
 			for _, pm := range *v.PortMappings {
 				if pm.LocalPort != nil && containsPort(strconv.Itoa(*pm.LocalPort), ports) {
 					continue
@@ -126,6 +130,9 @@ func RemovePort(removeAll bool, labelSelector string, args []string) error {
 			if len(newPortMappings) > 0 {
 				v.PortMappings = &newPortMappings
 				newPortForwards = append(newPortForwards, v)
+			} else if v.ReversePortMappings != nil && len(*v.ReversePortMappings) > 0 {
+				v.PortMappings = nil
+				newPortForwards = append(newPortForwards, v)
 			}
 		}
 
@@ -166,6 +173,10 @@ func insertOrReplacePortMapping(config *latest.Config, namespace string, labelSe
 		}
 
 		if areLabelMapsEqual(selectors, labelSelectorMap) {
+			if v.PortMappings == nil {
+				v.PortMappings = &[]*latest.PortMapping{}
+			}
+
 			portMap := append(*v.PortMappings, portMappings...)
 			v.PortMappings = &portMap
 			return
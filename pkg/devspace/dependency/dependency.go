@@ -13,6 +13,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/util/graph"
 	"github.com/devspace-cloud/devspace/pkg/util/hash"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 
@@ -39,7 +40,7 @@ func UpdateAll(config *latest.Config, cache *generated.Config, allowCyclic bool,
 	// Resolve all dependencies
 	_, err = resolver.Resolve(*config.Dependencies, true)
 	if err != nil {
-		if _, ok := err.(*CyclicError); ok {
+		if _, ok := err.(*graph.CyclicError); ok {
 			return fmt.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
 		}
 
@@ -64,7 +65,7 @@ func BuildAll(config *latest.Config, cache *generated.Config, allowCyclic, updat
 	// Resolve all dependencies
 	dependencies, err := resolver.Resolve(*config.Dependencies, updateDependencies)
 	if err != nil {
-		if _, ok := err.(*CyclicError); ok {
+		if _, ok := err.(*graph.CyclicError); ok {
 			return fmt.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
 		}
 
@@ -115,7 +116,7 @@ func DeployAll(config *latest.Config, cache *generated.Config, allowCyclic, upda
 	// Resolve all dependencies
 	dependencies, err := resolver.Resolve(*config.Dependencies, updateDependencies)
 	if err != nil {
-		if _, ok := err.(*CyclicError); ok {
+		if _, ok := err.(*graph.CyclicError); ok {
 			return fmt.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
 		}
 
@@ -166,7 +167,7 @@ func PurgeAll(config *latest.Config, cache *generated.Config, allowCyclic bool,
 	// Resolve all dependencies
 	dependencies, err := resolver.Resolve(*config.Dependencies, false)
 	if err != nil {
-		if _, ok := err.(*CyclicError); ok {
+		if _, ok := err.(*graph.CyclicError); ok {
 			return fmt.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
 		}
 
@@ -246,7 +247,7 @@ func (d *Dependency) Build(skipPush, forceDependencies, forceBuild bool, log log
 	builtImages := make(map[string]string)
 	if d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false {
 		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, log)
+		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, nil, log)
 		if err != nil {
 			return err
 		}
@@ -321,7 +322,7 @@ func (d *Dependency) Deploy(skipPush bool, forceDependencies, skipBuild, forceBu
 	builtImages := make(map[string]string)
 	if skipBuild == false && (d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false) {
 		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, log)
+		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, nil, log)
 		if err != nil {
 			return err
 		}
@@ -336,7 +337,7 @@ func (d *Dependency) Deploy(skipPush bool, forceDependencies, skipBuild, forceBu
 	}
 
 	// Deploy all defined deployments
-	err = deploy.All(d.Config, d.GeneratedConfig.GetActive(), client, false, forceDeploy, builtImages, nil, log)
+	err = deploy.All(d.Config, d.GeneratedConfig.GetActive(), client, false, forceDeploy, 1, false, builtImages, nil, log)
 	if err != nil {
 		return err
 	}
@@ -10,27 +10,14 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/dependency/source"
 	"github.com/devspace-cloud/devspace/pkg/util/git"
-	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/devspace-cloud/devspace/pkg/util/graph"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 
-	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 )
 
-// DependencyFolder is the dependency folder in the home directory of the user
-const DependencyFolder = ".devspace/dependencies"
-
-// DependencyFolderPath will be filled during init
-var DependencyFolderPath string
-
-func init() {
-	// Make sure dependency folder exists locally
-	homedir, _ := homedir.Dir()
-
-	DependencyFolderPath = filepath.Join(homedir, filepath.FromSlash(DependencyFolder))
-}
-
 // ResolverInterface defines the resolver interface that takes dependency configs and resolves them
 type ResolverInterface interface {
 	Resolve(dependencies []*latest.DependencyConfig, update bool) ([]*Dependency, error)
@@ -38,7 +25,7 @@ type ResolverInterface interface {
 
 // Resolver implements the resolver interface
 type Resolver struct {
-	DependencyGraph *Graph
+	DependencyGraph *graph.Graph
 
 	BasePath   string
 	BaseConfig *latest.Config
@@ -66,7 +53,7 @@ func NewResolver(baseConfig *latest.Config, baseCache *generated.Config, allowCy
 	}
 
 	return &Resolver{
-		DependencyGraph: NewGraph(NewNode(id, nil)),
+		DependencyGraph: graph.NewGraph(graph.NewNode(id, nil)),
 
 		BaseConfig: baseConfig,
 		BaseCache:  baseCache,
@@ -89,7 +76,7 @@ func (r *Resolver) Resolve(dependencies []*latest.DependencyConfig, update bool)
 
 	err = r.resolveRecursive(currentWorkingDirectory, r.DependencyGraph.Root.ID, dependencies, update)
 	if err != nil {
-		if _, ok := err.(*CyclicError); ok {
+		if _, ok := err.(*graph.CyclicError); ok {
 			return nil, err
 		}
 
@@ -129,7 +116,7 @@ func (r *Resolver) resolveRecursive(basePath, parentID string, dependencies []*l
 		if _, ok := r.DependencyGraph.Nodes[ID]; ok {
 			err := r.DependencyGraph.AddEdge(parentID, ID)
 			if err != nil {
-				if _, ok := err.(*CyclicError); ok {
+				if _, ok := err.(*graph.CyclicError); ok {
 					// Check if cyclic dependencies are allowed
 					if !r.AllowCyclic {
 						return err
@@ -174,54 +161,9 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 	)
 
 	// Resolve source
-	if dependency.Source.Git != nil {
-		gitPath := strings.TrimSpace(*dependency.Source.Git)
-
-		os.MkdirAll(DependencyFolderPath, 0755)
-		localPath = filepath.Join(DependencyFolderPath, hash.String(gitPath))
-
-		// Check if dependency exists
-		_, err := os.Stat(localPath)
-		if err != nil {
-			update = true
-		}
-
-		// Update chart
-		if update {
-			var (
-				gitRepo  = git.NewGitRepository(localPath, gitPath)
-				tag      string
-				branch   string
-				revision string
-			)
-
-			if dependency.Source.Tag != nil {
-				tag = *dependency.Source.Tag
-			} else if dependency.Source.Branch != nil {
-				branch = *dependency.Source.Branch
-			} else if dependency.Source.Revision != nil {
-				revision = *dependency.Source.Revision
-			}
-
-			err = gitRepo.Update(tag == "" && branch == "" && revision == "")
-			if err != nil {
-				return nil, errors.Wrap(err, "pull repo")
-			}
-
-			if tag != "" || branch != "" || revision != "" {
-				err = gitRepo.Checkout(tag, branch, revision)
-				if err != nil {
-					return nil, errors.Wrap(err, "checkout")
-				}
-			}
-
-			r.log.Donef("Pulled %s", ID)
-		}
-	} else if dependency.Source.Path != nil {
-		localPath, err = filepath.Abs(filepath.Join(basePath, filepath.FromSlash(*dependency.Source.Path)))
-		if err != nil {
-			return nil, errors.Wrap(err, "filepath absolute")
-		}
+	localPath, err = source.Download(basePath, dependency.Source, update, r.log)
+	if err != nil {
+		return nil, err
 	}
 
 	if dependency.Config != nil {
@@ -8,10 +8,10 @@ import (
 	
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/dependency/source"
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
-	"github.com/devspace-cloud/devspace/pkg/util/hash"
 
 	"gotest.tools/assert"
 )
@@ -52,7 +52,7 @@ func TestResolver(t *testing.T) {
 		t.Fatalf("Error writing file: %v", err)
 	}
 	gitPath := "devspace-cloud/quickstart-nodejs"
-	gitDepPath := filepath.Join(DependencyFolderPath, hash.String(gitPath))
+	gitDepPath := source.GetLocalPath(&latest.SourceConfig{Git: &gitPath})
 	err = fsutil.WriteToFile([]byte(""), filepath.Join(gitDepPath, "devspace.yaml"))
 	if err != nil {
 		t.Fatalf("Error writing file: %v", err)
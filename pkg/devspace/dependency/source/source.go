@@ -0,0 +1,128 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/git"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// DependencyFolder is the dependency folder in the home directory of the user
+const DependencyFolder = ".devspace/dependencies"
+
+// DependencyFolderPath will be filled during init
+var DependencyFolderPath string
+
+func init() {
+	// Make sure dependency folder exists locally
+	homedir, _ := homedir.Dir()
+
+	DependencyFolderPath = filepath.Join(homedir, filepath.FromSlash(DependencyFolder))
+}
+
+// GetLocalPath returns the path where the git repository of the source is cached. Sources with a different tag,
+// branch or revision of the same repository are cached in different paths
+func GetLocalPath(source *latest.SourceConfig) string {
+	return filepath.Join(DependencyFolderPath, hash.String(GetID(source)))
+}
+
+// GetID returns the git repository of the source followed by the checked out ref, e.g.
+// https://github.com/my-org/my-repo.git@v1.0.0
+func GetID(source *latest.SourceConfig) string {
+	id := strings.TrimSpace(*source.Git)
+	if ref := getRef(source); ref != "" {
+		id += "@" + ref
+	}
+
+	return id
+}
+
+func getRef(source *latest.SourceConfig) string {
+	if source.Tag != nil {
+		return *source.Tag
+	} else if source.Branch != nil {
+		return *source.Branch
+	} else if source.Revision != nil {
+		return *source.Revision
+	}
+
+	return ""
+}
+
+// Download makes sure the source is available locally and returns its local path. Git repositories are cloned into the
+// dependency folder and pulled again if update is true. Local paths are resolved relative to basePath. If the source
+// is empty, an empty path is returned
+func Download(basePath string, source *latest.SourceConfig, update bool, log log.Logger) (string, error) {
+	if source.Git != nil {
+		os.MkdirAll(DependencyFolderPath, 0755)
+		localPath := GetLocalPath(source)
+
+		err := Clone(localPath, source, update, log)
+		if err != nil {
+			return "", err
+		}
+
+		return localPath, nil
+	} else if source.Path != nil {
+		localPath, err := filepath.Abs(filepath.Join(basePath, filepath.FromSlash(*source.Path)))
+		if err != nil {
+			return "", errors.Wrap(err, "filepath absolute")
+		}
+
+		return localPath, nil
+	}
+
+	return "", nil
+}
+
+// Clone clones the git repository of the source into localPath and checks out the tag, branch or revision of the
+// source. An existing repository in localPath is only updated if update is true
+func Clone(localPath string, source *latest.SourceConfig, update bool, log log.Logger) error {
+	gitPath := strings.TrimSpace(*source.Git)
+
+	// Check if the repository exists
+	_, err := os.Stat(localPath)
+	if err != nil {
+		update = true
+	}
+	if update == false {
+		return nil
+	}
+
+	var (
+		gitRepo  = git.NewGitRepository(localPath, gitPath)
+		tag      string
+		branch   string
+		revision string
+	)
+
+	if source.Tag != nil {
+		tag = *source.Tag
+	} else if source.Branch != nil {
+		branch = *source.Branch
+	} else if source.Revision != nil {
+		revision = *source.Revision
+	}
+
+	err = gitRepo.Update(tag == "" && branch == "" && revision == "")
+	if err != nil {
+		return errors.Wrap(err, "pull repo")
+	}
+
+	if tag != "" || branch != "" || revision != "" {
+		err = gitRepo.Checkout(tag, branch, revision)
+		if err != nil {
+			return errors.Wrap(err, "checkout")
+		}
+	}
+
+	log.Donef("Pulled %s", GetID(source))
+	return nil
+}
@@ -49,6 +49,7 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 			Force:           deployConfig.Component.Options.Force,
 			Timeout:         deployConfig.Component.Options.Timeout,
 			TillerNamespace: deployConfig.Component.Options.TillerNamespace,
+			DevSpaceValues:  ptr.Bool(true),
 		},
 	}, log)
 	if err != nil {
@@ -80,3 +81,13 @@ func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
 func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 	return d.HelmConfig.Delete(cache)
 }
+
+// Rollback rolls back the release
+func (d *DeployConfig) Rollback(cache *generated.CacheConfig) error {
+	return d.HelmConfig.Rollback(cache)
+}
+
+// Resources returns the kubernetes objects of the release that was deployed last
+func (d *DeployConfig) Resources() []*deploy.Resource {
+	return d.HelmConfig.Resources()
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/pkg/errors"
@@ -20,7 +21,12 @@ type DeployConfig struct {
 	DeploymentConfig *latest.DeploymentConfig
 	Log              log.Logger
 
-	config *latest.Config
+	config           *latest.Config
+	resources        []*deploy.Resource
+	previousRevision int32
+
+	// installed is true if the chart was installed or upgraded during the current deploy
+	installed bool
 }
 
 // New creates a new helm deployment client
@@ -69,3 +75,56 @@ func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 	delete(cache.Deployments, *d.DeploymentConfig.Name)
 	return nil
 }
+
+// Rollback rolls the release back to the revision that was deployed before the last deploy or deletes
+// the release if it did not exist before
+func (d *DeployConfig) Rollback(cache *generated.CacheConfig) error {
+	var (
+		releaseName = *d.DeploymentConfig.Name
+		err         error
+	)
+
+	if d.Helm == nil {
+		d.Helm, err = helm.NewClient(d.config, d.TillerNamespace, d.Log, false)
+		if err != nil {
+			return errors.Wrap(err, "new helm client")
+		}
+	}
+
+	// Nothing to roll back if the deploy failed before the release was changed
+	if d.installed == false {
+		return nil
+	}
+
+	if d.previousRevision == 0 {
+		_, err = d.Helm.DeleteRelease(releaseName, true)
+		if err != nil {
+			return err
+		}
+
+		delete(cache.Deployments, releaseName)
+		d.Log.Donef("Deleted release %s, because it did not exist before", releaseName)
+		return nil
+	}
+
+	release, err := d.Helm.RollbackRelease(releaseName, d.previousRevision)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the next deploy does not skip this deployment
+	deployCache := cache.GetDeploymentCache(releaseName)
+	deployCache.DeploymentConfigHash = ""
+	deployCache.HelmReleaseRevision = d.previousRevision
+	if release != nil {
+		deployCache.HelmReleaseRevision = release.Version
+	}
+
+	d.Log.Donef("Rolled back release %s to revision %d", releaseName, d.previousRevision)
+	return nil
+}
+
+// Resources returns the kubernetes objects of the release that was deployed last
+func (d *DeployConfig) Resources() []*deploy.Resource {
+	return d.resources
+}
@@ -7,7 +7,9 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
 	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
@@ -67,6 +69,9 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 		}
 	}
 
+	// Remember the currently deployed revision to be able to roll back
+	d.previousRevision = d.getDeployedRevision(releaseName, deployCache)
+
 	// Check if redeploying is necessary
 	forceDeploy = forceDeploy || deployCache.HelmOverridesHash != helmOverridesHash || deployCache.HelmChartHash != hash || deployCache.DeploymentConfigHash != deploymentConfigHash
 	if forceDeploy == false {
@@ -154,13 +159,26 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 		Values(overwriteValues).MergeInto(*d.DeploymentConfig.Helm.Values)
 	}
 
+	// Write the built images into the configured values
+	if d.DeploymentConfig.Helm.ImageValues != nil {
+		shouldRedeploy, err := d.setImageValues(overwriteValues, cache, builtImages)
+		if err != nil {
+			return false, err
+		}
+		if forceDeploy == false && shouldRedeploy {
+			forceDeploy = true
+		}
+	}
+
 	// Add devspace specific values
-	if d.DeploymentConfig.Helm.DevSpaceValues == nil || *d.DeploymentConfig.Helm.DevSpaceValues == true {
+	if d.DeploymentConfig.Helm.DevSpaceValues != nil && *d.DeploymentConfig.Helm.DevSpaceValues == true {
 		// Replace image names
 		shouldRedeploy := replaceContainerNames(overwriteValues, cache, builtImages)
 		if forceDeploy == false && shouldRedeploy {
 			forceDeploy = true
 		}
+	} else if d.DeploymentConfig.Helm.DevSpaceValues == nil && d.DeploymentConfig.Helm.ImageValues == nil && containsImageNames(overwriteValues, cache) {
+		d.Log.Warnf("The values of deployment %s contain built images, but their tags are not set automatically anymore. Please configure `imageValues` or set `devSpaceValues: true` in the helm options of the deployment", releaseName)
 	}
 
 	// Deployment is not necessary
@@ -172,6 +190,7 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 	defer d.Log.StopWait()
 
 	// Deploy chart
+	d.installed = true
 	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, &overwriteValues, d.DeploymentConfig.Helm)
 	if err != nil {
 		return false, fmt.Errorf("Unable to deploy helm chart: %v\nRun `%s` and `%s` to recreate the chart", err, ansi.Color("devspace purge -d "+*d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
@@ -181,6 +200,20 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 	if appRelease != nil {
 		releaseRevision := int(appRelease.Version)
 		d.Log.Donef("Deployed helm chart (Release revision: %d)", releaseRevision)
+		cache.GetDeploymentCache(releaseName).HelmReleaseRevision = appRelease.Version
+
+		// Remember the deployed objects
+		if releaseNamespace == "" {
+			releaseNamespace, err = configutil.GetDefaultNamespace(d.config)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		d.resources, err = deploy.ParseResources(appRelease.Manifest, releaseNamespace)
+		if err != nil {
+			return false, errors.Wrap(err, "parse release manifest")
+		}
 	} else {
 		d.Log.Done("Deployed helm chart")
 	}
@@ -188,6 +221,93 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 	return true, nil
 }
 
+// getDeployedRevision returns the revision of the currently deployed release or 0 if the release does not exist
+func (d *DeployConfig) getDeployedRevision(releaseName string, deployCache *generated.DeploymentCache) int32 {
+	releases, err := d.Helm.ListReleases()
+	if err != nil {
+		return deployCache.HelmReleaseRevision
+	}
+
+	if releases != nil {
+		for _, release := range releases.Releases {
+			if release.GetName() == releaseName {
+				return release.GetVersion()
+			}
+		}
+	}
+
+	return 0
+}
+
+// setImageValues writes the repository, tag and digest of the images configured in imageValues into the given values
+func (d *DeployConfig) setImageValues(overwriteValues map[interface{}]interface{}, cache *generated.CacheConfig, builtImages map[string]string) (bool, error) {
+	shouldRedeploy := false
+
+	for imageConfigName, imageValues := range *d.DeploymentConfig.Helm.ImageValues {
+		if imageValues == nil {
+			continue
+		}
+		if d.config.Images == nil {
+			return false, errors.Errorf("Image %s in imageValues of deployment %s does not exist", imageConfigName, *d.DeploymentConfig.Name)
+		}
+		if _, ok := (*d.config.Images)[imageConfigName]; !ok {
+			return false, errors.Errorf("Image %s in imageValues of deployment %s does not exist", imageConfigName, *d.DeploymentConfig.Name)
+		}
+
+		imageCache, ok := cache.Images[imageConfigName]
+		if !ok || imageCache.ImageName == "" || imageCache.Tag == "" {
+			d.Log.Warnf("Image %s has not been built yet, skipping its imageValues in deployment %s", imageConfigName, *d.DeploymentConfig.Name)
+			continue
+		}
+		if _, ok := builtImages[imageCache.ImageName]; ok {
+			shouldRedeploy = true
+		}
+
+		if imageValues.Image != nil {
+			Values(overwriteValues).Set(*imageValues.Image, imageCache.ImageName+":"+imageCache.Tag)
+		}
+		if imageValues.Repository != nil {
+			Values(overwriteValues).Set(*imageValues.Repository, imageCache.ImageName)
+		}
+		if imageValues.Tag != nil {
+			Values(overwriteValues).Set(*imageValues.Tag, imageCache.Tag)
+		}
+		if imageValues.Digest != nil {
+			if imageCache.Digest == "" {
+				d.Log.Warnf("The digest of image %s is unknown, because it was not pushed with docker during the last build", imageConfigName)
+			} else {
+				Values(overwriteValues).Set(*imageValues.Digest, imageCache.Digest)
+			}
+		}
+	}
+
+	return shouldRedeploy, nil
+}
+
+// containsImageNames checks if the values contain an image that was built
+func containsImageNames(overwriteValues map[interface{}]interface{}, cache *generated.CacheConfig) bool {
+	found := false
+
+	match := func(path, key, value string) bool {
+		image, err := registry.GetStrippedDockerImageName(value)
+		if err != nil {
+			return false
+		}
+
+		for _, imageCache := range cache.Images {
+			if imageCache.ImageName == image && imageCache.Tag != "" {
+				found = true
+			}
+		}
+
+		// We never replace anything here
+		return false
+	}
+
+	_ = walk.Walk(overwriteValues, match, nil)
+	return found
+}
+
 func replaceContainerNames(overwriteValues map[interface{}]interface{}, cache *generated.CacheConfig, builtImages map[string]string) bool {
 	shouldRedeploy := false
 
@@ -138,6 +138,17 @@ func TestHelmDeployment(t *testing.T) {
 		t.Fatalf("Unexpected deployment status: %s != Deployed", status.Status)
 	}
 
+	// Deploy again and roll back to the first revision
+	isDeployed, err = helm.Deploy(generatedConfig.Configs["default"], true, nil)
+	assert.NilError(t, err, "Error upgrading chart")
+	assert.Equal(t, true, isDeployed)
+	assert.Equal(t, generatedConfig.Configs["default"].Deployments["test-deployment"].HelmReleaseRevision, int32(2))
+
+	err = helm.Rollback(generatedConfig.Configs["default"])
+	assert.NilError(t, err, "Error rolling back chart")
+	assert.Equal(t, generatedConfig.Configs["default"].Deployments["test-deployment"].HelmReleaseRevision, int32(1))
+	assert.Equal(t, generatedConfig.Configs["default"].Deployments["test-deployment"].DeploymentConfigHash, "")
+
 	// 6. Delete test chart
 	err = helm.Delete(generatedConfig.Configs["default"])
 	if err != nil {
@@ -473,3 +484,70 @@ func TestReplaceImageNames(t *testing.T) {
 		t.Fatalf("Replace failed: Got\n %s\n, but expected\n %s", gotYaml, expectedYaml)
 	}
 }
+
+func TestSetImageValues(t *testing.T) {
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"api": &generated.ImageCache{
+				ImageName: "dscr.io/user/api",
+				Tag:       "abcdef",
+				Digest:    "sha256:123",
+			},
+		},
+	}
+
+	deployConfig := &DeployConfig{
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: ptr.String("test-deployment"),
+			Helm: &latest.HelmConfig{
+				ImageValues: &map[string]*latest.ImageValuesConfig{
+					"api": &latest.ImageValuesConfig{
+						Image:      ptr.String("api.fullImage"),
+						Repository: ptr.String("api.image.repository"),
+						Tag:        ptr.String("api.image.tag"),
+						Digest:     ptr.String("digest"),
+					},
+				},
+			},
+		},
+		Log: &log.DiscardLogger{},
+		config: &latest.Config{
+			Images: &map[string]*latest.ImageConfig{
+				"api": &latest.ImageConfig{
+					Image: ptr.String("dscr.io/user/api"),
+				},
+			},
+		},
+	}
+
+	values := map[interface{}]interface{}{
+		"api": map[interface{}]interface{}{
+			"image": map[interface{}]interface{}{
+				"repository": "nginx",
+				"pullPolicy": "Always",
+			},
+		},
+		"other": "dscr.io/user/api",
+	}
+
+	shouldRedeploy, err := deployConfig.setImageValues(values, cache, map[string]string{"dscr.io/user/api": "abcdef"})
+	assert.NilError(t, err, "Error setting image values")
+	assert.Equal(t, shouldRedeploy, true)
+	assert.DeepEqual(t, values, map[interface{}]interface{}{
+		"api": map[interface{}]interface{}{
+			"image": map[interface{}]interface{}{
+				"repository": "dscr.io/user/api",
+				"tag":        "abcdef",
+				"pullPolicy": "Always",
+			},
+			"fullImage": "dscr.io/user/api:abcdef",
+		},
+		"digest": "sha256:123",
+		"other":  "dscr.io/user/api",
+	})
+
+	// Unknown images are an error
+	(*deployConfig.DeploymentConfig.Helm.ImageValues)["unknown"] = &latest.ImageValuesConfig{Tag: ptr.String("tag")}
+	_, err = deployConfig.setImageValues(values, cache, nil)
+	assert.Error(t, err, "Image unknown in imageValues of deployment test-deployment does not exist")
+}
@@ -1,5 +1,7 @@
 package helm
 
+import "strings"
+
 // Values is the type to go
 type Values map[interface{}]interface{}
 
@@ -19,6 +21,24 @@ func (v Values) MergeInto(src Values) {
 	}
 }
 
+// Set sets the value at the given dot separated path, e.g. image.tag. Missing maps on the path are created
+func (v Values) Set(path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	current := v
+
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[interface{}]interface{})
+		if !ok {
+			next = map[interface{}]interface{}{}
+			current[key] = next
+		}
+
+		current = Values(next)
+	}
+
+	current[keys[len(keys)-1]] = value
+}
+
 // istable is a special-purpose function to see if the present thing matches the definition of a YAML table.
 func istable(v interface{}) bool {
 	_, ok := v.(map[interface{}]interface{})
@@ -54,11 +54,18 @@ func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
 				}, nil
 			}
 
+			// The objects of the release are used for the detailed status
+			resources, err := deploy.ParseResources(release.GetManifest(), release.GetNamespace())
+			if err != nil {
+				d.Log.Warnf("Error parsing manifest of release %s: %v", release.GetName(), err)
+			}
+
 			return &deploy.StatusResult{
-				Name:   *d.DeploymentConfig.Name,
-				Type:   "Helm",
-				Target: deployTargetStr,
-				Status: "Deployed " + time.Since(time.Unix(release.Info.LastDeployed.Seconds, 0)).String() + " ago",
+				Name:      *d.DeploymentConfig.Name,
+				Type:      "Helm",
+				Target:    deployTargetStr,
+				Status:    "Deployed " + time.Since(time.Unix(release.Info.LastDeployed.Seconds, 0)).String() + " ago",
+				Resources: resources,
 			}, nil
 		}
 	}
@@ -9,6 +9,22 @@ type Interface interface {
 	Status() (*StatusResult, error)
 	Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error)
 	Delete(cache *generated.CacheConfig) error
+
+	// Rollback restores the state the deployment had before the last call to Deploy
+	Rollback(cache *generated.CacheConfig) error
+
+	// Resources returns the kubernetes objects that were applied during the last call to Deploy
+	Resources() []*Resource
+}
+
+// Pruner is implemented by deployment methods that can find and delete objects which were deployed before,
+// but are not part of the deployment anymore
+type Pruner interface {
+	// Orphans returns the objects of the deployment that were not applied by the last call to Deploy
+	Orphans() ([]*Resource, error)
+
+	// Prune deletes the given objects
+	Prune(resources []*Resource) error
 }
 
 // StatusResult holds the status of a deployment
@@ -17,4 +33,7 @@ type StatusResult struct {
 	Type   string
 	Target string
 	Status string
+
+	// Resources are the kubernetes objects that belong to the deployment, if they can be determined
+	Resources []*Resource
 }
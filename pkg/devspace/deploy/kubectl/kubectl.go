@@ -2,12 +2,14 @@ package kubectl
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -21,21 +23,43 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 )
 
-// DeployConfig holds the necessary information for kubectl deployment
+// DeployConfig holds the necessary information for kubectl and template deployments
 type DeployConfig struct {
-	KubeClient kubernetes.Interface // This is not used yet, however the plan is to use it instead of calling kubectl via cmd
+	KubeClient kubernetes.Interface // This is only used for the status yet, however the plan is to use it instead of calling kubectl via cmd
 	Name       string
 	CmdPath    string
 	Context    string
 	Namespace  string
 	Manifests  []string
+	Flags      []string
+	Kustomize  bool
+
+	// Template is true if the manifests are go templates that are rendered by devspace
+	Template bool
+
+	// Project and ConfigName are added as labels to all applied objects together with the deployment name
+	Project    string
+	ConfigName string
 
 	DeploymentConfig *latest.DeploymentConfig
 	Log              log.Logger
+
+	resources []*deploy.Resource
+
+	// previousResources are the objects that were applied by the deploy before this one
+	previousResources []*deploy.Resource
+
+	// previousObjects and createdResources are kept in memory to roll back the current deploy. previousObjects are the
+	// last applied configurations of the objects that existed before and createdResources are the objects that did not exist
+	previousObjects  []string
+	createdResources []*deploy.Resource
 }
 
-// New creates a new deploy config for kubectl
+// New creates a new deploy config for kubectl or template deployments
 func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) (*DeployConfig, error) {
+	if deployConfig.Template != nil {
+		return newTemplate(config, kubectl, deployConfig, log)
+	}
 	if deployConfig.Kubectl == nil {
 		return nil, errors.New("Error creating kubectl deploy config: kubectl is nil")
 	}
@@ -43,6 +67,41 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 		return nil, errors.New("No manifests defined for kubectl deploy")
 	}
 
+	deployClient, err := newDeployConfig(config, kubectl, deployConfig, deployConfig.Kubectl.CmdPath, deployConfig.Kubectl.Flags, log)
+	if err != nil {
+		return nil, err
+	}
+
+	deployClient.Kustomize = deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true
+	for _, ptrManifest := range *deployConfig.Kubectl.Manifests {
+		manifest := strings.Replace(*ptrManifest, "*", "", -1)
+		if deployClient.Kustomize {
+			manifest = strings.TrimSuffix(manifest, "kustomization.yaml")
+		}
+
+		deployClient.Manifests = append(deployClient.Manifests, manifest)
+	}
+
+	return deployClient, nil
+}
+
+// newTemplate creates a new deploy config for a template deployment
+func newTemplate(config *latest.Config, kubectl kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) (*DeployConfig, error) {
+	if deployConfig.Template.Path == nil {
+		return nil, errors.New("No path defined for template deploy")
+	}
+
+	deployClient, err := newDeployConfig(config, kubectl, deployConfig, deployConfig.Template.CmdPath, deployConfig.Template.Flags, log)
+	if err != nil {
+		return nil, err
+	}
+
+	deployClient.Template = true
+	deployClient.Manifests = []string{*deployConfig.Template.Path}
+	return deployClient, nil
+}
+
+func newDeployConfig(config *latest.Config, kubectl kubernetes.Interface, deployConfig *latest.DeploymentConfig, cmdPath *string, flags *[]*string, log log.Logger) (*DeployConfig, error) {
 	context := ""
 	if config.Cluster != nil && config.Cluster.KubeContext != nil {
 		context = *config.Cluster.KubeContext
@@ -56,58 +115,126 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 		namespace = *deployConfig.Namespace
 	}
 
-	cmdPath := "kubectl"
-	if deployConfig.Kubectl.CmdPath != nil {
-		cmdPath = *deployConfig.Kubectl.CmdPath
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
 	}
 
-	manifests := []string{}
-	for _, ptrManifest := range *deployConfig.Kubectl.Manifests {
-		manifest := strings.Replace(*ptrManifest, "*", "", -1)
-		if deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
-			manifest = strings.TrimSuffix(manifest, "kustomization.yaml")
-		}
-
-		manifests = append(manifests, manifest)
+	configName := configutil.LoadedConfig
+	if configName == "" {
+		configName = generated.DefaultConfigName
 	}
 
-	return &DeployConfig{
+	deployClient := &DeployConfig{
 		Name:       *deployConfig.Name,
 		KubeClient: kubectl,
-		CmdPath:    cmdPath,
+		CmdPath:    "kubectl",
 		Context:    context,
 		Namespace:  namespace,
-		Manifests:  manifests,
+		Manifests:  []string{},
+		Flags:      []string{},
+		Project:    getProjectName(config, cwd),
+		ConfigName: configName,
 
 		DeploymentConfig: deployConfig,
 		Log:              log,
-	}, nil
-}
+	}
 
-// Status prints the status of all matched manifests from kubernetes
-func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
-	// TODO: parse kubectl get output into the required string array
-	manifests := strings.Join(d.Manifests, ",")
-	if len(manifests) > 20 {
-		manifests = manifests[:20] + "..."
+	if cmdPath != nil {
+		deployClient.CmdPath = *cmdPath
+	}
+	if flags != nil {
+		for _, flag := range *flags {
+			deployClient.Flags = append(deployClient.Flags, *flag)
+		}
 	}
 
-	return &deploy.StatusResult{
+	return deployClient, nil
+}
+
+// Status returns the number of ready pods and the workloads and services that have the labels of this deployment
+func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
+	status := &deploy.StatusResult{
 		Name:   d.Name,
 		Type:   "Manifests",
-		Target: manifests,
+		Target: strings.Join(d.Manifests, ","),
 		Status: "N/A",
-	}, nil
+	}
+	if d.Template {
+		status.Type = "Template"
+	} else if len(status.Target) > 20 {
+		status.Target = status.Target[:20] + "..."
+	}
+	if d.KubeClient == nil {
+		return status, nil
+	}
+
+	resources, selectors, err := d.getLabeledWorkloadsAndServices()
+	if err != nil {
+		status.Status = fmt.Sprintf("Error: %v", err)
+		return status, nil
+	}
+
+	status.Resources = resources
+
+	// The pods are not labeled, so they are found by the selectors of the labeled workloads
+	pods, err := d.getWorkloadPods(selectors)
+	if err != nil {
+		status.Status = fmt.Sprintf("Error: %v", err)
+		return status, nil
+	}
+	if len(pods) == 0 {
+		status.Status = "No pods found"
+		return status, nil
+	}
+
+	ready := 0
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+
+		containersReady := true
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Ready == false {
+				containersReady = false
+				break
+			}
+		}
+		if containersReady {
+			ready++
+		}
+	}
+
+	status.Status = fmt.Sprintf("%d/%d pods ready", ready, len(pods))
+	return status, nil
+}
+
+// Resources returns the kubernetes objects that were applied during the last deploy
+func (d *DeployConfig) Resources() []*deploy.Resource {
+	return d.resources
 }
 
-// Delete deletes all matched manifests from kubernetes
+// Delete deletes all matched manifests from kubernetes and all other objects that have the labels of this deployment
 func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 	d.Log.StartWait("Deleting manifests with kubectl")
 	defer d.Log.StopWait()
 
+	// The types of the previously applied objects are used to find objects that have been removed from the manifests
+	resources := []*deploy.Resource{}
+	if deployCache, ok := cache.Deployments[d.Name]; ok {
+		resources = append(resources, fromObjectReferences(deployCache.KubectlAppliedObjects)...)
+	}
+
 	for _, manifest := range d.Manifests {
 		_, replacedManifest, err := d.getReplacedManifest(manifest, cache, nil)
 		if err != nil {
+			if d.Template {
+				// All rendered objects have the labels of the deployment, so we can still delete them by label
+				d.Log.Warnf("Error rendering templates in %s: %v", manifest, err)
+				continue
+			}
+
 			return err
 		}
 
@@ -124,6 +251,18 @@ func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 		if err != nil {
 			return err
 		}
+
+		replacedResources, err := deploy.ParseResources(replacedManifest, d.Namespace)
+		if err != nil {
+			return err
+		}
+
+		resources = append(resources, replacedResources...)
+	}
+
+	err := d.deleteLabeledObjects(resources)
+	if err != nil {
+		return err
 	}
 
 	delete(cache.Deployments, *d.DeploymentConfig.Name)
@@ -163,21 +302,37 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 	defer d.Log.StopWait()
 
 	wasDeployed := false
+	d.resources = []*deploy.Resource{}
+	d.previousResources = fromObjectReferences(deployCache.KubectlAppliedObjects)
+	d.previousObjects = []string{}
+	d.createdResources = []*deploy.Resource{}
 
 	for _, manifest := range d.Manifests {
 		shouldRedeploy, replacedManifest, err := d.getReplacedManifest(manifest, cache, builtImages)
 		if err != nil {
+			if d.Template {
+				return false, errors.Wrapf(err, "render templates in %s", manifest)
+			}
+
 			return false, fmt.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
 		}
 
 		if shouldRedeploy || forceDeploy {
+			resources, err := deploy.ParseResources(replacedManifest, d.Namespace)
+			if err != nil {
+				return false, err
+			}
+
+			// Remember the current state of the objects to be able to roll back. This fails for example if the manifest
+			// contains custom resources whose definition is created by the same manifest
+			err = d.rememberPreviousObjects(replacedManifest, resources)
+			if err != nil {
+				d.Log.Warnf("Unable to get the current objects of manifest %s, they will not be rolled back if the deploy fails: %v", manifest, err)
+			}
+
 			stringReader := strings.NewReader(replacedManifest)
 			args := d.getCmdArgs("apply", "--force")
-			if d.DeploymentConfig.Kubectl.Flags != nil {
-				for _, flag := range *d.DeploymentConfig.Kubectl.Flags {
-					args = append(args, *flag)
-				}
-			}
+			args = append(args, d.Flags...)
 
 			cmd := exec.Command(d.CmdPath, args...)
 
@@ -190,6 +345,7 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 				return false, fmt.Errorf("%v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", err, manifest)
 			}
 
+			d.resources = append(d.resources, resources...)
 			wasDeployed = true
 		} else {
 			d.Log.Infof("Skipping manifest %s", manifest)
@@ -198,11 +354,201 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 
 	deployCache.KubectlManifestsHash = manifestsHash
 	deployCache.DeploymentConfigHash = deploymentConfigHash
+	if wasDeployed {
+		deployCache.KubectlAppliedObjects = toObjectReferences(d.resources)
+	}
 
 	return wasDeployed, nil
 }
 
+// Rollback applies the objects that existed before the current deploy again and deletes the objects that were created
+func (d *DeployConfig) Rollback(cache *generated.CacheConfig) error {
+	if len(d.previousObjects) == 0 && len(d.createdResources) == 0 {
+		return nil
+	}
+
+	d.Log.StartWait("Restoring previous objects with kubectl")
+	defer d.Log.StopWait()
+
+	if len(d.previousObjects) > 0 {
+		args := d.getCmdArgs("apply", "--force")
+		args = append(args, d.Flags...)
+
+		cmd := exec.Command(d.CmdPath, args...)
+
+		cmd.Stdin = strings.NewReader(strings.Join(d.previousObjects, "\n---\n"))
+		cmd.Stdout = d.Log
+		cmd.Stderr = d.Log
+
+		err := cmd.Run()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(d.createdResources) > 0 {
+		err := d.Prune(d.createdResources)
+		if err != nil {
+			return errors.Wrap(err, "delete created objects")
+		}
+	}
+
+	// Make sure the next deploy does not skip this deployment
+	deployCache := cache.GetDeploymentCache(*d.DeploymentConfig.Name)
+	deployCache.KubectlAppliedObjects = toObjectReferences(d.previousResources)
+	deployCache.KubectlManifestsHash = ""
+	deployCache.DeploymentConfigHash = ""
+
+	d.Log.Donef("Restored previous objects of deployment %s", d.Name)
+	return nil
+}
+
+// rememberPreviousObjects retrieves the last applied configurations of the given objects from the cluster and
+// remembers the objects that do not exist yet
+func (d *DeployConfig) rememberPreviousObjects(manifest string, resources []*deploy.Resource) error {
+	args := d.getCmdArgs("get", "--ignore-not-found=true", "-o", "yaml")
+
+	cmd := exec.Command(d.CmdPath, args...)
+	cmd.Stdin = strings.NewReader(manifest)
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return errors.Errorf("%v: %s", err, exitError.Stderr)
+		}
+
+		return err
+	}
+
+	previousObjects, createdResources, err := getPreviousObjects(out, resources, d.Namespace)
+	if err != nil {
+		return err
+	}
+
+	d.previousObjects = append(d.previousObjects, previousObjects...)
+	d.createdResources = append(d.createdResources, createdResources...)
+	return nil
+}
+
+// getPreviousObjects returns the last applied configurations of the existing objects in the given kubectl get output
+// and the resources that do not exist yet
+func getPreviousObjects(out []byte, resources []*deploy.Resource, defaultNamespace string) ([]string, []*deploy.Resource, error) {
+	object := map[interface{}]interface{}{}
+	err := yaml.Unmarshal(out, &object)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal objects")
+	}
+
+	items := []interface{}{object}
+	if kind, _ := object["kind"].(string); kind == "List" {
+		items, _ = object["items"].([]interface{})
+	} else if len(object) == 0 {
+		items = nil
+	}
+
+	previousObjects := []string{}
+	existing := map[string]bool{}
+	for _, item := range items {
+		itemObject, ok := item.(map[interface{}]interface{})
+		if ok == false {
+			continue
+		}
+
+		previousObject, err := getLastAppliedConfiguration(itemObject)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		itemBytes, err := yaml.Marshal(itemObject)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		itemResources, err := deploy.ParseResources(string(itemBytes), defaultNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, resource := range itemResources {
+			existing[resource.Kind+"/"+resource.Namespace+"/"+resource.Name] = true
+		}
+
+		previousObjects = append(previousObjects, previousObject)
+	}
+
+	createdResources := []*deploy.Resource{}
+	for _, resource := range resources {
+		if existing[resource.Kind+"/"+resource.Namespace+"/"+resource.Name] == false {
+			createdResources = append(createdResources, resource)
+		}
+	}
+
+	return previousObjects, createdResources, nil
+}
+
+// getLastAppliedConfiguration returns the configuration that was applied last for the given object. If the object was not
+// created by kubectl apply, the object itself without its status and server side metadata is returned
+func getLastAppliedConfiguration(object map[interface{}]interface{}) (string, error) {
+	metadata, _ := object["metadata"].(map[interface{}]interface{})
+	if metadata != nil {
+		annotations, _ := metadata["annotations"].(map[interface{}]interface{})
+		if lastApplied, ok := annotations[v1.LastAppliedConfigAnnotation].(string); ok && lastApplied != "" {
+			return lastApplied, nil
+		}
+
+		for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, field)
+		}
+	}
+
+	delete(object, "status")
+
+	out, err := yaml.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func toObjectReferences(resources []*deploy.Resource) []*generated.ObjectReference {
+	references := []*generated.ObjectReference{}
+	for _, resource := range resources {
+		references = append(references, &generated.ObjectReference{
+			APIVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Namespace:  resource.Namespace,
+			Name:       resource.Name,
+		})
+	}
+
+	return references
+}
+
+func fromObjectReferences(references []*generated.ObjectReference) []*deploy.Resource {
+	resources := []*deploy.Resource{}
+	for _, reference := range references {
+		resources = append(resources, &deploy.Resource{
+			APIVersion: reference.APIVersion,
+			Kind:       reference.Kind,
+			Namespace:  reference.Namespace,
+			Name:       reference.Name,
+		})
+	}
+
+	return resources
+}
+
 func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.CacheConfig, builtImages map[string]string) (bool, string, error) {
+	if d.Template {
+		return d.getTemplateManifest(manifest, cache, builtImages)
+	}
+
+	// Kustomizations are rendered in-process and the image tags are set by the kustomize images transformer
+	if d.Kustomize {
+		return d.getKustomizeManifest(manifest, cache, builtImages)
+	}
+
 	manifestYamlBytes, err := d.dryRun(manifest)
 	if err != nil {
 		return false, "", err
@@ -229,6 +575,8 @@ func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.Cac
 			shouldRedeploy = replaceManifest(manifestYaml, cache, builtImages) || shouldRedeploy
 		}
 
+		addObjectLabels(manifestYaml, d.getLabels())
+
 		replacedManifest, err := yaml.Marshal(manifestYaml)
 		if err != nil {
 			return false, "", errors.Wrap(err, "marshal yaml")
@@ -240,6 +588,30 @@ func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.Cac
 	return shouldRedeploy, strings.Join(replaceManifests, "\n---\n"), nil
 }
 
+func (d *DeployConfig) getKustomizeManifest(manifest string, cache *generated.CacheConfig, builtImages map[string]string) (bool, string, error) {
+	images := getKustomizeImages(cache)
+
+	rendered, err := renderKustomization(manifest, images, configutil.ResolveVarsInString)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "render kustomization %s", manifest)
+	}
+
+	// Redeploy if a built image is used in the kustomization
+	shouldRedeploy := false
+	for _, image := range images {
+		if _, ok := builtImages[image.Name]; ok && strings.Contains(string(rendered), image.Name+":"+image.NewTag) {
+			shouldRedeploy = true
+		}
+	}
+
+	labeled, err := d.addLabels(string(rendered))
+	if err != nil {
+		return false, "", err
+	}
+
+	return shouldRedeploy, labeled, nil
+}
+
 func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []string {
 	args := []string{}
 
@@ -269,15 +641,7 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 		args = append(args, "--namespace", d.Namespace)
 	}
 
-	args = append(args, "--dry-run", "--output", "yaml", "--validate=false")
-
-	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
-		args = append(args, "--kustomize")
-	} else {
-		args = append(args, "--filename")
-	}
-
-	args = append(args, manifest)
+	args = append(args, "--dry-run", "--output", "yaml", "--validate=false", "--filename", manifest)
 
 	// Execute command
 	output, err := exec.Command(d.CmdPath, args...).Output()
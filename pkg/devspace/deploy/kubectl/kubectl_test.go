@@ -10,6 +10,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
@@ -290,3 +291,46 @@ spec:
 
 	return nil
 }
+
+func TestGetPreviousObjects(t *testing.T) {
+	resources := []*deploy.Resource{
+		&deploy.Resource{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "existing"},
+		&deploy.Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "unmanaged"},
+		&deploy.Resource{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "new"},
+	}
+
+	out := `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: existing
+    namespace: default
+    resourceVersion: "10"
+    annotations:
+      kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1","kind":"Secret","metadata":{"name":"existing"}}'
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: unmanaged
+    namespace: default
+    uid: abc
+    resourceVersion: "11"
+  data:
+    key: value
+`
+
+	previousObjects, createdResources, err := getPreviousObjects([]byte(out), resources, "default")
+	assert.NilError(t, err, "Error getting previous objects")
+	assert.Equal(t, len(previousObjects), 2)
+	assert.Equal(t, previousObjects[0], `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"existing"}}`)
+	assert.Equal(t, previousObjects[1], "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n  name: unmanaged\n  namespace: default\n")
+	assert.DeepEqual(t, createdResources, resources[2:])
+
+	// Nothing exists on the first deploy
+	previousObjects, createdResources, err = getPreviousObjects([]byte{}, resources, "default")
+	assert.NilError(t, err, "Error getting previous objects")
+	assert.Equal(t, len(previousObjects), 0)
+	assert.DeepEqual(t, createdResources, resources)
+}
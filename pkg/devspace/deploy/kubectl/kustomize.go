@@ -0,0 +1,198 @@
+package kubectl
+
+import (
+	"bytes"
+	"path/filepath"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/cli-runtime/pkg/kustomize"
+	"sigs.k8s.io/kustomize/pkg/constants"
+	"sigs.k8s.io/kustomize/pkg/fs"
+)
+
+// kustomizeImage is an entry of the images transformer of a kustomization
+type kustomizeImage struct {
+	Name   string `yaml:"name"`
+	NewTag string `yaml:"newTag"`
+}
+
+// kustomizationPatches holds the patch files that are referenced in a kustomization
+type kustomizationPatches struct {
+	Patches               []string `yaml:"patches,omitempty"`
+	PatchesStrategicMerge []string `yaml:"patchesStrategicMerge,omitempty"`
+	PatchesJSON6902       []struct {
+		Path string `yaml:"path,omitempty"`
+	} `yaml:"patchesJson6902,omitempty"`
+}
+
+// kustomizeFileSystem wraps the real file system for rendering a kustomization. It resolves devspace variables
+// in the kustomization file and its patches and adds the built images to the images transformer of the kustomization
+type kustomizeFileSystem struct {
+	fs.FileSystem
+
+	kustomizationFile string
+	kustomization     []byte
+
+	// Patch files in which variables are resolved
+	patches map[string]bool
+	resolve func(string) (string, error)
+}
+
+// ReadFile returns the modified kustomization or the patch files with resolved variables
+func (k *kustomizeFileSystem) ReadFile(name string) ([]byte, error) {
+	if name == k.kustomizationFile {
+		return k.kustomization, nil
+	}
+
+	content, err := k.FileSystem.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if k.patches[name] {
+		resolved, err := k.resolve(string(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve variables in %s", name)
+		}
+
+		return []byte(resolved), nil
+	}
+
+	return content, nil
+}
+
+// getKustomizeImages returns the images transformer entries for all images with a tag in the cache
+func getKustomizeImages(cache *generated.CacheConfig) []*kustomizeImage {
+	images := []*kustomizeImage{}
+	for _, imageCache := range cache.Images {
+		if imageCache.ImageName != "" && imageCache.Tag != "" {
+			images = append(images, &kustomizeImage{
+				Name:   imageCache.ImageName,
+				NewTag: imageCache.Tag,
+			})
+		}
+	}
+
+	return images
+}
+
+// renderKustomization renders the kustomization in the given directory in-process with the given images
+// and returns the rendered manifests
+func renderKustomization(path string, images []*kustomizeImage, resolve func(string) (string, error)) ([]byte, error) {
+	realFS := fs.MakeRealFS()
+	root, _, err := realFS.CleanedAbs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	kustomizationFile := ""
+	for _, name := range constants.KustomizationFileNames {
+		if realFS.Exists(root.Join(name)) {
+			kustomizationFile = root.Join(name)
+			break
+		}
+	}
+	if kustomizationFile == "" {
+		return nil, errors.Errorf("No kustomization file found in %s", path)
+	}
+
+	content, err := realFS.ReadFile(kustomizationFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve devspace variables, e.g. in configMapGenerator literals
+	resolved, err := resolve(string(content))
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolve variables in %s", kustomizationFile)
+	}
+
+	kustomization, err := addKustomizeImages([]byte(resolved), images)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", kustomizationFile)
+	}
+
+	patches := kustomizationPatches{}
+	err = yaml.Unmarshal([]byte(resolved), &patches)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", kustomizationFile)
+	}
+
+	patchFiles := map[string]bool{}
+	for _, patch := range append(patches.Patches, patches.PatchesStrategicMerge...) {
+		patchFiles[filepath.Join(root.String(), patch)] = true
+	}
+	for _, patch := range patches.PatchesJSON6902 {
+		patchFiles[filepath.Join(root.String(), patch.Path)] = true
+	}
+
+	out := &bytes.Buffer{}
+	err = kustomize.RunKustomizeBuild(out, &kustomizeFileSystem{
+		FileSystem:        realFS,
+		kustomizationFile: kustomizationFile,
+		kustomization:     kustomization,
+		patches:           patchFiles,
+		resolve:           resolve,
+	}, root.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// addKustomizeImages adds the images to the images transformer of the kustomization. Images that are already
+// configured in the kustomization are replaced
+func addKustomizeImages(kustomization []byte, images []*kustomizeImage) ([]byte, error) {
+	if len(images) == 0 {
+		return kustomization, nil
+	}
+
+	parsed := yaml.MapSlice{}
+	err := yaml.Unmarshal(kustomization, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	newImages := []interface{}{}
+	replaced := map[string]bool{}
+	for _, image := range images {
+		replaced[image.Name] = true
+		newImages = append(newImages, image)
+	}
+
+	found := false
+	for idx, item := range parsed {
+		if key, ok := item.Key.(string); !ok || key != "images" {
+			continue
+		}
+
+		// Keep the images that are not built by devspace
+		existing, _ := item.Value.([]interface{})
+		for _, existingImage := range existing {
+			if imageMap, ok := existingImage.(yaml.MapSlice); ok {
+				name := ""
+				for _, field := range imageMap {
+					if field.Key == "name" {
+						name, _ = field.Value.(string)
+					}
+				}
+
+				if replaced[name] {
+					continue
+				}
+			}
+
+			newImages = append(newImages, existingImage)
+		}
+
+		parsed[idx].Value = newImages
+		found = true
+	}
+	if found == false {
+		parsed = append(parsed, yaml.MapItem{Key: "images", Value: newImages})
+	}
+
+	return yaml.Marshal(parsed)
+}
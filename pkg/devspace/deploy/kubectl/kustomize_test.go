@@ -0,0 +1,79 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var testKustomizeFiles = map[string]string{
+	"kustomization.yaml": `resources:
+- deployment.yaml
+patchesStrategicMerge:
+- patch.yaml
+images:
+- name: other-image
+  newTag: stable
+configMapGenerator:
+- name: app-config
+  literals:
+  - LOG_LEVEL=${LOG_LEVEL}
+`,
+	"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: my-image
+      - name: sidecar
+        image: other-image
+`,
+	"patch.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: ${REPLICAS}
+`,
+}
+
+func TestRenderKustomization(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-kustomize")
+	assert.NilError(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	for name, content := range testKustomizeFiles {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err, "Error writing %s", name)
+	}
+
+	resolve := func(value string) (string, error) {
+		value = strings.Replace(value, "${LOG_LEVEL}", "debug", -1)
+		return strings.Replace(value, "${REPLICAS}", "3", -1), nil
+	}
+
+	rendered, err := renderKustomization(dir, []*kustomizeImage{&kustomizeImage{Name: "my-image", NewTag: "abcdef"}}, resolve)
+	assert.NilError(t, err, "Error rendering kustomization")
+
+	manifests := string(rendered)
+	assert.Assert(t, strings.Contains(manifests, "image: my-image:abcdef"), "Built image not replaced:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "image: other-image:stable"), "Existing image transformer removed:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "LOG_LEVEL: debug"), "Variable not resolved in configMapGenerator:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "replicas: 3"), "Variable not resolved in patch:\n%s", manifests)
+
+	// The kustomization on disk must not be changed
+	content, err := ioutil.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	assert.NilError(t, err, "Error reading kustomization")
+	assert.Equal(t, string(content), testKustomizeFiles["kustomization.yaml"])
+
+	_, err = renderKustomization(os.TempDir(), nil, resolve)
+	assert.Assert(t, err != nil, "No error rendering a directory without kustomization")
+}
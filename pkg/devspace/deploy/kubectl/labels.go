@@ -0,0 +1,463 @@
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The labels devspace adds to every object it applies with kubectl. Together they identify the deployment an object belongs to
+const (
+	ProjectLabel    = "devspace.cloud/project"
+	ConfigLabel     = "devspace.cloud/config"
+	DeploymentLabel = "devspace.cloud/deployment"
+)
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+var invalidLabelValueChars = regexp.MustCompile("[^-A-Za-z0-9_.]+")
+
+// skippedTypeErrors are kubectl errors for types that cannot be searched, e.g. because the user is not allowed
+// to list them or because their custom resource definition has been deleted
+var skippedTypeErrors = regexp.MustCompile(`(?i)forbidden|doesn't have a resource type|no matches for kind`)
+
+// getProjectName returns the name of the project that is used for the project label. If it is not configured, the
+// name of the project directory is used together with a hash of its path, so that projects with the same directory
+// name do not share their objects
+func getProjectName(config *latest.Config, cwd string) string {
+	if config.Name != nil && *config.Name != "" {
+		return *config.Name
+	}
+
+	directoryName := labelValue(filepath.Base(cwd))
+	if len(directoryName) > 54 {
+		directoryName = directoryName[:54]
+	}
+
+	return directoryName + "-" + hash.String(cwd)[:8]
+}
+
+// labelValue converts the given string into a valid label value
+func labelValue(value string) string {
+	value = invalidLabelValueChars.ReplaceAllString(value, "-")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+
+	return strings.Trim(value, "-_.")
+}
+
+// addLabels adds the labels of this deployment to every object in the manifests
+func (d *DeployConfig) addLabels(manifests string) (string, error) {
+	labeled := []string{}
+	for _, document := range documentSeparator.Split(manifests, -1) {
+		object := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(document), &object)
+		if err != nil {
+			return "", errors.Wrap(err, "unmarshal manifest")
+		}
+		if len(object) == 0 {
+			continue
+		}
+
+		addObjectLabels(object, d.getLabels())
+
+		out, err := yaml.Marshal(object)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		labeled = append(labeled, string(out))
+	}
+
+	return strings.Join(labeled, "\n---\n"), nil
+}
+
+// addObjectLabels adds the labels to the metadata of the object. The pod templates of workloads are not changed, because
+// this would restart the pods of all workloads and fail for immutable templates like the ones of jobs
+func addObjectLabels(object map[interface{}]interface{}, labels map[string]string) {
+	kind, _ := object["kind"].(string)
+	if kind == "" {
+		return
+	}
+
+	// Label the items of lists
+	if strings.HasSuffix(kind, "List") {
+		items, _ := object["items"].([]interface{})
+		for _, item := range items {
+			if itemObject, ok := item.(map[interface{}]interface{}); ok {
+				addObjectLabels(itemObject, labels)
+			}
+		}
+
+		return
+	}
+
+	setLabels(object, labels)
+}
+
+func setLabels(object map[interface{}]interface{}, labels map[string]string) {
+	metadata, ok := object["metadata"].(map[interface{}]interface{})
+	if !ok {
+		metadata = map[interface{}]interface{}{}
+		object["metadata"] = metadata
+	}
+
+	objectLabels, ok := metadata["labels"].(map[interface{}]interface{})
+	if !ok {
+		objectLabels = map[interface{}]interface{}{}
+		metadata["labels"] = objectLabels
+	}
+
+	for key, value := range labels {
+		objectLabels[key] = value
+	}
+}
+
+// getLabels returns the labels that identify the objects of this deployment
+func (d *DeployConfig) getLabels() map[string]string {
+	return map[string]string{
+		ProjectLabel:    labelValue(d.Project),
+		ConfigLabel:     labelValue(d.ConfigName),
+		DeploymentLabel: labelValue(d.Name),
+	}
+}
+
+// getLabelSelector returns the label selector that matches the objects of this deployment
+func (d *DeployConfig) getLabelSelector() string {
+	keys := []string{}
+	labels := d.getLabels()
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	selector := []string{}
+	for _, key := range keys {
+		selector = append(selector, key+"="+labels[key])
+	}
+
+	return strings.Join(selector, ",")
+}
+
+// getResourceType returns the type of the resource in the form kubectl expects it, e.g. deployment.v1.apps
+func getResourceType(resource *deploy.Resource) string {
+	kind := strings.ToLower(resource.Kind)
+
+	splitted := strings.Split(resource.APIVersion, "/")
+	if len(splitted) != 2 {
+		return kind
+	}
+
+	return fmt.Sprintf("%s.%s.%s", kind, splitted[1], splitted[0])
+}
+
+// getResourceTypesByNamespace groups the types of the given resources by their namespace
+func getResourceTypesByNamespace(resources []*deploy.Resource) map[string][]string {
+	types := map[string][]string{}
+	found := map[string]bool{}
+
+	for _, resource := range resources {
+		resourceType := getResourceType(resource)
+		if found[resource.Namespace+"/"+resourceType] {
+			continue
+		}
+
+		found[resource.Namespace+"/"+resourceType] = true
+		types[resource.Namespace] = append(types[resource.Namespace], resourceType)
+	}
+
+	return types
+}
+
+// labeledObject is an object returned by kubectl get
+type labeledObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name            string        `json:"name"`
+		Namespace       string        `json:"namespace"`
+		OwnerReferences []interface{} `json:"ownerReferences"`
+	} `json:"metadata"`
+}
+
+// getSortedNamespaces returns the namespaces of the given types in alphabetical order
+func getSortedNamespaces(typesByNamespace map[string][]string) []string {
+	namespaces := []string{}
+	for namespace := range typesByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// runForType runs kubectl with the given arguments for a single type. Errors for types that cannot be searched are
+// logged and an empty output is returned
+func (d *DeployConfig) runForType(namespace, resourceType string, args ...string) ([]byte, error) {
+	cmdArgs := []string{}
+	if d.Context != "" {
+		cmdArgs = append(cmdArgs, "--context", d.Context)
+	}
+	if namespace != "" {
+		cmdArgs = append(cmdArgs, "--namespace", namespace)
+	}
+
+	cmdArgs = append(cmdArgs, args[0], resourceType)
+	cmdArgs = append(cmdArgs, args[1:]...)
+
+	output, err := exec.Command(d.CmdPath, cmdArgs...).Output()
+	if err != nil {
+		stderr := err.Error()
+		if exitError, ok := err.(*exec.ExitError); ok {
+			stderr = strings.TrimSpace(string(exitError.Stderr))
+		}
+		if skippedTypeErrors.MatchString(stderr) {
+			d.Log.Warnf("Skipping %s in namespace %s: %s", resourceType, namespace, stderr)
+			return nil, nil
+		}
+
+		return nil, errors.New(stderr)
+	}
+
+	return output, nil
+}
+
+// getLabeledObjects returns the objects in the cluster that have the labels of this deployment. Only the types of the
+// given resources are searched in their namespaces. Objects that are owned by other objects are ignored
+func (d *DeployConfig) getLabeledObjects(resources []*deploy.Resource) ([]*deploy.Resource, error) {
+	typesByNamespace := getResourceTypesByNamespace(resources)
+
+	objects := []*deploy.Resource{}
+	found := map[string]bool{}
+	for _, namespace := range getSortedNamespaces(typesByNamespace) {
+		for _, resourceType := range typesByNamespace[namespace] {
+			output, err := d.runForType(namespace, resourceType, "get", "--selector", d.getLabelSelector(), "--ignore-not-found=true", "--output", "json")
+			if err != nil {
+				return nil, err
+			}
+
+			items, err := parseLabeledObjects(output)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				key := item.Kind + "/" + item.Namespace + "/" + item.Name
+				if found[key] {
+					continue
+				}
+
+				found[key] = true
+				objects = append(objects, item)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// parseLabeledObjects parses the json output of kubectl get and returns the objects that are not owned by other objects
+func parseLabeledObjects(output []byte) ([]*deploy.Resource, error) {
+	list := struct {
+		Items []*labeledObject `json:"items"`
+	}{}
+	if len(strings.TrimSpace(string(output))) > 0 {
+		err := json.Unmarshal(output, &list)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse kubectl output")
+		}
+	}
+
+	objects := []*deploy.Resource{}
+	for _, item := range list.Items {
+		if len(item.Metadata.OwnerReferences) > 0 {
+			continue
+		}
+
+		objects = append(objects, &deploy.Resource{
+			APIVersion: item.APIVersion,
+			Kind:       item.Kind,
+			Name:       item.Metadata.Name,
+			Namespace:  item.Metadata.Namespace,
+		})
+	}
+
+	return objects, nil
+}
+
+// getOrphans returns the labeled objects that are not contained in the applied resources. Objects without namespace
+// are cluster scoped and only compared by kind and name
+func getOrphans(labeled []*deploy.Resource, applied []*deploy.Resource) []*deploy.Resource {
+	appliedKeys := map[string]bool{}
+	for _, resource := range applied {
+		appliedKeys[resource.Kind+"/"+resource.Namespace+"/"+resource.Name] = true
+		appliedKeys[resource.Kind+"//"+resource.Name] = true
+	}
+
+	orphans := []*deploy.Resource{}
+	for _, resource := range labeled {
+		if appliedKeys[resource.Kind+"/"+resource.Namespace+"/"+resource.Name] == false {
+			orphans = append(orphans, resource)
+		}
+	}
+
+	return orphans
+}
+
+// Orphans returns the objects that have the labels of this deployment, but were not applied by the last deploy
+func (d *DeployConfig) Orphans() ([]*deploy.Resource, error) {
+	resources := append([]*deploy.Resource{}, d.resources...)
+	resources = append(resources, d.previousResources...)
+
+	labeled, err := d.getLabeledObjects(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOrphans(labeled, d.resources), nil
+}
+
+// Prune deletes the given objects
+func (d *DeployConfig) Prune(resources []*deploy.Resource) error {
+	namesByNamespace := map[string][]string{}
+	namespaces := []string{}
+	for _, resource := range resources {
+		if _, ok := namesByNamespace[resource.Namespace]; ok == false {
+			namespaces = append(namespaces, resource.Namespace)
+		}
+
+		namesByNamespace[resource.Namespace] = append(namesByNamespace[resource.Namespace], getResourceType(resource)+"/"+resource.Name)
+	}
+
+	for _, namespace := range namespaces {
+		args := []string{}
+		if d.Context != "" {
+			args = append(args, "--context", d.Context)
+		}
+		if namespace != "" {
+			args = append(args, "--namespace", namespace)
+		}
+
+		args = append(args, "delete")
+		args = append(args, namesByNamespace[namespace]...)
+		args = append(args, "--ignore-not-found=true")
+
+		cmd := exec.Command(d.CmdPath, args...)
+		cmd.Stdout = d.Log
+		cmd.Stderr = d.Log
+
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("Error deleting objects of deployment %s: %v", d.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteLabeledObjects deletes all objects with the labels of this deployment. Only the types of the given resources
+// are deleted in their namespaces
+func (d *DeployConfig) deleteLabeledObjects(resources []*deploy.Resource) error {
+	typesByNamespace := getResourceTypesByNamespace(resources)
+
+	for _, namespace := range getSortedNamespaces(typesByNamespace) {
+		for _, resourceType := range typesByNamespace[namespace] {
+			output, err := d.runForType(namespace, resourceType, "delete", "--selector", d.getLabelSelector(), "--ignore-not-found=true")
+			if err != nil {
+				return err
+			}
+
+			d.Log.Write(output)
+		}
+	}
+
+	return nil
+}
+
+// getLabeledWorkloadsAndServices returns the deployments, statefulsets, daemonsets and services in the namespace
+// of the deployment that have the labels of this deployment together with the pod selectors of the workloads
+func (d *DeployConfig) getLabeledWorkloadsAndServices() ([]*deploy.Resource, []*metav1.LabelSelector, error) {
+	resources := []*deploy.Resource{}
+	selectors := []*metav1.LabelSelector{}
+	options := metav1.ListOptions{LabelSelector: d.getLabelSelector()}
+
+	deployments, err := d.KubeClient.AppsV1().Deployments(d.Namespace).List(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, deployment := range deployments.Items {
+		resources = append(resources, &deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, Namespace: d.Namespace})
+		selectors = append(selectors, deployment.Spec.Selector)
+	}
+
+	statefulSets, err := d.KubeClient.AppsV1().StatefulSets(d.Namespace).List(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		resources = append(resources, &deploy.Resource{APIVersion: "apps/v1", Kind: "StatefulSet", Name: statefulSet.Name, Namespace: d.Namespace})
+		selectors = append(selectors, statefulSet.Spec.Selector)
+	}
+
+	daemonSets, err := d.KubeClient.AppsV1().DaemonSets(d.Namespace).List(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, daemonSet := range daemonSets.Items {
+		resources = append(resources, &deploy.Resource{APIVersion: "apps/v1", Kind: "DaemonSet", Name: daemonSet.Name, Namespace: d.Namespace})
+		selectors = append(selectors, daemonSet.Spec.Selector)
+	}
+
+	services, err := d.KubeClient.CoreV1().Services(d.Namespace).List(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, service := range services.Items {
+		resources = append(resources, &deploy.Resource{APIVersion: "v1", Kind: "Service", Name: service.Name, Namespace: d.Namespace})
+	}
+
+	return resources, selectors, nil
+}
+
+// getWorkloadPods returns the pods that are selected by the given workload selectors
+func (d *DeployConfig) getWorkloadPods(selectors []*metav1.LabelSelector) ([]v1.Pod, error) {
+	pods := []v1.Pod{}
+	found := map[string]bool{}
+	for _, selector := range selectors {
+		if selector == nil {
+			continue
+		}
+
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		podList, err := d.KubeClient.CoreV1().Pods(d.Namespace).List(metav1.ListOptions{LabelSelector: labelSelector.String()})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range podList.Items {
+			if found[pod.Name] == false {
+				found[pod.Name] = true
+				pods = append(pods, pod)
+			}
+		}
+	}
+
+	return pods, nil
+}
@@ -0,0 +1,120 @@
+package kubectl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+
+	"gotest.tools/assert"
+)
+
+func TestLabelValue(t *testing.T) {
+	assert.Equal(t, labelValue("my-project"), "my-project")
+	assert.Equal(t, labelValue("My Project (2)"), "My-Project-2")
+	assert.Equal(t, labelValue("_project."), "project")
+	assert.Equal(t, len(labelValue(strings.Repeat("a", 100))), 63)
+}
+
+func TestAddLabels(t *testing.T) {
+	deployConfig := &DeployConfig{
+		Name:       "my-deployment",
+		Project:    "my-project",
+		ConfigName: "production",
+	}
+
+	labeled, err := deployConfig.addLabels(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: my-service
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-deployment
+  labels:
+    app: test
+spec:
+  template:
+    metadata:
+      labels:
+        app: test
+`)
+	assert.NilError(t, err, "Error adding labels")
+
+	// Only the object metadata is labeled, pod templates are left untouched
+	assert.Equal(t, strings.Count(labeled, "devspace.cloud/project: my-project"), 2, "Unexpected labels:\n%s", labeled)
+	assert.Equal(t, strings.Count(labeled, "devspace.cloud/config: production"), 2, "Unexpected labels:\n%s", labeled)
+	assert.Equal(t, strings.Count(labeled, "devspace.cloud/deployment: my-deployment"), 2, "Unexpected labels:\n%s", labeled)
+	assert.Equal(t, strings.Count(labeled, "app: test"), 2, "Existing labels removed:\n%s", labeled)
+
+	assert.Equal(t, deployConfig.getLabelSelector(), "devspace.cloud/config=production,devspace.cloud/deployment=my-deployment,devspace.cloud/project=my-project")
+}
+
+func TestGetOrphans(t *testing.T) {
+	applied := []*deploy.Resource{
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "default"},
+		&deploy.Resource{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "app", Namespace: "default"},
+	}
+	labeled := []*deploy.Resource{
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "default"},
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", Namespace: "other"},
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "old-app", Namespace: "default"},
+		&deploy.Resource{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "app"},
+	}
+
+	orphans := getOrphans(labeled, applied)
+	assert.Equal(t, len(orphans), 2)
+	assert.Equal(t, orphans[0].Namespace, "other")
+	assert.Equal(t, orphans[1].Name, "old-app")
+}
+
+func TestGetResourceTypesByNamespace(t *testing.T) {
+	types := getResourceTypesByNamespace([]*deploy.Resource{
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "a", Namespace: "other"},
+		&deploy.Resource{APIVersion: "apps/v1", Kind: "Deployment", Name: "b", Namespace: "other"},
+		&deploy.Resource{APIVersion: "v1", Kind: "Service", Name: "a", Namespace: "other"},
+		&deploy.Resource{APIVersion: "example.com/v1", Kind: "MyResource", Name: "a", Namespace: "default"},
+	})
+
+	// Types of the applied objects are only searched once per namespace
+	assert.DeepEqual(t, types["other"], []string{"deployment.v1.apps", "service"})
+	assert.DeepEqual(t, types["default"], []string{"myresource.v1.example.com"})
+	assert.DeepEqual(t, getSortedNamespaces(types), []string{"default", "other"})
+}
+
+func TestGetProjectName(t *testing.T) {
+	name := "my-project"
+	assert.Equal(t, getProjectName(&latest.Config{Name: &name}, "/home/user/app"), "my-project")
+
+	// Directories with the same name get different project names
+	first := getProjectName(&latest.Config{}, "/home/user/app")
+	second := getProjectName(&latest.Config{}, "/home/other/app")
+	assert.Assert(t, strings.HasPrefix(first, "app-"), first)
+	assert.Assert(t, first != second)
+	assert.Equal(t, first, getProjectName(&latest.Config{}, "/home/user/app"))
+	assert.Assert(t, len(getProjectName(&latest.Config{}, "/"+strings.Repeat("a", 100))) <= 63)
+}
+
+func TestParseLabeledObjects(t *testing.T) {
+	objects, err := parseLabeledObjects([]byte(`{"items": [
+		{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app", "namespace": "default"}},
+		{"apiVersion": "apps/v1", "kind": "ReplicaSet", "metadata": {"name": "app-123", "namespace": "default", "ownerReferences": [{"kind": "Deployment"}]}}
+	]}`))
+	assert.NilError(t, err, "Error parsing objects")
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objects[0].Kind, "Deployment")
+	assert.Equal(t, objects[0].Namespace, "default")
+
+	// Skipped types return no output
+	objects, err = parseLabeledObjects(nil)
+	assert.NilError(t, err, "Error parsing empty output")
+	assert.Equal(t, len(objects), 0)
+
+	assert.Assert(t, skippedTypeErrors.MatchString(`Error from server (Forbidden): secrets is forbidden: User "test" cannot list resource "secrets"`))
+	assert.Assert(t, !skippedTypeErrors.MatchString("Unable to connect to the server"))
+}
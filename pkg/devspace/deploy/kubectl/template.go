@@ -0,0 +1,285 @@
+package kubectl
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// templateData is the data the templates of a template deployment are executed with
+type templateData struct {
+	// Values holds the merged values of the values files and the values in the config. The maps have string keys,
+	// so that the sprig dict functions like hasKey can be used
+	Values map[string]interface{}
+
+	// Images maps the image config names to the image with the tag of the last build, e.g. .Images.default
+	Images map[string]string
+
+	// Tags maps the image config names to the tag of the last build
+	Tags map[string]string
+
+	// Vars holds the values of the devspace variables
+	Vars map[string]string
+
+	Namespace  string
+	Deployment string
+}
+
+// isTemplateHelper returns true for files that only define named templates and are not rendered themselves
+func isTemplateHelper(path string) bool {
+	return strings.HasPrefix(filepath.Base(path), "_") || filepath.Ext(path) == ".tpl"
+}
+
+// isTemplateManifest returns true for files that are rendered as manifests
+func isTemplateManifest(path string) bool {
+	ext := filepath.Ext(path)
+	return isTemplateHelper(path) == false && (ext == ".yaml" || ext == ".yml")
+}
+
+// getTemplateValues merges the values files and the values of the template config
+func getTemplateValues(templateConfig *latest.TemplateConfig) (map[interface{}]interface{}, error) {
+	values := map[interface{}]interface{}{}
+
+	if templateConfig.ValuesFiles != nil {
+		for _, valuesFile := range *templateConfig.ValuesFiles {
+			valuesFromFile := map[interface{}]interface{}{}
+			err := yamlutil.ReadYamlFromFile(*valuesFile, valuesFromFile)
+			if err != nil {
+				return nil, errors.Wrapf(err, "read values file %s", *valuesFile)
+			}
+
+			mergeValues(values, valuesFromFile)
+		}
+	}
+
+	if templateConfig.Values != nil {
+		mergeValues(values, *templateConfig.Values)
+	}
+
+	return values, nil
+}
+
+// mergeValues merges src into dest. Maps are merged while values and arrays are replaced
+func mergeValues(dest, src map[interface{}]interface{}) {
+	for key, srcVal := range src {
+		srcMap, srcIsMap := srcVal.(map[interface{}]interface{})
+		destMap, destIsMap := dest[key].(map[interface{}]interface{})
+
+		if srcIsMap && destIsMap {
+			mergeValues(destMap, srcMap)
+		} else {
+			dest[key] = srcVal
+		}
+	}
+}
+
+// getTemplateData returns the data the templates are executed with
+func (d *DeployConfig) getTemplateData(cache *generated.CacheConfig) (*templateData, error) {
+	values, err := getTemplateValues(d.DeploymentConfig.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &templateData{
+		Values:     stringKeys(values).(map[string]interface{}),
+		Images:     map[string]string{},
+		Tags:       map[string]string{},
+		Vars:       map[string]string{},
+		Namespace:  d.Namespace,
+		Deployment: d.Name,
+	}
+
+	for imageConfigName, imageCache := range cache.Images {
+		if imageCache.ImageName == "" {
+			continue
+		}
+
+		data.Images[imageConfigName] = imageCache.ImageName
+		if imageCache.Tag != "" {
+			data.Images[imageConfigName] += ":" + imageCache.Tag
+			data.Tags[imageConfigName] = imageCache.Tag
+		}
+	}
+
+	// Secret variables and variables from other sources are not saved in the cache
+	data.Vars = configutil.GetVars(cache)
+
+	return data, nil
+}
+
+// stringKeys converts all maps in the given value to maps with string keys
+func stringKeys(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, val := range typedValue {
+			converted[fmt.Sprintf("%v", key)] = stringKeys(val)
+		}
+
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(typedValue))
+		for i, val := range typedValue {
+			converted[i] = stringKeys(val)
+		}
+
+		return converted
+	}
+
+	return value
+}
+
+// renderTemplates executes all yaml files in the given directory as go templates and returns the rendered manifests.
+// Files starting with an underscore or ending with .tpl can be used to define named templates. The files in skip are ignored
+func renderTemplates(path string, skip map[string]bool, data *templateData, resolve func(string) (string, error)) (string, error) {
+	files := []string{}
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			return err
+		}
+		if skip[absPath] {
+			return nil
+		}
+
+		if info.IsDir() == false && (isTemplateHelper(filePath) || isTemplateManifest(filePath)) {
+			files = append(files, filePath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", errors.Errorf("No templates found in %s", path)
+	}
+
+	sort.Strings(files)
+
+	// Missing keys are errors instead of rendering "<no value>". Optional values can be checked with hasKey or index
+	tpl := template.New("").Option("missingkey=error")
+	funcMap := sprig.TxtFuncMap()
+	funcMap["toYaml"] = func(value interface{}) string {
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimSuffix(string(out), "\n")
+	}
+	funcMap["include"] = func(name string, data interface{}) (string, error) {
+		buf := &bytes.Buffer{}
+		err := tpl.ExecuteTemplate(buf, name, data)
+		return buf.String(), err
+	}
+	funcMap["var"] = func(name string) (string, error) {
+		return resolve("${" + name + "}")
+	}
+	tpl.Funcs(funcMap)
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = tpl.New(file).Parse(string(content))
+		if err != nil {
+			return "", errors.Wrapf(err, "parse template %s", file)
+		}
+	}
+
+	rendered := []string{}
+	for _, file := range files {
+		if isTemplateManifest(file) == false {
+			continue
+		}
+
+		buf := &bytes.Buffer{}
+		err = tpl.ExecuteTemplate(buf, file, data)
+		if err != nil {
+			return "", errors.Wrapf(err, "render template %s", file)
+		}
+
+		for _, document := range documentSeparator.Split(buf.String(), -1) {
+			if strings.TrimSpace(document) != "" {
+				rendered = append(rendered, strings.TrimSpace(document))
+			}
+		}
+	}
+
+	return strings.Join(rendered, "\n---\n"), nil
+}
+
+// getTemplateManifest renders the templates of a template deployment and labels all rendered objects
+func (d *DeployConfig) getTemplateManifest(manifest string, cache *generated.CacheConfig, builtImages map[string]string) (bool, string, error) {
+	data, err := d.getTemplateData(cache)
+	if err != nil {
+		return false, "", err
+	}
+
+	// Values files can be placed next to the templates
+	valuesFiles := map[string]bool{}
+	if d.DeploymentConfig.Template.ValuesFiles != nil {
+		for _, valuesFile := range *d.DeploymentConfig.Template.ValuesFiles {
+			absPath, err := filepath.Abs(*valuesFile)
+			if err != nil {
+				return false, "", err
+			}
+
+			valuesFiles[absPath] = true
+		}
+	}
+
+	rendered, err := renderTemplates(manifest, valuesFiles, data, configutil.ResolveVarsInString)
+	if err != nil {
+		return false, "", err
+	}
+
+	shouldRedeploy := false
+	replacedManifests := []string{}
+	for _, document := range documentSeparator.Split(rendered, -1) {
+		object := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(document), &object)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "unmarshal rendered templates of %s", manifest)
+		}
+		if len(object) == 0 {
+			continue
+		}
+
+		addObjectLabels(object, d.getLabels())
+
+		// Replace image names that are used without the template data in the same way as for kubectl manifests
+		if len(cache.Images) > 0 {
+			shouldRedeploy = replaceManifest(object, cache, builtImages) || shouldRedeploy
+		}
+
+		replacedManifest, err := yaml.Marshal(object)
+		if err != nil {
+			return false, "", errors.Wrap(err, "marshal yaml")
+		}
+
+		replacedManifests = append(replacedManifests, string(replacedManifest))
+	}
+
+	return shouldRedeploy, strings.Join(replacedManifests, "\n---\n"), nil
+}
@@ -0,0 +1,238 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	yaml "gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+var testTemplateFiles = map[string]string{
+	"_helpers.tpl": `{{- define "labels" -}}
+app: {{ .Deployment }}
+{{- end -}}`,
+	"deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Deployment }}
+  labels:
+{{ include "labels" . | indent 4 }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: app
+        image: {{ .Images.default }}
+        env:
+        - name: LOG_LEVEL
+          value: {{ var "LOG_LEVEL" | quote }}
+`,
+	"config/configmap.yml": `{{- if hasKey .Values "config" }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: {{ .Namespace }}
+data:
+{{ toYaml .Values.config | indent 2 }}
+{{- end }}`,
+	"README.md": "{{ this is not rendered",
+}
+
+func TestTemplateDeploy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-template")
+	assert.NilError(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	for name, content := range testTemplateFiles {
+		err = os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		assert.NilError(t, err, "Error creating directory for %s", name)
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err, "Error writing %s", name)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "values.yaml"), []byte("replicas: 1\nconfig:\n  a: b\n"), 0644)
+	assert.NilError(t, err, "Error writing values file")
+
+	deployConfig := &DeployConfig{
+		Name:      "my-deployment",
+		Namespace: "my-namespace",
+		Manifests: []string{dir},
+		Template:  true,
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: ptr.String("my-deployment"),
+			Template: &latest.TemplateConfig{
+				Path:        ptr.String(dir),
+				ValuesFiles: &[]*string{ptr.String(filepath.Join(dir, "values.yaml"))},
+				Values: &map[interface{}]interface{}{
+					"replicas": 3,
+				},
+			},
+		},
+		Log: log.Discard,
+	}
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"default": &generated.ImageCache{
+				ImageName: "my-image",
+				Tag:       "abcdef",
+			},
+		},
+		Vars: map[string]string{},
+	}
+
+	os.Setenv("LOG_LEVEL", "debug")
+	defer os.Unsetenv("LOG_LEVEL")
+
+	// The values file in the template directory must not be rendered
+	shouldRedeploy, manifests, err := deployConfig.getTemplateManifest(dir, cache, map[string]string{"my-image": "abcdef"})
+	assert.NilError(t, err, "Error rendering templates")
+	assert.Equal(t, shouldRedeploy, true)
+
+	resources, err := deploy.ParseResources(manifests, deployConfig.Namespace)
+	assert.NilError(t, err, "Error parsing rendered manifests")
+	assert.Equal(t, len(resources), 2, "Unexpected objects rendered:\n%s", manifests)
+	assert.Equal(t, resources[0].Kind, "ConfigMap")
+	assert.Equal(t, resources[0].Namespace, "my-namespace")
+	assert.Equal(t, resources[1].Kind, "Deployment")
+
+	documents := documentSeparator.Split(manifests, -1)
+	configMap := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(documents[0]), &configMap)
+	assert.NilError(t, err, "Error parsing config map")
+	assert.Equal(t, configMap["data"].(map[interface{}]interface{})["a"], "b")
+	assert.Equal(t, configMap["metadata"].(map[interface{}]interface{})["labels"].(map[interface{}]interface{})[DeploymentLabel], "my-deployment")
+
+	assert.Assert(t, strings.Contains(manifests, "image: my-image:abcdef"), "Image not rendered:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "replicas: 3"), "Values not rendered:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "app: my-deployment"), "Named template not included:\n%s", manifests)
+	assert.Assert(t, strings.Contains(manifests, "value: debug"), "Variable not rendered:\n%s", manifests)
+
+	deployment := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(documents[1]), &deployment)
+	assert.NilError(t, err, "Error parsing deployment")
+	assert.Equal(t, deployment["metadata"].(map[interface{}]interface{})["labels"].(map[interface{}]interface{})[DeploymentLabel], "my-deployment")
+	assert.Equal(t, strings.Count(documents[1], DeploymentLabel), 1, "Pod template labeled:\n%s", documents[1])
+
+	// Without values the config map is not rendered
+	err = os.Remove(filepath.Join(dir, "values.yaml"))
+	assert.NilError(t, err, "Error removing values file")
+	deployConfig.DeploymentConfig.Template.ValuesFiles = nil
+	_, manifests, err = deployConfig.getTemplateManifest(dir, cache, nil)
+	assert.NilError(t, err, "Error rendering templates")
+
+	resources, err = deploy.ParseResources(manifests, deployConfig.Namespace)
+	assert.NilError(t, err, "Error parsing rendered manifests")
+	assert.Equal(t, len(resources), 1, "Config map without values rendered:\n%s", manifests)
+
+	_, _, err = deployConfig.getTemplateManifest(filepath.Join(dir, "empty"), cache, nil)
+	assert.Assert(t, err != nil, "No error rendering a directory without templates")
+}
+
+func TestTemplateStatus(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	deployConfig := &DeployConfig{
+		Name:       "my-deployment",
+		Namespace:  "my-namespace",
+		Manifests:  []string{"templates"},
+		Template:   true,
+		Project:    "my-project",
+		ConfigName: "default",
+		KubeClient: kubeClient,
+	}
+
+	status, err := deployConfig.Status()
+	assert.NilError(t, err, "Error getting status")
+	assert.Equal(t, status.Type, "Template")
+	assert.Equal(t, status.Status, "No pods found")
+
+	// Pods are found by the selector of the labeled workloads
+	_, err = kubeClient.AppsV1().Deployments("my-namespace").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "my-app",
+			Labels: deployConfig.getLabels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+		},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+
+	for _, ready := range []bool{true, false} {
+		_, err = kubeClient.CoreV1().Pods("my-namespace").Create(&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pod-" + map[bool]string{true: "ready", false: "not-ready"}[ready],
+				Labels: map[string]string{"app": "my-app"},
+			},
+			Status: k8sv1.PodStatus{
+				Phase:             k8sv1.PodRunning,
+				ContainerStatuses: []k8sv1.ContainerStatus{k8sv1.ContainerStatus{Ready: ready}},
+			},
+		})
+		assert.NilError(t, err, "Error creating pod")
+	}
+
+	_, err = kubeClient.CoreV1().Pods("my-namespace").Create(&k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "other-pod",
+			Labels: map[string]string{"app": "other-app"},
+		},
+	})
+	assert.NilError(t, err, "Error creating pod")
+
+	status, err = deployConfig.Status()
+	assert.NilError(t, err, "Error getting status")
+	assert.Equal(t, status.Status, "1/2 pods ready")
+}
+
+func TestTemplateMissingKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-template")
+	assert.NilError(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "configmap.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.name }}\ndata:\n  optional: {{ index .Values \"optional\" | default \"none\" | quote }}\n"), 0644)
+	assert.NilError(t, err, "Error writing template")
+
+	data := &templateData{Values: stringKeys(map[interface{}]interface{}{"name": "config"}).(map[string]interface{})}
+	rendered, err := renderTemplates(dir, nil, data, nil)
+	assert.NilError(t, err, "Error rendering templates")
+	assert.Assert(t, strings.Contains(rendered, `optional: "none"`), "Optional value not rendered:\n%s", rendered)
+
+	// Missing values must not be rendered as <no value>
+	data.Values = map[string]interface{}{}
+	_, err = renderTemplates(dir, nil, data, nil)
+	assert.Assert(t, err != nil && strings.Contains(err.Error(), "map has no entry for key \"name\""), "Unexpected error: %v", err)
+}
+
+func TestTemplateDataVars(t *testing.T) {
+	deployConfig := &DeployConfig{
+		DeploymentConfig: &latest.DeploymentConfig{
+			Template: &latest.TemplateConfig{},
+		},
+	}
+
+	configutil.ResolvedVars["SECRET_VAR"] = "secret"
+	defer delete(configutil.ResolvedVars, "SECRET_VAR")
+
+	data, err := deployConfig.getTemplateData(&generated.CacheConfig{Vars: map[string]string{"CACHED_VAR": "cached"}})
+	assert.NilError(t, err, "Error getting template data")
+	assert.Equal(t, data.Vars["CACHED_VAR"], "cached")
+	assert.Equal(t, data.Vars["SECRET_VAR"], "secret")
+}
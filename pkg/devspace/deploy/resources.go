@@ -0,0 +1,79 @@
+package deploy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Resource identifies a kubernetes object that was applied by a deployment
+type Resource struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// ParseResources parses the given multi document yaml manifests and returns the contained kubernetes objects.
+// Objects without a namespace are assigned to the given default namespace
+func ParseResources(manifests string, defaultNamespace string) ([]*Resource, error) {
+	resources := []*Resource{}
+
+	for _, document := range documentSeparator.Split(manifests, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		object := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(document), &object)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal manifest")
+		}
+
+		resources = append(resources, parseObject(object, defaultNamespace)...)
+	}
+
+	return resources, nil
+}
+
+func parseObject(object map[interface{}]interface{}, defaultNamespace string) []*Resource {
+	kind, _ := object["kind"].(string)
+	if kind == "" {
+		return nil
+	}
+
+	// Unpack lists
+	if strings.HasSuffix(kind, "List") {
+		resources := []*Resource{}
+		items, _ := object["items"].([]interface{})
+
+		for _, item := range items {
+			itemObject, ok := item.(map[interface{}]interface{})
+			if ok {
+				resources = append(resources, parseObject(itemObject, defaultNamespace)...)
+			}
+		}
+
+		return resources
+	}
+
+	resource := &Resource{
+		Kind:      kind,
+		Namespace: defaultNamespace,
+	}
+	resource.APIVersion, _ = object["apiVersion"].(string)
+
+	metadata, ok := object["metadata"].(map[interface{}]interface{})
+	if ok {
+		resource.Name, _ = metadata["name"].(string)
+		if namespace, ok := metadata["namespace"].(string); ok && namespace != "" {
+			resource.Namespace = namespace
+		}
+	}
+
+	return []*Resource{resource}
+}
@@ -0,0 +1,47 @@
+package deploy
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const testManifests = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-service
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-deployment
+  namespace: other
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: my-configmap
+`
+
+func TestParseResources(t *testing.T) {
+	resources, err := ParseResources(testManifests, "default")
+	assert.NilError(t, err, "Error parsing resources")
+	assert.Equal(t, len(resources), 3, "Wrong number of resources")
+
+	assert.Equal(t, resources[0].Kind, "Service")
+	assert.Equal(t, resources[0].Name, "my-service")
+	assert.Equal(t, resources[0].Namespace, "default")
+
+	assert.Equal(t, resources[1].Kind, "Deployment")
+	assert.Equal(t, resources[1].APIVersion, "apps/v1")
+	assert.Equal(t, resources[1].Namespace, "other")
+
+	assert.Equal(t, resources[2].Kind, "ConfigMap")
+	assert.Equal(t, resources[2].Name, "my-configmap")
+
+	_, err = ParseResources("kind: [", "default")
+	assert.Assert(t, err != nil, "No error parsing invalid yaml")
+}
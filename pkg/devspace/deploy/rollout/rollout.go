@@ -0,0 +1,369 @@
+package rollout
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/analyze"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultTimeout is the default time to wait for the workloads of a deployment to become ready
+const DefaultTimeout = 180 * time.Second
+
+// deploymentRevisionAnnotation is the annotation that holds the revision of deployments and their replica sets
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// pollInterval is the interval in which the workload status is checked
+var pollInterval = time.Second
+
+// workloadStatus holds the current rollout status of a single workload
+type workloadStatus struct {
+	// Pending is empty if the workload is rolled out
+	Pending string
+	Pods    []k8sv1.Pod
+}
+
+// WaitForDeployment waits for the workloads of a deployment as configured in the rollout options of the deployment
+func WaitForDeployment(client kubernetes.Interface, deployConfig *latest.DeploymentConfig, resources []*deploy.Resource, log log.Logger) error {
+	timeout := DefaultTimeout
+	warnOnly := false
+
+	if deployConfig.Rollout != nil {
+		if deployConfig.Rollout.Disabled != nil && *deployConfig.Rollout.Disabled {
+			return nil
+		}
+		if deployConfig.Rollout.Timeout != nil {
+			timeout = time.Duration(*deployConfig.Rollout.Timeout) * time.Second
+		}
+		if deployConfig.Rollout.WarnOnly != nil {
+			warnOnly = *deployConfig.Rollout.WarnOnly
+		}
+	}
+
+	err := Wait(client, resources, timeout, log)
+	if err != nil {
+		if warnOnly {
+			log.Warnf("Deployment %s did not roll out successfully: %v", *deployConfig.Name, err)
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Wait waits until all deployments, statefulsets and daemonsets within resources are rolled out. It returns an error containing
+// the analyze report of the affected pods if a pod cannot start or the rollout does not finish within the timeout
+func Wait(client kubernetes.Interface, resources []*deploy.Resource, timeout time.Duration, log log.Logger) error {
+	workloads := []*deploy.Resource{}
+	for _, resource := range resources {
+		if resource.Kind == "Deployment" || resource.Kind == "StatefulSet" || resource.Kind == "DaemonSet" {
+			workloads = append(workloads, resource)
+		}
+	}
+	if len(workloads) == 0 {
+		return nil
+	}
+
+	defer log.StopWait()
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pending := []string{}
+		pods := []k8sv1.Pod{}
+		criticalPods := []k8sv1.Pod{}
+
+		for _, workload := range workloads {
+			status, err := getWorkloadStatus(client, workload)
+			if err != nil {
+				return errors.Wrapf(err, "get %s %s", workload.Kind, workload.Name)
+			}
+			if status.Pending == "" {
+				continue
+			}
+
+			pending = append(pending, status.Pending)
+			pods = append(pods, status.Pods...)
+
+			for _, pod := range status.Pods {
+				if _, ok := analyze.CriticalStatus[kubectl.GetPodStatus(&pod)]; ok {
+					criticalPods = append(criticalPods, pod)
+				}
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		// Fail fast if a pod will not be able to start
+		if len(criticalPods) > 0 {
+			return newRolloutError("Rollout failed", pending, analyze.CheckPods(client, criticalPods))
+		}
+		if time.Now().After(deadline) {
+			return newRolloutError(fmt.Sprintf("Rollout timed out after %s", timeout.String()), pending, analyze.CheckPods(client, pods))
+		}
+
+		log.StartWait(pending[0])
+		time.Sleep(pollInterval)
+	}
+}
+
+func newRolloutError(message string, pending []string, podProblems []string) error {
+	report := []*analyze.ReportItem{
+		&analyze.ReportItem{
+			Name:     "Rollout",
+			Problems: pending,
+		},
+	}
+	if len(podProblems) > 0 {
+		report = append(report, &analyze.ReportItem{
+			Name:     "Pods",
+			Problems: podProblems,
+		})
+	}
+
+	return fmt.Errorf("%s:\n%s", message, analyze.ReportToString(report))
+}
+
+func getWorkloadStatus(client kubernetes.Interface, workload *deploy.Resource) (*workloadStatus, error) {
+	var (
+		pending  string
+		selector *metav1.LabelSelector
+
+		// revisionLabel and revision select the pods of the current revision of the workload. Pods of old revisions,
+		// e.g. of a crashing version that is replaced, are ignored
+		revisionLabel string
+		revision      string
+	)
+
+	switch workload.Kind {
+	case "Deployment":
+		deployment, err := client.AppsV1().Deployments(workload.Namespace).Get(workload.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return &workloadStatus{Pending: fmt.Sprintf("Waiting for deployment %s to be created", workload.Name)}, nil
+			}
+
+			return nil, err
+		}
+
+		pending = getDeploymentPending(deployment)
+		selector = deployment.Spec.Selector
+		revisionLabel = appsv1.DefaultDeploymentUniqueLabelKey
+
+		if pending != "" {
+			revision, err = getDeploymentRevision(client, deployment)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "StatefulSet":
+		statefulSet, err := client.AppsV1().StatefulSets(workload.Namespace).Get(workload.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return &workloadStatus{Pending: fmt.Sprintf("Waiting for statefulset %s to be created", workload.Name)}, nil
+			}
+
+			return nil, err
+		}
+
+		pending = getStatefulSetPending(statefulSet)
+		selector = statefulSet.Spec.Selector
+		revisionLabel = appsv1.ControllerRevisionHashLabelKey
+		revision = statefulSet.Status.UpdateRevision
+	case "DaemonSet":
+		daemonSet, err := client.AppsV1().DaemonSets(workload.Namespace).Get(workload.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return &workloadStatus{Pending: fmt.Sprintf("Waiting for daemonset %s to be created", workload.Name)}, nil
+			}
+
+			return nil, err
+		}
+
+		pending = getDaemonSetPending(daemonSet)
+		selector = daemonSet.Spec.Selector
+		revisionLabel = appsv1.DefaultDaemonSetUniqueLabelKey
+
+		if pending != "" {
+			revision, err = getDaemonSetRevision(client, daemonSet)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return &workloadStatus{}, nil
+	}
+
+	status := &workloadStatus{
+		Pending: pending,
+	}
+
+	// Get the pods of the current revision if the workload is not ready yet. If the current revision is not known yet,
+	// there are no pods to check
+	if pending != "" && selector != nil && revision != "" {
+		selector = selector.DeepCopy()
+		if selector.MatchLabels == nil {
+			selector.MatchLabels = map[string]string{}
+		}
+		selector.MatchLabels[revisionLabel] = revision
+
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		podList, err := client.CoreV1().Pods(workload.Namespace).List(metav1.ListOptions{
+			LabelSelector: labelSelector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		status.Pods = podList.Items
+	}
+
+	return status, nil
+}
+
+// getDeploymentRevision returns the pod-template-hash of the current replica set of the deployment or an empty string
+// if the replica set hasn't been created yet
+func getDeploymentRevision(client kubernetes.Interface, deployment *appsv1.Deployment) (string, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+
+	replicaSets, err := client.AppsV1().ReplicaSets(deployment.Namespace).List(metav1.ListOptions{
+		LabelSelector: labelSelector.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	deploymentRevision := deployment.Annotations[deploymentRevisionAnnotation]
+	for _, replicaSet := range replicaSets.Items {
+		if isOwnedBy(replicaSet.OwnerReferences, deployment.UID) && replicaSet.Annotations[deploymentRevisionAnnotation] == deploymentRevision {
+			return replicaSet.Labels[appsv1.DefaultDeploymentUniqueLabelKey], nil
+		}
+	}
+
+	return "", nil
+}
+
+// getDaemonSetRevision returns the hash of the newest controller revision of the daemon set or an empty string if
+// there is none yet
+func getDaemonSetRevision(client kubernetes.Interface, daemonSet *appsv1.DaemonSet) (string, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+
+	revisions, err := client.AppsV1().ControllerRevisions(daemonSet.Namespace).List(metav1.ListOptions{
+		LabelSelector: labelSelector.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var newest *appsv1.ControllerRevision
+	for i, revision := range revisions.Items {
+		if isOwnedBy(revision.OwnerReferences, daemonSet.UID) && (newest == nil || revision.Revision > newest.Revision) {
+			newest = &revisions.Items[i]
+		}
+	}
+	if newest == nil {
+		return "", nil
+	}
+
+	return newest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+func isOwnedBy(ownerReferences []metav1.OwnerReference, uid types.UID) bool {
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.UID == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getDeploymentPending(deployment *appsv1.Deployment) string {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return fmt.Sprintf("Waiting for deployment %s spec update to be observed", deployment.Name)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("Waiting for deployment %s rollout: %d of %d new replicas have been updated", deployment.Name, deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for deployment %s rollout: %d old replicas are pending termination", deployment.Name, deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for deployment %s rollout: %d of %d updated replicas are available", deployment.Name, deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+
+	return ""
+}
+
+func getStatefulSetPending(statefulSet *appsv1.StatefulSet) string {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return fmt.Sprintf("Waiting for statefulset %s spec update to be observed", statefulSet.Name)
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("Waiting for statefulset %s rollout: %d of %d pods are ready", statefulSet.Name, statefulSet.Status.ReadyReplicas, replicas)
+	}
+
+	// Partitioned and OnDelete updates never reach the update revision completely
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return ""
+	}
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil && *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition > 0 {
+		return ""
+	}
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		return fmt.Sprintf("Waiting for statefulset %s rollout: %d pods at revision %s", statefulSet.Name, statefulSet.Status.UpdatedReplicas, statefulSet.Status.UpdateRevision)
+	}
+
+	return ""
+}
+
+func getDaemonSetPending(daemonSet *appsv1.DaemonSet) string {
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return fmt.Sprintf("Waiting for daemonset %s spec update to be observed", daemonSet.Name)
+	}
+	if daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for daemonset %s rollout: %d of %d updated pods have been scheduled", daemonSet.Name, daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	}
+	if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for daemonset %s rollout: %d of %d updated pods are available", daemonSet.Name, daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+
+	return ""
+}
@@ -0,0 +1,169 @@
+package rollout
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "test-rollout"
+
+func createDeployment(t *testing.T, client *fake.Clientset, name string, updatedReplicas, availableReplicas int32) {
+	replicas := int32(1)
+	_, err := client.AppsV1().Deployments(testNamespace).Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   testNamespace,
+			UID:         types.UID(name),
+			Annotations: map[string]string{deploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          updatedReplicas,
+			UpdatedReplicas:   updatedReplicas,
+			AvailableReplicas: availableReplicas,
+		},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+}
+
+// createReplicaSet creates a replica set of the given deployment revision and returns its pod-template-hash
+func createReplicaSet(t *testing.T, client *fake.Clientset, deployment, revision string) string {
+	hash := deployment + "-" + revision
+	_, err := client.AppsV1().ReplicaSets(testNamespace).Create(&appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            hash,
+			Namespace:       testNamespace,
+			Labels:          map[string]string{"app": deployment, appsv1.DefaultDeploymentUniqueLabelKey: hash},
+			Annotations:     map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment, UID: types.UID(deployment)}},
+		},
+	})
+	assert.NilError(t, err, "Error creating replica set")
+
+	return hash
+}
+
+// createCrashingPod creates a crash looping pod with the given labels
+func createCrashingPod(t *testing.T, client *fake.Clientset, name string, labels map[string]string) {
+	_, err := client.CoreV1().Pods(testNamespace).Create(&k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    labels,
+		},
+		Status: k8sv1.PodStatus{
+			Phase: k8sv1.PodRunning,
+			ContainerStatuses: []k8sv1.ContainerStatus{
+				k8sv1.ContainerStatus{
+					Name: "container",
+					State: k8sv1.ContainerState{
+						Waiting: &k8sv1.ContainerStateWaiting{
+							Reason: "CrashLoopBackOff",
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err, "Error creating pod")
+}
+
+func TestWaitReady(t *testing.T) {
+	pollInterval = time.Millisecond
+	client := fake.NewSimpleClientset()
+	createDeployment(t, client, "ready", 1, 1)
+
+	resources := []*deploy.Resource{
+		&deploy.Resource{Kind: "Service", Name: "ready", Namespace: testNamespace},
+		&deploy.Resource{Kind: "Deployment", Name: "ready", Namespace: testNamespace},
+	}
+
+	err := Wait(client, resources, time.Second, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error waiting for ready deployment")
+}
+
+func TestWaitCrashLoop(t *testing.T) {
+	pollInterval = time.Millisecond
+	client := fake.NewSimpleClientset()
+	createDeployment(t, client, "crashing", 1, 0)
+	hash := createReplicaSet(t, client, "crashing", "2")
+	createCrashingPod(t, client, "crashing-pod", map[string]string{"app": "crashing", appsv1.DefaultDeploymentUniqueLabelKey: hash})
+
+	resources := []*deploy.Resource{
+		&deploy.Resource{Kind: "Deployment", Name: "crashing", Namespace: testNamespace},
+	}
+
+	err := Wait(client, resources, time.Minute, &log.DiscardLogger{})
+	assert.Assert(t, err != nil, "No error waiting for crashing deployment")
+	assert.Assert(t, strings.Contains(err.Error(), "Rollout failed"), "Unexpected error: %v", err)
+	assert.Assert(t, strings.Contains(err.Error(), "crashing-pod"), "Report does not contain the crashing pod: %v", err)
+}
+
+func TestWaitIgnoresOldReplicaSets(t *testing.T) {
+	pollInterval = time.Millisecond
+	client := fake.NewSimpleClientset()
+	createDeployment(t, client, "updating", 1, 0)
+	oldHash := createReplicaSet(t, client, "updating", "1")
+	createReplicaSet(t, client, "updating", "2")
+	createCrashingPod(t, client, "old-pod", map[string]string{"app": "updating", appsv1.DefaultDeploymentUniqueLabelKey: oldHash})
+
+	resources := []*deploy.Resource{
+		&deploy.Resource{Kind: "Deployment", Name: "updating", Namespace: testNamespace},
+	}
+
+	// The crashing pod of the old revision must not fail the rollout
+	err := Wait(client, resources, 10*time.Millisecond, &log.DiscardLogger{})
+	assert.Assert(t, err != nil, "No error waiting for pending deployment")
+	assert.Assert(t, strings.Contains(err.Error(), "timed out"), "Unexpected error: %v", err)
+}
+
+func TestWaitTimeout(t *testing.T) {
+	pollInterval = time.Millisecond
+	client := fake.NewSimpleClientset()
+	createDeployment(t, client, "pending", 0, 0)
+
+	resources := []*deploy.Resource{
+		&deploy.Resource{Kind: "Deployment", Name: "pending", Namespace: testNamespace},
+	}
+
+	err := Wait(client, resources, 10*time.Millisecond, &log.DiscardLogger{})
+	assert.Assert(t, err != nil, "No error waiting for pending deployment")
+	assert.Assert(t, strings.Contains(err.Error(), "timed out"), "Unexpected error: %v", err)
+
+	// Warn only should not return the error
+	err = WaitForDeployment(client, &latest.DeploymentConfig{
+		Name: ptr.String("pending"),
+		Rollout: &latest.RolloutConfig{
+			Timeout:  ptr.Int64(0),
+			WarnOnly: ptr.Bool(true),
+		},
+	}, resources, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error with warnOnly enabled")
+
+	// Disabled should not wait at all
+	err = WaitForDeployment(client, &latest.DeploymentConfig{
+		Name: ptr.String("pending"),
+		Rollout: &latest.RolloutConfig{
+			Disabled: ptr.Bool(true),
+		},
+	}, resources, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error with rollout disabled")
+}
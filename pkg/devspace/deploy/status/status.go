@@ -0,0 +1,288 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DeploymentStatus holds the status of a deployment and of the objects it owns
+type DeploymentStatus struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	Status string `json:"status"`
+
+	Workloads []*WorkloadStatus `json:"workloads"`
+	Pods      []*PodStatus      `json:"pods"`
+	Services  []*ServiceStatus  `json:"services"`
+
+	// Error is set if the status of the objects could not be retrieved
+	Error string `json:"error,omitempty"`
+}
+
+// WorkloadStatus holds the replica status of a deployment, statefulset or daemonset
+type WorkloadStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Ready     int32  `json:"ready"`
+	Desired   int32  `json:"desired"`
+
+	// NotFound is true if the workload does not exist in the cluster
+	NotFound bool `json:"notFound,omitempty"`
+}
+
+// PodStatus holds the status of a pod that belongs to a workload of a deployment
+type PodStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload,omitempty"`
+	Status    string `json:"status"`
+
+	ReadyContainers int   `json:"readyContainers"`
+	TotalContainers int   `json:"totalContainers"`
+	Restarts        int32 `json:"restarts"`
+
+	// LastTermination is the reason why a container of the pod was terminated the last time, e.g. OOMKilled
+	LastTermination string `json:"lastTermination,omitempty"`
+}
+
+// ServiceStatus holds the endpoints of a service of a deployment
+type ServiceStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+	ClusterIP string `json:"clusterIP"`
+
+	// Endpoints are the ready endpoints (ip:port) of the service
+	Endpoints []string `json:"endpoints"`
+
+	// NotReadyEndpoints is the number of endpoints that are not ready
+	NotReadyEndpoints int `json:"notReadyEndpoints,omitempty"`
+
+	// NotFound is true if the service does not exist in the cluster
+	NotFound bool `json:"notFound,omitempty"`
+}
+
+// Get returns the status of the workloads, pods and services of the deployment with the given status result
+func Get(client kubernetes.Interface, result *deploy.StatusResult) *DeploymentStatus {
+	status := &DeploymentStatus{
+		Name:      result.Name,
+		Type:      result.Type,
+		Target:    result.Target,
+		Status:    result.Status,
+		Workloads: []*WorkloadStatus{},
+		Pods:      []*PodStatus{},
+		Services:  []*ServiceStatus{},
+	}
+
+	for _, resource := range result.Resources {
+		var err error
+
+		switch resource.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			err = status.addWorkload(client, resource)
+		case "Pod":
+			err = status.addPod(client, resource)
+		case "Service":
+			err = status.addService(client, resource)
+		}
+
+		if err != nil {
+			status.Error = fmt.Sprintf("Error retrieving status of %s %s: %v", resource.Kind, resource.Name, err)
+			break
+		}
+	}
+
+	return status
+}
+
+func (s *DeploymentStatus) addWorkload(client kubernetes.Interface, resource *deploy.Resource) error {
+	var (
+		workload = &WorkloadStatus{
+			Kind:      resource.Kind,
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+		}
+		selector *metav1.LabelSelector
+		err      error
+	)
+
+	switch resource.Kind {
+	case "Deployment":
+		deployment, getErr := client.AppsV1().Deployments(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if getErr == nil {
+			workload.Ready = deployment.Status.ReadyReplicas
+			workload.Desired = 1
+			if deployment.Spec.Replicas != nil {
+				workload.Desired = *deployment.Spec.Replicas
+			}
+
+			selector = deployment.Spec.Selector
+		}
+
+		err = getErr
+	case "StatefulSet":
+		statefulSet, getErr := client.AppsV1().StatefulSets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if getErr == nil {
+			workload.Ready = statefulSet.Status.ReadyReplicas
+			workload.Desired = 1
+			if statefulSet.Spec.Replicas != nil {
+				workload.Desired = *statefulSet.Spec.Replicas
+			}
+
+			selector = statefulSet.Spec.Selector
+		}
+
+		err = getErr
+	case "DaemonSet":
+		daemonSet, getErr := client.AppsV1().DaemonSets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if getErr == nil {
+			workload.Ready = daemonSet.Status.NumberReady
+			workload.Desired = daemonSet.Status.DesiredNumberScheduled
+			selector = daemonSet.Spec.Selector
+		}
+
+		err = getErr
+	}
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			workload.NotFound = true
+			s.Workloads = append(s.Workloads, workload)
+			return nil
+		}
+
+		return err
+	}
+
+	s.Workloads = append(s.Workloads, workload)
+	if selector == nil {
+		return nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return err
+	}
+
+	podList, err := client.CoreV1().Pods(resource.Namespace).List(metav1.ListOptions{
+		LabelSelector: labelSelector.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+	for i := range podList.Items {
+		pod := getPodStatus(&podList.Items[i])
+		pod.Workload = resource.Name
+
+		s.Pods = append(s.Pods, pod)
+	}
+
+	return nil
+}
+
+func (s *DeploymentStatus) addPod(client kubernetes.Interface, resource *deploy.Resource) error {
+	pod, err := client.CoreV1().Pods(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	s.Pods = append(s.Pods, getPodStatus(pod))
+	return nil
+}
+
+func (s *DeploymentStatus) addService(client kubernetes.Interface, resource *deploy.Resource) error {
+	serviceStatus := &ServiceStatus{
+		Name:      resource.Name,
+		Namespace: resource.Namespace,
+		Endpoints: []string{},
+	}
+
+	service, err := client.CoreV1().Services(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			serviceStatus.NotFound = true
+			s.Services = append(s.Services, serviceStatus)
+			return nil
+		}
+
+		return err
+	}
+
+	serviceStatus.Type = string(service.Spec.Type)
+	serviceStatus.ClusterIP = service.Spec.ClusterIP
+
+	endpoints, err := client.CoreV1().Endpoints(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return err
+	}
+	if err == nil {
+		for _, subset := range endpoints.Subsets {
+			for _, address := range subset.Addresses {
+				for _, port := range subset.Ports {
+					serviceStatus.Endpoints = append(serviceStatus.Endpoints, address.IP+":"+strconv.Itoa(int(port.Port)))
+				}
+			}
+
+			serviceStatus.NotReadyEndpoints += len(subset.NotReadyAddresses)
+		}
+	}
+
+	s.Services = append(s.Services, serviceStatus)
+	return nil
+}
+
+func getPodStatus(pod *k8sv1.Pod) *PodStatus {
+	podStatus := &PodStatus{
+		Name:            pod.Name,
+		Namespace:       pod.Namespace,
+		Status:          kubectl.GetPodStatus(pod),
+		TotalContainers: len(pod.Spec.Containers),
+	}
+
+	var lastTermination *k8sv1.ContainerStateTerminated
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Ready {
+			podStatus.ReadyContainers++
+		}
+
+		podStatus.Restarts += containerStatus.RestartCount
+
+		terminated := containerStatus.LastTerminationState.Terminated
+		if terminated != nil && (lastTermination == nil || terminated.FinishedAt.After(lastTermination.FinishedAt.Time)) {
+			lastTermination = terminated
+		}
+	}
+	if len(pod.Status.ContainerStatuses) > podStatus.TotalContainers {
+		podStatus.TotalContainers = len(pod.Status.ContainerStatuses)
+	}
+
+	if lastTermination != nil {
+		reason := lastTermination.Reason
+		if reason == "" {
+			reason = "Terminated"
+		}
+
+		podStatus.LastTermination = fmt.Sprintf("%s (exit code %d)", reason, lastTermination.ExitCode)
+	}
+
+	return podStatus
+}
@@ -0,0 +1,112 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+func TestGet(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	_, err := kubeClient.AppsV1().Deployments("my-namespace").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-deployment"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "my-app"}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+
+	finishedAt := metav1.NewTime(time.Now())
+	pods := []*k8sv1.Pod{
+		&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Labels: map[string]string{"app": "my-app"}},
+			Spec:       k8sv1.PodSpec{Containers: []k8sv1.Container{k8sv1.Container{Name: "app"}}},
+			Status: k8sv1.PodStatus{
+				Phase: k8sv1.PodRunning,
+				ContainerStatuses: []k8sv1.ContainerStatus{
+					k8sv1.ContainerStatus{
+						Name:         "app",
+						Ready:        true,
+						RestartCount: 3,
+						State:        k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+						LastTerminationState: k8sv1.ContainerState{
+							Terminated: &k8sv1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: finishedAt},
+						},
+					},
+				},
+			},
+		},
+		&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-pod", Labels: map[string]string{"app": "other-app"}},
+		},
+	}
+	for _, pod := range pods {
+		_, err = kubeClient.CoreV1().Pods("my-namespace").Create(pod)
+		assert.NilError(t, err, "Error creating pod")
+	}
+
+	_, err = kubeClient.CoreV1().Services("my-namespace").Create(&k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service"},
+		Spec:       k8sv1.ServiceSpec{Type: k8sv1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+	})
+	assert.NilError(t, err, "Error creating service")
+
+	_, err = kubeClient.CoreV1().Endpoints("my-namespace").Create(&k8sv1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service"},
+		Subsets: []k8sv1.EndpointSubset{
+			k8sv1.EndpointSubset{
+				Addresses:         []k8sv1.EndpointAddress{k8sv1.EndpointAddress{IP: "1.2.3.4"}},
+				NotReadyAddresses: []k8sv1.EndpointAddress{k8sv1.EndpointAddress{IP: "1.2.3.5"}},
+				Ports:             []k8sv1.EndpointPort{k8sv1.EndpointPort{Port: 8080}},
+			},
+		},
+	})
+	assert.NilError(t, err, "Error creating endpoints")
+
+	status := Get(kubeClient, &deploy.StatusResult{
+		Name:   "my-deployment",
+		Type:   "Manifests",
+		Target: "kube/*",
+		Status: "1/1 pods ready",
+		Resources: []*deploy.Resource{
+			&deploy.Resource{Kind: "Deployment", Name: "my-deployment", Namespace: "my-namespace"},
+			&deploy.Resource{Kind: "StatefulSet", Name: "missing", Namespace: "my-namespace"},
+			&deploy.Resource{Kind: "Service", Name: "my-service", Namespace: "my-namespace"},
+			&deploy.Resource{Kind: "ConfigMap", Name: "my-config", Namespace: "my-namespace"},
+		},
+	})
+
+	assert.Equal(t, status.Error, "")
+	assert.Equal(t, status.Name, "my-deployment")
+
+	assert.Equal(t, len(status.Workloads), 2)
+	assert.Equal(t, status.Workloads[0].Ready, int32(1))
+	assert.Equal(t, status.Workloads[0].Desired, int32(2))
+	assert.Equal(t, status.Workloads[1].NotFound, true)
+
+	assert.Equal(t, len(status.Pods), 1)
+	assert.Equal(t, status.Pods[0].Name, "pod-a")
+	assert.Equal(t, status.Pods[0].Workload, "my-deployment")
+	assert.Equal(t, status.Pods[0].Status, "Running")
+	assert.Equal(t, status.Pods[0].ReadyContainers, 1)
+	assert.Equal(t, status.Pods[0].TotalContainers, 1)
+	assert.Equal(t, status.Pods[0].Restarts, int32(3))
+	assert.Equal(t, status.Pods[0].LastTermination, "OOMKilled (exit code 137)")
+
+	assert.Equal(t, len(status.Services), 1)
+	assert.Equal(t, status.Services[0].ClusterIP, "10.0.0.1")
+	assert.DeepEqual(t, status.Services[0].Endpoints, []string{"1.2.3.4:8080"})
+	assert.Equal(t, status.Services[0].NotReadyEndpoints, 1)
+}
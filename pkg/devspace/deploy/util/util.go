@@ -1,7 +1,10 @@
 package deploy
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -10,114 +13,448 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/rollout"
 	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
-	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/graph"
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 )
 
-// All deploys all deployments in the config
-func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, isDev, forceDeploy bool, builtImages map[string]string, deployments []string, log log.Logger) error {
+// deploymentResult is sent by a deployment goroutine when it is done
+type deploymentResult struct {
+	name        string
+	client      deploy.Interface
+	wasDeployed bool
+	output      string
+	err         error
+}
+
+// All deploys all deployments in the config. Deployments are deployed after the deployments they depend on, up to
+// maxConcurrent independent deployments are deployed in parallel. Objects that are not part of the deployments
+// anymore are deleted after confirmation if prune is true, otherwise they are only listed
+func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, isDev, forceDeploy bool, maxConcurrent int, prune bool, builtImages map[string]string, deployments []string, log logpkg.Logger) error {
 	if config.Deployments != nil && len(*config.Deployments) > 0 {
+		deploymentGraph, order, err := createDeploymentGraph(config, deployments)
+		if err != nil {
+			return err
+		}
+		if len(order) == 0 {
+			return nil
+		}
+
+		// Deploy not in parallel when we only have one deployment
+		sequential := maxConcurrent <= 1 || len(order) <= 1
+		if sequential {
+			maxConcurrent = 1
+		}
+
 		// Execute before deployments deploy hook
-		err := hook.Execute(config, hook.Before, hook.StageDeployments, hook.All, log)
+		err = hook.Execute(config, hook.Before, hook.StageDeployments, hook.All, log)
 		if err != nil {
 			return err
 		}
 
-		for _, deployConfig := range *config.Deployments {
-			if len(deployments) > 0 {
-				shouldSkip := true
+		// Create the deployment caches upfront, because the cache map must not be written concurrently
+		for _, node := range order {
+			cache.GetDeploymentCache(node.ID)
+		}
+
+		var (
+			// The deployments that were changed in this run and need to be rolled back if a later deployment fails
+			changed = []*changedDeployment{}
+
+			started    = map[string]bool{}
+			running    = 0
+			resultChan = make(chan deploymentResult)
+			deployErr  error
+		)
 
-				for _, deployment := range deployments {
-					if deployment == strings.TrimSpace(*deployConfig.Name) {
-						shouldSkip = false
+		for {
+			// Start all deployments whose dependencies are deployed
+			if deployErr == nil {
+				for _, node := range order {
+					if running >= maxConcurrent {
 						break
 					}
-				}
+					if started[node.ID] || node.IsLeaf() == false {
+						continue
+					}
 
-				if shouldSkip {
-					continue
+					started[node.ID] = true
+					deployConfig := node.Data.(*latest.DeploymentConfig)
+
+					// Parallel deployments write to a buffer that is printed when the deployment is done
+					deployLog := log
+					buff := &bytes.Buffer{}
+					if sequential == false {
+						deployLog = logpkg.NewStreamLogger(buff, logrus.InfoLevel)
+					}
+
+					deployClient, method, err := newDeployClient(config, client, deployConfig, deployLog)
+					if err != nil {
+						deployErr = fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
+						break
+					}
+
+					go func() {
+						wasDeployed, err := deployOne(config, cache, client, deployConfig, deployClient, method, forceDeploy, builtImages, deployLog)
+						resultChan <- deploymentResult{name: *deployConfig.Name, client: deployClient, wasDeployed: wasDeployed, output: buff.String(), err: err}
+					}()
+
+					running++
 				}
 			}
 
-			var (
-				deployClient deploy.Interface
-				err          error
-				method       string
-			)
+			if running == 0 {
+				break
+			}
 
-			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(config, client, deployConfig, log)
-				if err != nil {
-					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
-				}
+			if sequential == false {
+				log.StartWait(fmt.Sprintf("Deploying %d deployments...", running))
+			}
 
-				method = "kubectl"
-			} else if deployConfig.Helm != nil {
-				deployClient, err = helm.New(config, client, deployConfig, log)
-				if err != nil {
-					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
-				}
+			result := <-resultChan
+			running--
 
-				method = "helm"
-			} else if deployConfig.Component != nil {
-				deployClient, err = component.New(config, client, deployConfig, log)
-				if err != nil {
-					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
+			if sequential == false {
+				log.StopWait()
+				log.WriteString(result.output)
+			}
+			if result.wasDeployed {
+				changed = append(changed, &changedDeployment{
+					Name:   result.name,
+					Client: result.client,
+				})
+			}
+			if result.err != nil {
+				// Wait for the running deployments to finish before rolling back
+				if deployErr == nil {
+					deployErr = result.err
 				}
 
-				method = "component"
-			} else {
-				return fmt.Errorf("Error deploying devspace: deployment %s has no deployment method", *deployConfig.Name)
+				continue
 			}
 
-			// Execute before deploment deploy hook
-			err = hook.Execute(config, hook.Before, hook.StageDeployments, *deployConfig.Name, log)
+			// Deployments that depend on this deployment can be started now
+			err = deploymentGraph.RemoveNode(result.name)
 			if err != nil {
-				return err
+				deployErr = err
 			}
+		}
 
-			wasDeployed, err := deployClient.Deploy(cache, forceDeploy, builtImages)
-			if err != nil {
-				return fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
+		if deployErr != nil {
+			return rollback(changed, cache, deployErr, log)
+		}
+
+		err = pruneOrphans(changed, prune, log)
+		if err != nil {
+			return rollback(changed, cache, err, log)
+		}
+
+		// Execute after deployments deploy hook
+		err = hook.Execute(config, hook.After, hook.StageDeployments, hook.All, log)
+		if err != nil {
+			return rollback(changed, cache, err, log)
+		}
+	}
+
+	return nil
+}
+
+// deployOne deploys a single deployment and waits for its rollout
+func deployOne(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployConfig *latest.DeploymentConfig, deployClient deploy.Interface, method string, forceDeploy bool, builtImages map[string]string, log logpkg.Logger) (bool, error) {
+	// Execute before deploment deploy hook
+	err := hook.Execute(config, hook.Before, hook.StageDeployments, *deployConfig.Name, log)
+	if err != nil {
+		return false, err
+	}
+
+	// A deployment that fails partway might have changed objects already, so it is rolled back as well
+	wasDeployed, err := deployClient.Deploy(cache, forceDeploy, builtImages)
+	if err != nil {
+		return true, fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
+	}
+	if wasDeployed == false {
+		log.Infof("Skipping deployment %s", *deployConfig.Name)
+		return false, nil
+	}
+
+	// Wait until the deployed workloads are ready
+	err = rollout.WaitForDeployment(client, deployConfig, deployClient.Resources(), log)
+	if err != nil {
+		return true, fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
+	}
+
+	log.Donef("Successfully deployed %s with %s", *deployConfig.Name, method)
+
+	// Execute after deploment deploy hook
+	err = hook.Execute(config, hook.After, hook.StageDeployments, *deployConfig.Name, log)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// newDeployClient creates the deploy client for the deployment method of the given deployment
+func newDeployClient(config *latest.Config, client kubernetes.Interface, deployConfig *latest.DeploymentConfig, log logpkg.Logger) (deploy.Interface, string, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(config, client, deployConfig, log)
+		return deployClient, "kubectl", err
+	} else if deployConfig.Template != nil {
+		deployClient, err := kubectl.New(config, client, deployConfig, log)
+		return deployClient, "template", err
+	} else if deployConfig.Helm != nil {
+		deployClient, err := helm.New(config, client, deployConfig, log)
+		return deployClient, "helm", err
+	} else if deployConfig.Component != nil {
+		deployClient, err := component.New(config, client, deployConfig, log)
+		return deployClient, "component", err
+	}
+
+	return nil, "", errors.New("deployment has no deployment method")
+}
+
+// createDeploymentGraph creates a graph of the selected deployments where a deployment is a parent of the deployments it depends on.
+// It also returns the nodes in the order they are defined in the config. Dependencies on deployments that are not selected are ignored
+func createDeploymentGraph(config *latest.Config, deployments []string) (*graph.Graph, []*graph.Node, error) {
+	deploymentGraph := graph.NewGraph(graph.NewNode("", nil))
+	order := []*graph.Node{}
+
+	// Check if all selected deployments exist
+	for _, deployment := range deployments {
+		found := false
+		for _, deployConfig := range *config.Deployments {
+			if deployment == strings.TrimSpace(*deployConfig.Name) {
+				found = true
+				break
 			}
+		}
+		if found == false {
+			return nil, nil, fmt.Errorf("Deployment %s does not exist", deployment)
+		}
+	}
 
-			if wasDeployed {
-				log.Donef("Successfully deployed %s with %s", *deployConfig.Name, method)
+	for _, deployConfig := range *config.Deployments {
+		if len(deployments) > 0 {
+			shouldSkip := true
 
-				// Execute after deploment deploy hook
-				err = hook.Execute(config, hook.After, hook.StageDeployments, *deployConfig.Name, log)
-				if err != nil {
-					return err
+			for _, deployment := range deployments {
+				if deployment == strings.TrimSpace(*deployConfig.Name) {
+					shouldSkip = false
+					break
+				}
+			}
+
+			if shouldSkip {
+				continue
+			}
+		}
+
+		if _, ok := deploymentGraph.Nodes[*deployConfig.Name]; ok {
+			return nil, nil, fmt.Errorf("Deployment %s is defined twice", *deployConfig.Name)
+		}
+
+		node, err := deploymentGraph.InsertNodeAt("", *deployConfig.Name, deployConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		order = append(order, node)
+	}
+
+	for _, node := range order {
+		deployConfig := node.Data.(*latest.DeploymentConfig)
+		if deployConfig.DependsOn == nil {
+			continue
+		}
+
+		for _, dependsOn := range *deployConfig.DependsOn {
+			if dependsOn == nil {
+				continue
+			}
+
+			exists := false
+			for _, otherConfig := range *config.Deployments {
+				if *otherConfig.Name == *dependsOn {
+					exists = true
+					break
+				}
+			}
+			if exists == false {
+				return nil, nil, fmt.Errorf("Deployment %s depends on deployment %s, which does not exist", *deployConfig.Name, *dependsOn)
+			}
+
+			// Skip dependencies that are not deployed in this run
+			if _, ok := deploymentGraph.Nodes[*dependsOn]; ok == false {
+				continue
+			}
+
+			err := deploymentGraph.AddEdge(node.ID, *dependsOn)
+			if err != nil {
+				if _, ok := err.(*graph.CyclicError); ok {
+					return nil, nil, fmt.Errorf("Cyclic dependsOn between deployments: %v", err)
 				}
-			} else {
-				log.Infof("Skipping deployment %s", *deployConfig.Name)
+
+				return nil, nil, err
 			}
 		}
+	}
 
-		// Execute after deployments deploy hook
-		err = hook.Execute(config, hook.After, hook.StageDeployments, hook.All, log)
+	return deploymentGraph, order, nil
+}
+
+// changedDeployment is a deployment that was deployed during the current run
+type changedDeployment struct {
+	Name   string
+	Client deploy.Interface
+}
+
+// rollback rolls back the changed deployments in reverse order and returns the original error together with a summary of the rollback
+func rollback(changed []*changedDeployment, cache *generated.CacheConfig, deployErr error, log logpkg.Logger) error {
+	if len(changed) == 0 {
+		return deployErr
+	}
+
+	log.Warnf("Deployment failed, rolling back %d deployment(s) that were changed during this deploy", len(changed))
+
+	restored := []string{}
+	failed := []string{}
+
+	for i := len(changed) - 1; i >= 0; i-- {
+		err := changed[i].Client.Rollback(cache)
 		if err != nil {
-			return err
+			log.Warnf("Error rolling back deployment %s: %v", changed[i].Name, err)
+			failed = append(failed, changed[i].Name)
+			continue
+		}
+
+		restored = append(restored, changed[i].Name)
+	}
+
+	message := deployErr.Error()
+	if len(restored) > 0 {
+		message += fmt.Sprintf("\nRolled back deployment(s): %s", strings.Join(restored, ", "))
+	}
+	if len(failed) > 0 {
+		message += fmt.Sprintf("\nUnable to roll back deployment(s): %s", strings.Join(failed, ", "))
+	}
+
+	return errors.New(message)
+}
+
+// pruneOrphans lists the objects that were deployed before, but are not part of the changed deployments anymore.
+// If prune is true, the objects are deleted after confirmation
+func pruneOrphans(changed []*changedDeployment, prune bool, log logpkg.Logger) error {
+	orphans := map[string][]*deploy.Resource{}
+	orphanList := []string{}
+
+	for _, deployment := range changed {
+		pruner, ok := deployment.Client.(deploy.Pruner)
+		if ok == false {
+			continue
+		}
+
+		resources, err := pruner.Orphans()
+		if err != nil {
+			log.Warnf("Error searching for orphaned objects of deployment %s: %v", deployment.Name, err)
+			continue
+		}
+		if len(resources) == 0 {
+			continue
+		}
+
+		orphans[deployment.Name] = resources
+		for _, resource := range resources {
+			orphanList = append(orphanList, fmt.Sprintf("- %s %s (deployment %s)", resource.Kind, path.Join(resource.Namespace, resource.Name), deployment.Name))
+		}
+	}
+	if len(orphanList) == 0 {
+		return nil
+	}
+
+	if prune == false {
+		log.Warnf("The following objects were deployed before, but are not part of the deployments anymore:\n%s\nRun with --prune to delete them", strings.Join(orphanList, "\n"))
+		return nil
+	}
+
+	log.Infof("The following objects were deployed before, but are not part of the deployments anymore:\n%s", strings.Join(orphanList, "\n"))
+	shouldPrune, err := survey.Question(&survey.QuestionOptions{
+		Question:     fmt.Sprintf("Do you want to delete these %d objects?", len(orphanList)),
+		DefaultValue: "no",
+		Options: []string{
+			"no",
+			"yes",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if shouldPrune != "yes" {
+		return nil
+	}
+
+	for _, deployment := range changed {
+		if resources, ok := orphans[deployment.Name]; ok {
+			err := deployment.Client.(deploy.Pruner).Prune(resources)
+			if err != nil {
+				return err
+			}
+
+			log.Donef("Deleted %d orphaned objects of deployment %s", len(resources), deployment.Name)
 		}
 	}
 
 	return nil
 }
 
+// getDeploymentOrder returns all deployments in the order they are deployed, i.e. every deployment is placed after the deployments it depends on
+func getDeploymentOrder(config *latest.Config) ([]*latest.DeploymentConfig, error) {
+	deploymentGraph, nodes, err := createDeploymentGraph(config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deployOrder := []*latest.DeploymentConfig{}
+	for len(deployOrder) < len(nodes) {
+		for _, node := range nodes {
+			if _, ok := deploymentGraph.Nodes[node.ID]; ok == false || node.IsLeaf() == false {
+				continue
+			}
+
+			err = deploymentGraph.RemoveNode(node.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			deployOrder = append(deployOrder, node.Data.(*latest.DeploymentConfig))
+			break
+		}
+	}
+
+	return deployOrder, nil
+}
+
 // PurgeDeployments removes all deployments or a set of deployments from the cluster
-func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployments []string, log log.Logger) {
+func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployments []string, log logpkg.Logger) {
 	if deployments != nil && len(deployments) == 0 {
 		deployments = nil
 	}
 
 	if config.Deployments != nil {
-		// Reverse them
-		for i := len(*config.Deployments) - 1; i >= 0; i-- {
+		deployOrder, err := getDeploymentOrder(config)
+		if err != nil {
+			log.Warnf("Unable to determine the deployment order: %v", err)
+			deployOrder = *config.Deployments
+		}
+
+		// Reverse them, so that deployments are deleted before the deployments they depend on
+		for i := len(deployOrder) - 1; i >= 0; i-- {
 			var (
-				err          error
 				deployClient deploy.Interface
-				deployConfig = (*config.Deployments)[i]
+				deployConfig = deployOrder[i]
 			)
 
 			// Check if we should skip deleting deployment
@@ -137,7 +474,7 @@ func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, clien
 			}
 
 			// Delete kubectl engine
-			if deployConfig.Kubectl != nil {
+			if deployConfig.Kubectl != nil || deployConfig.Template != nil {
 				deployClient, err = kubectl.New(config, client, deployConfig, log)
 				if err != nil {
 					log.Warnf("Unable to create kubectl deploy config: %v", err)
@@ -1,22 +1,26 @@
 package deploy 
 
 import (
+	"errors"
 	"testing"
 	"os"
 	"io/ioutil"
+	"strings"
 	
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	
 	"k8s.io/client-go/kubernetes/fake"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	//"gotest.tools/assert"
+	"gotest.tools/assert"
 )
 
 // Test namespace to create
@@ -118,7 +122,7 @@ func TestHelmDeployment(t *testing.T) {
 	}
 
 	// 4. Deploy
-	err = All(testConfig, cache, kubeClient, true, true, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
+	err = All(testConfig, cache, kubeClient, true, true, 1, false, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
 	if err != nil {
 		t.Fatalf("Error deploying all: %v", err)
 	}
@@ -133,7 +137,7 @@ func TestHelmDeployment(t *testing.T) {
 			Kubectl: &latest.KubectlConfig{},
 		},
 	}
-	err = All(testConfig, cache, kubeClient, true, true, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
+	err = All(testConfig, cache, kubeClient, true, true, 1, false, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
 	if err == nil {
 		t.Fatal("No Error deploying with an invalid Kubectl in deployment config.")
 	}
@@ -143,7 +147,7 @@ func TestHelmDeployment(t *testing.T) {
 			Name: ptr.String("test-deployment"),
 		},
 	}
-	err = All(testConfig, cache, kubeClient, true, true, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
+	err = All(testConfig, cache, kubeClient, true, true, 1, false, map[string]string{"default": "nginx"}, []string{"test-deployment"}, &log.DiscardLogger{})
 	if err == nil {
 		t.Fatal("No Error deploying with no deployClient in deployment conig.")
 	}
@@ -262,6 +266,154 @@ func TestPurgeDeployments(t *testing.T) {
 
 }
 
+type fakeDeployment struct {
+	name        string
+	deployErr   error
+	rollbackErr error
+	rolledBack  *[]string
+}
+
+func (f *fakeDeployment) Status() (*deploy.StatusResult, error) {
+	return &deploy.StatusResult{Name: f.name}, nil
+}
+
+func (f *fakeDeployment) Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
+	if f.deployErr != nil {
+		return false, f.deployErr
+	}
+
+	return true, nil
+}
+
+func (f *fakeDeployment) Delete(cache *generated.CacheConfig) error {
+	return nil
+}
+
+func (f *fakeDeployment) Rollback(cache *generated.CacheConfig) error {
+	if f.rollbackErr != nil {
+		return f.rollbackErr
+	}
+
+	*f.rolledBack = append(*f.rolledBack, f.name)
+	return nil
+}
+
+func (f *fakeDeployment) Resources() []*deploy.Resource {
+	return nil
+}
+
+func TestRollback(t *testing.T) {
+	rolledBack := []string{}
+	changed := []*changedDeployment{
+		&changedDeployment{Name: "first", Client: &fakeDeployment{name: "first", rolledBack: &rolledBack}},
+		&changedDeployment{Name: "second", Client: &fakeDeployment{name: "second", rollbackErr: errors.New("rollback failed"), rolledBack: &rolledBack}},
+		&changedDeployment{Name: "third", Client: &fakeDeployment{name: "third", rolledBack: &rolledBack}},
+	}
+
+	err := rollback(changed, generated.NewCache(), errors.New("deploy failed"), &log.DiscardLogger{})
+	if err == nil {
+		t.Fatal("No error returned after rollback")
+	}
+
+	// Deployments have to be rolled back in reverse order
+	assert.DeepEqual(t, rolledBack, []string{"third", "first"})
+	assert.Assert(t, strings.HasPrefix(err.Error(), "deploy failed"), "Original error missing: %v", err)
+	assert.Assert(t, strings.Contains(err.Error(), "Rolled back deployment(s): third, first"), "Restored deployments missing: %v", err)
+	assert.Assert(t, strings.Contains(err.Error(), "Unable to roll back deployment(s): second"), "Failed deployments missing: %v", err)
+
+	// Without changed deployments the original error is returned
+	err = rollback(nil, generated.NewCache(), errors.New("deploy failed"), &log.DiscardLogger{})
+	assert.Error(t, err, "deploy failed")
+}
+
+func TestDeployOnePartialFailure(t *testing.T) {
+	deployConfig := &latest.DeploymentConfig{Name: ptr.String("failing")}
+	deployClient := &fakeDeployment{name: "failing", deployErr: errors.New("apply failed")}
+
+	// A deployment that fails partway has to be rolled back as well
+	wasDeployed, err := deployOne(&latest.Config{}, generated.NewCache(), nil, deployConfig, deployClient, "kubectl", false, nil, &log.DiscardLogger{})
+	assert.Error(t, err, "Error deploying failing: apply failed")
+	assert.Equal(t, wasDeployed, true)
+}
+
+type fakePruner struct {
+	fakeDeployment
+
+	orphans []*deploy.Resource
+	pruned  *[]*deploy.Resource
+}
+
+func (f *fakePruner) Orphans() ([]*deploy.Resource, error) {
+	return f.orphans, nil
+}
+
+func (f *fakePruner) Prune(resources []*deploy.Resource) error {
+	*f.pruned = append(*f.pruned, resources...)
+	return nil
+}
+
+func TestPruneOrphans(t *testing.T) {
+	pruned := []*deploy.Resource{}
+	orphan := &deploy.Resource{Kind: "Deployment", Name: "old-app", Namespace: "default"}
+	changed := []*changedDeployment{
+		&changedDeployment{Name: "helm", Client: &fakeDeployment{name: "helm"}},
+		&changedDeployment{Name: "kubectl", Client: &fakePruner{orphans: []*deploy.Resource{orphan}, pruned: &pruned}},
+		&changedDeployment{Name: "unchanged", Client: &fakePruner{pruned: &pruned}},
+	}
+
+	// Without prune the orphans are only listed
+	err := pruneOrphans(changed, false, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error listing orphans")
+	assert.Equal(t, len(pruned), 0)
+
+	// The orphans are only deleted after confirmation
+	survey.SetNextAnswer("no")
+	err = pruneOrphans(changed, true, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error pruning orphans")
+	assert.Equal(t, len(pruned), 0)
+
+	survey.SetNextAnswer("yes")
+	err = pruneOrphans(changed, true, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error pruning orphans")
+	assert.DeepEqual(t, pruned, []*deploy.Resource{orphan})
+}
+
+func TestDeploymentOrder(t *testing.T) {
+	config := &latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{Name: ptr.String("app"), DependsOn: &[]*string{ptr.String("api")}},
+			&latest.DeploymentConfig{Name: ptr.String("api"), DependsOn: &[]*string{ptr.String("database"), ptr.String("cache")}},
+			&latest.DeploymentConfig{Name: ptr.String("database")},
+			&latest.DeploymentConfig{Name: ptr.String("cache")},
+		},
+	}
+
+	deployOrder, err := getDeploymentOrder(config)
+	assert.NilError(t, err, "Error getting deployment order")
+
+	names := []string{}
+	for _, deployConfig := range deployOrder {
+		names = append(names, *deployConfig.Name)
+	}
+	assert.DeepEqual(t, names, []string{"database", "cache", "api", "app"})
+
+	// Dependencies that are not selected are ignored
+	deploymentGraph, nodes, err := createDeploymentGraph(config, []string{"app", "database"})
+	assert.NilError(t, err, "Error creating deployment graph")
+	assert.Equal(t, len(nodes), 2)
+	assert.Equal(t, deploymentGraph.Nodes["app"].IsLeaf(), true)
+
+	// Unknown dependencies
+	(*config.Deployments)[2].DependsOn = &[]*string{ptr.String("unknown")}
+	_, _, err = createDeploymentGraph(config, nil)
+	assert.Error(t, err, "Deployment database depends on deployment unknown, which does not exist")
+
+	// Cyclic dependencies
+	(*config.Deployments)[2].DependsOn = &[]*string{ptr.String("app")}
+	_, _, err = createDeploymentGraph(config, nil)
+	assert.Assert(t, err != nil && strings.HasPrefix(err.Error(), "Cyclic dependsOn"), "No cyclic error: %v", err)
+}
+
 func makeTestProject(dir string) error {
 	file, err := os.Create("package.json")
 	if err != nil {
@@ -68,7 +68,10 @@ func (c *ComponentSchema) varReplaceFn(path, value string) (interface{}, error)
 		}
 
 		// Fill c.VariableValues[varName]
-		c.askQuestion(variable)
+		err := c.askQuestion(variable)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	retValue := matched[1] + c.VariableValues[varName] + matched[3]
@@ -84,7 +87,7 @@ func (c *ComponentSchema) varReplaceFn(path, value string) (interface{}, error)
 }
 
 // askQuestion asks the user a question depending on the variable options
-func (c *ComponentSchema) askQuestion(variable *configs.Variable) {
+func (c *ComponentSchema) askQuestion(variable *configs.Variable) error {
 	params := &survey.QuestionOptions{}
 
 	if variable == nil {
@@ -115,7 +118,13 @@ func (c *ComponentSchema) askQuestion(variable *configs.Variable) {
 		}
 	}
 
-	c.VariableValues[*variable.Name] = survey.Question(params)
+	value, err := survey.Question(params)
+	if err != nil {
+		return err
+	}
+
+	c.VariableValues[*variable.Name] = value
+	return nil
 }
 
 // NewComponentGenerator creates a new component generator for the given path
@@ -191,7 +200,10 @@ func (cg *ComponentsGenerator) GetComponentTemplate(name string) (*latest.Compon
 
 	// Ask questions
 	for _, variable := range component.Variables {
-		component.askQuestion(&variable)
+		err = component.askQuestion(&variable)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Check if component exists
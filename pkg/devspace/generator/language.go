@@ -74,11 +74,14 @@ func ContainerizeApplication(dockerfilePath, localPath string, templateRepoURL s
 	log.StopWait()
 
 	// Let the user select the language
-	selectedLanguage := survey.Question(&survey.QuestionOptions{
+	selectedLanguage, err := survey.Question(&survey.QuestionOptions{
 		Question:     "Select programming language of project",
 		DefaultValue: detectedLang,
 		Options:      supportedLanguages,
 	})
+	if err != nil {
+		return err
+	}
 
 	return dockerfileGenerator.CreateDockerfile(selectedLanguage)
 }
@@ -33,6 +33,7 @@ import (
 type Interface interface {
 	InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error)
 	DeleteRelease(releaseName string, purge bool) (*rls.UninstallReleaseResponse, error)
+	RollbackRelease(releaseName string, revision int32) (*hapi_release5.Release, error)
 	ListReleases() (*rls.ListReleasesResponse, error)
 }
 
@@ -240,6 +241,16 @@ func (client *Client) DeleteRelease(releaseName string, purge bool) (*rls.Uninst
 	return client.helm.DeleteRelease(releaseName, k8shelm.DeletePurge(purge))
 }
 
+// RollbackRelease rolls back a helm release to the given revision
+func (client *Client) RollbackRelease(releaseName string, revision int32) (*hapi_release5.Release, error) {
+	response, err := client.helm.RollbackRelease(releaseName, k8shelm.RollbackVersion(revision), k8shelm.RollbackTimeout(DeploymentTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	return response.GetRelease(), nil
+}
+
 // ListReleases lists all helm releases
 func (client *Client) ListReleases() (*rls.ListReleasesResponse, error) {
 	return client.helm.ListReleases()
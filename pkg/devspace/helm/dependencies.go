@@ -0,0 +1,227 @@
+package helm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+
+	yaml "gopkg.in/yaml.v2"
+	helmchartutil "k8s.io/helm/pkg/chartutil"
+	helmdownloader "k8s.io/helm/pkg/downloader"
+	"k8s.io/helm/pkg/getter"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// ChartDependencyFolder is the folder where the fetched dependencies of local charts are cached
+var ChartDependencyFolder = ".devspace/charts/dependencies"
+
+// getMissingDependencies returns the names of the dependencies in requirements.yaml that are not in the charts/ directory
+func getMissingDependencies(ch *chart.Chart, reqs *helmchartutil.Requirements) []string {
+	missing := []string{}
+
+	deps := ch.GetDependencies()
+	for _, r := range reqs.Dependencies {
+		found := false
+		for _, d := range deps {
+			if d.Metadata.Name == r.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, r.Name)
+		}
+	}
+
+	return missing
+}
+
+// buildDependencies does the same as `helm dependency build` for the dependencies that are missing in the charts/ directory of the chart.
+// Instead of writing into the chart directory, the fetched subcharts are cached in the ChartDependencyFolder and added to the loaded chart
+func (client *Client) buildDependencies(ch *chart.Chart, chartPath string, reqs *helmchartutil.Requirements) error {
+	missing := getMissingDependencies(ch, reqs)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	absChartPath, err := filepath.Abs(chartPath)
+	if err != nil {
+		return err
+	}
+
+	requirements, err := ioutil.ReadFile(filepath.Join(absChartPath, "requirements.yaml"))
+	if err != nil {
+		return err
+	}
+
+	// The lock file is optional
+	lock, _ := ioutil.ReadFile(filepath.Join(absChartPath, "requirements.lock"))
+
+	// Local dependencies are part of the cache key, because they can change without changing the requirements
+	localDependenciesHash, err := hashLocalDependencies(requirements, absChartPath)
+	if err != nil {
+		return err
+	}
+
+	cacheDir := filepath.Join(ChartDependencyFolder, hash.String(absChartPath+string(requirements)+string(lock)+localDependenciesHash))
+	if _, err := os.Stat(filepath.Join(cacheDir, "charts")); os.IsNotExist(err) {
+		err = client.fetchDependencies(absChartPath, cacheDir, requirements, lock)
+		if err != nil {
+			os.RemoveAll(cacheDir)
+			return errors.Wrap(err, "build chart dependencies")
+		}
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(cacheDir, "charts"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		subchart, err := helmchartutil.Load(filepath.Join(cacheDir, "charts", file.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "load chart dependency %s", file.Name())
+		}
+
+		for _, name := range missing {
+			if subchart.Metadata.Name == name {
+				ch.Dependencies = append(ch.Dependencies, subchart)
+				break
+			}
+		}
+	}
+
+	stillMissing := getMissingDependencies(ch, reqs)
+	if len(stillMissing) > 0 {
+		return fmt.Errorf("found in requirements.yaml, but unable to fetch: %s", strings.Join(stillMissing, ", "))
+	}
+
+	return nil
+}
+
+// fetchDependencies creates a chart in the cache dir that only contains the requirements of the original chart and fetches them
+func (client *Client) fetchDependencies(absChartPath, cacheDir string, requirements, lock []byte) error {
+	err := os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	chartYaml, err := ioutil.ReadFile(filepath.Join(absChartPath, "Chart.yaml"))
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(cacheDir, "Chart.yaml"), chartYaml, 0644)
+	if err != nil {
+		return err
+	}
+
+	// Local dependencies are relative to the original chart
+	rewritten, changed, err := makeLocalRepositoriesAbsolute(requirements, absChartPath)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(cacheDir, "requirements.yaml"), rewritten, 0644)
+	if err != nil {
+		return err
+	}
+
+	// The lock file does not match the rewritten requirements anymore, so we resolve the requirements again in this case
+	if len(lock) > 0 && changed == false {
+		err = ioutil.WriteFile(filepath.Join(cacheDir, "requirements.lock"), lock, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	man := &helmdownloader.Manager{
+		Out:       ioutil.Discard,
+		ChartPath: cacheDir,
+		HelmHome:  client.Settings.Home,
+		Getters:   getter.All(*client.Settings),
+	}
+
+	return man.Build()
+}
+
+// hashLocalDependencies returns a hash of the directories of all file:// dependencies in the requirements
+func hashLocalDependencies(requirements []byte, absChartPath string) (string, error) {
+	parsed := map[interface{}]interface{}{}
+	err := yaml.Unmarshal(requirements, parsed)
+	if err != nil {
+		return "", errors.Wrap(err, "parse requirements.yaml")
+	}
+
+	hashes := ""
+	dependencies, _ := parsed["dependencies"].([]interface{})
+	for _, dependency := range dependencies {
+		dependencyMap, ok := dependency.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		repository, _ := dependencyMap["repository"].(string)
+		if strings.HasPrefix(repository, "file://") == false {
+			continue
+		}
+
+		path := strings.TrimPrefix(repository, "file://")
+		if filepath.IsAbs(path) == false {
+			path = filepath.Join(absChartPath, path)
+		}
+
+		dependencyHash, err := hash.Directory(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "hash local dependency %s", repository)
+		}
+
+		hashes += dependencyHash
+	}
+
+	return hashes, nil
+}
+
+// makeLocalRepositoriesAbsolute rewrites relative file:// repositories in the requirements to absolute paths
+func makeLocalRepositoriesAbsolute(requirements []byte, absChartPath string) ([]byte, bool, error) {
+	parsed := map[interface{}]interface{}{}
+	err := yaml.Unmarshal(requirements, parsed)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parse requirements.yaml")
+	}
+
+	changed := false
+	dependencies, _ := parsed["dependencies"].([]interface{})
+	for _, dependency := range dependencies {
+		dependencyMap, ok := dependency.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		repository, _ := dependencyMap["repository"].(string)
+		if strings.HasPrefix(repository, "file://") == false {
+			continue
+		}
+
+		path := strings.TrimPrefix(repository, "file://")
+		if filepath.IsAbs(path) == false {
+			dependencyMap["repository"] = "file://" + filepath.ToSlash(filepath.Join(absChartPath, path))
+			changed = true
+		}
+	}
+	if changed == false {
+		return requirements, false, nil
+	}
+
+	rewritten, err := yaml.Marshal(parsed)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rewritten, true, nil
+}
@@ -0,0 +1,83 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	helmchartutil "k8s.io/helm/pkg/chartutil"
+	helmenvironment "k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/helm/helmpath"
+)
+
+func TestBuildDependencies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-chart-dependencies")
+	assert.NilError(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	oldChartDependencyFolder := ChartDependencyFolder
+	ChartDependencyFolder = filepath.Join(dir, "cache")
+	defer func() { ChartDependencyFolder = oldChartDependencyFolder }()
+
+	files := map[string]string{
+		"helm/repository/repositories.yaml": "apiVersion: v1\nrepositories: []\n",
+		"parent/Chart.yaml":                 "name: parent\nversion: 0.1.0\n",
+		"parent/requirements.yaml":          "dependencies:\n- name: child\n  version: 0.1.0\n  repository: file://../child\n",
+		"child/Chart.yaml":                  "name: child\nversion: 0.1.0\n",
+	}
+	for name, content := range files {
+		err = os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		assert.NilError(t, err, "Error creating directory for %s", name)
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err, "Error writing %s", name)
+	}
+
+	client := &Client{
+		Settings: &helmenvironment.EnvSettings{
+			Home: helmpath.Home(filepath.Join(dir, "helm")),
+		},
+	}
+
+	chartPath := filepath.Join(dir, "parent")
+	for i := 0; i < 3; i++ {
+		ch, err := helmchartutil.Load(chartPath)
+		assert.NilError(t, err, "Error loading chart")
+		reqs, err := helmchartutil.LoadRequirements(ch)
+		assert.NilError(t, err, "Error loading requirements")
+
+		err = client.buildDependencies(ch, chartPath, reqs)
+		assert.NilError(t, err, "Error building dependencies")
+		assert.Equal(t, len(ch.Dependencies), 1)
+		assert.Equal(t, ch.Dependencies[0].Metadata.Name, "child")
+
+		// The second build has to use the cached dependencies, the third one has to fetch the changed local dependency again
+		cached, err := ioutil.ReadDir(ChartDependencyFolder)
+		assert.NilError(t, err, "Error reading cache folder")
+		assert.Equal(t, len(cached), 1+i/2)
+
+		if i == 1 {
+			err = ioutil.WriteFile(filepath.Join(dir, "child", "values.yaml"), []byte("replicas: 2\n"), 0644)
+			assert.NilError(t, err, "Error changing child chart")
+		}
+	}
+
+	// The chart directory must not be changed
+	_, err = os.Stat(filepath.Join(chartPath, "charts"))
+	assert.Assert(t, os.IsNotExist(err), "charts/ directory was created in the chart")
+}
+
+func TestMakeLocalRepositoriesAbsolute(t *testing.T) {
+	requirements := []byte("dependencies:\n- name: remote\n  repository: https://charts.test\n")
+	rewritten, changed, err := makeLocalRepositoriesAbsolute(requirements, "/charts/parent")
+	assert.NilError(t, err, "Error rewriting requirements")
+	assert.Equal(t, changed, false)
+	assert.Equal(t, string(rewritten), string(requirements))
+
+	requirements = []byte("dependencies:\n- name: local\n  repository: file://../child\n")
+	rewritten, changed, err = makeLocalRepositoriesAbsolute(requirements, "/charts/parent")
+	assert.NilError(t, err, "Error rewriting requirements")
+	assert.Equal(t, changed, true)
+	assert.Equal(t, string(rewritten), "dependencies:\n- name: local\n  repository: file:///charts/child\n")
+}
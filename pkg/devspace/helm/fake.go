@@ -36,6 +36,16 @@ func (f *FakeClient) DeleteRelease(releaseName string, purge bool) (*rls.Uninsta
 	return f.helm.DeleteRelease(releaseName, k8shelm.DeletePurge(purge))
 }
 
+// RollbackRelease rolls back a helm release to the given revision
+func (f *FakeClient) RollbackRelease(releaseName string, revision int32) (*hapi_release5.Release, error) {
+	response, err := f.helm.RollbackRelease(releaseName, k8shelm.RollbackVersion(revision))
+	if err != nil {
+		return nil, err
+	}
+
+	return response.GetRelease(), nil
+}
+
 // ListReleases lists all helm releases
 func (f *FakeClient) ListReleases() (*rls.ListReleasesResponse, error) {
 	return f.helm.ListReleases()
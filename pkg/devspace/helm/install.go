@@ -2,7 +2,7 @@ package helm
 
 import (
 	"fmt"
-	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
@@ -16,8 +16,6 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 	helmchartutil "k8s.io/helm/pkg/chartutil"
-	helmdownloader "k8s.io/helm/pkg/downloader"
-	"k8s.io/helm/pkg/getter"
 	k8shelm "k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
@@ -27,22 +25,7 @@ import (
 const DeploymentTimeout = int64(180)
 
 func checkDependencies(ch *chart.Chart, reqs *helmchartutil.Requirements) error {
-	missing := []string{}
-
-	deps := ch.GetDependencies()
-	for _, r := range reqs.Dependencies {
-		found := false
-		for _, d := range deps {
-			if d.Metadata.Name == r.Name {
-				found = true
-				break
-			}
-		}
-		if !found {
-			missing = append(missing, r.Name)
-		}
-	}
-
+	missing := getMissingDependencies(ch, reqs)
 	if len(missing) > 0 {
 		return fmt.Errorf("found in requirements.yaml, but missing in charts/ directory: %s", strings.Join(missing, ", "))
 	}
@@ -67,25 +50,15 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 	}
 
 	if req, err := helmchartutil.LoadRequirements(chart); err == nil {
-		// If checkDependencies returns an error, we have unfulfilled dependencies.
-		// As of Helm 2.4.0, this is treated as a stopping condition:
-		// https://github.com/kubernetes/helm/issues/2209
-		if err := checkDependencies(chart, req); err != nil {
-			man := &helmdownloader.Manager{
-				Out:       ioutil.Discard,
-				ChartPath: chartPath,
-				HelmHome:  client.Settings.Home,
-				Getters:   getter.All(*client.Settings),
-			}
-			if err := man.Update(); err != nil {
-				return nil, err
-			}
-
-			// Update all dependencies which are present in /charts.
-			chart, err = helmchartutil.Load(chartPath)
+		// Local charts get their missing dependencies fetched like with `helm dependency build`,
+		// packaged charts have to contain all of their dependencies
+		if stat, err := os.Stat(chartPath); err == nil && stat.IsDir() {
+			err = client.buildDependencies(chart, chartPath, req)
 			if err != nil {
 				return nil, err
 			}
+		} else if err := checkDependencies(chart, req); err != nil {
+			return nil, err
 		}
 	} else if err != helmchartutil.ErrRequirementsNotFound {
 		return nil, fmt.Errorf("cannot load requirements: %v", err)
@@ -120,9 +93,9 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 	}
 
 	if releaseExists {
-		upgradeResponse, err := client.helm.UpdateRelease(
+		upgradeResponse, err := client.helm.UpdateReleaseFromChart(
 			releaseName,
-			chartPath,
+			chart,
 			k8shelm.UpgradeWait(wait),
 			k8shelm.UpgradeTimeout(waitTimeout),
 			k8shelm.UpdateValueOverrides(overwriteValues),
@@ -200,6 +173,23 @@ func (client *Client) analyzeError(srcErr error, releaseNamespace string) error
 // InstallChart installs the given chart by name under the releasename in the releasenamespace
 func (client *Client) InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error) {
 	chart := helmConfig.Chart
+
+	// Charts from OCI registries are pulled by devspace, because helm does not support them
+	if strings.HasPrefix(ptr.ReverseString(chart.Name), OCIPrefix) {
+		registryURL, repository, err := parseOCIChartName(*chart.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		authConfig := client.getOCIAuthConfig(registryURL, ptr.ReverseString(chart.Username), ptr.ReverseString(chart.Password))
+		chartPath, err := pullOCIChart(registryURL, repository, ptr.ReverseString(chart.Version), authConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "pull chart")
+		}
+
+		return client.InstallChartByPath(releaseName, releaseNamespace, chartPath, values, helmConfig)
+	}
+
 	chartPath, err := locateChartPath(client.Settings, ptr.ReverseString(chart.RepoURL), ptr.ReverseString(chart.Username), ptr.ReverseString(chart.Password), ptr.ReverseString(chart.Name), ptr.ReverseString(chart.Version), false, "", "", "", "")
 	if err != nil {
 		return nil, errors.Wrap(err, "locate chart path")
@@ -0,0 +1,212 @@
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dockerclient "github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	"github.com/docker/distribution/registry/client/transport"
+	"github.com/docker/docker/api/types"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// OCIPrefix is the prefix of chart names that are pulled from an OCI registry, e.g. oci://my-registry.com/charts/my-chart
+const OCIPrefix = "oci://"
+
+// OCIChartFolder is the folder where charts pulled from OCI registries are cached
+var OCIChartFolder = ".devspace/charts/oci"
+
+// chartLayerMediaTypes are the media types of the layer that contains the packaged chart
+var chartLayerMediaTypes = map[string]bool{
+	"application/vnd.cncf.helm.chart.content.v1.tar+gzip": true,
+	"application/tar+gzip":                                true,
+}
+
+// ociManifest is the part of an OCI image manifest we need to find the chart layer
+type ociManifest struct {
+	Layers []struct {
+		MediaType string `json:"mediaType"`
+		Digest    string `json:"digest"`
+	} `json:"layers"`
+}
+
+// credentialStore provides the credentials of a registry to the docker distribution authorizer
+type credentialStore struct {
+	authConfig *types.AuthConfig
+}
+
+// Basic returns the username and password of the registry
+func (c *credentialStore) Basic(*url.URL) (string, string) {
+	return c.authConfig.Username, c.authConfig.Password
+}
+
+// RefreshToken returns the identity token of the registry
+func (c *credentialStore) RefreshToken(*url.URL, string) string {
+	return c.authConfig.IdentityToken
+}
+
+// SetRefreshToken is not needed, because we do not store tokens
+func (c *credentialStore) SetRefreshToken(*url.URL, string, string) {}
+
+// getOCIAuthConfig returns the credentials for the registry of an OCI chart. Configured credentials are preferred,
+// otherwise the credentials are loaded in the same way as for image registries
+func (client *Client) getOCIAuthConfig(registryURL, username, password string) *types.AuthConfig {
+	if username != "" || password != "" {
+		return &types.AuthConfig{
+			Username:      username,
+			Password:      password,
+			ServerAddress: registryURL,
+		}
+	}
+
+	dockerClient, err := dockerclient.NewClient(client.config, false, log.Discard)
+	if err != nil {
+		return &types.AuthConfig{}
+	}
+
+	authConfig, err := dockerclient.GetAuthConfig(dockerClient, registryURL, true)
+	if err != nil || authConfig == nil {
+		return &types.AuthConfig{}
+	}
+
+	return authConfig
+}
+
+// parseOCIChartName splits an oci:// chart name into the registry and the repository
+func parseOCIChartName(name string) (string, string, error) {
+	ref := strings.TrimPrefix(strings.TrimSpace(name), OCIPrefix)
+
+	splitted := strings.SplitN(ref, "/", 2)
+	if len(splitted) != 2 || splitted[0] == "" || splitted[1] == "" {
+		return "", "", fmt.Errorf("Invalid OCI chart %s, expected format is %sregistry/repository", name, OCIPrefix)
+	}
+
+	return splitted[0], splitted[1], nil
+}
+
+// pullOCIChart pulls the chart with the given version (tag) from an OCI registry and returns the path to the packaged chart.
+// Charts are cached by their digest in the OCIChartFolder
+func pullOCIChart(registryURL, repository, version string, authConfig *types.AuthConfig) (string, error) {
+	if version == "" {
+		version = "latest"
+	}
+
+	scheme := "https"
+	if strings.HasPrefix(registryURL, "localhost") || strings.HasPrefix(registryURL, "127.0.0.1") {
+		scheme = "http"
+	}
+
+	baseURL := scheme + "://" + registryURL + "/v2/"
+
+	// Find out how to authenticate with the registry
+	challengeManager := challenge.NewSimpleManager()
+	resp, err := http.Get(baseURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "ping registry %s", registryURL)
+	}
+	resp.Body.Close()
+
+	err = challengeManager.AddResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	creds := &credentialStore{authConfig: authConfig}
+	httpClient := &http.Client{
+		Transport: transport.NewTransport(http.DefaultTransport, auth.NewAuthorizer(challengeManager, auth.NewTokenHandler(http.DefaultTransport, creds, repository, "pull"), auth.NewBasicHandler(creds))),
+	}
+
+	// Get the manifest
+	req, err := http.NewRequest("GET", baseURL+repository+"/manifests/"+version, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
+
+	resp, err = httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "get chart manifest")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Error getting manifest of chart %s/%s:%s: %s", registryURL, repository, version, resp.Status)
+	}
+
+	manifest := &ociManifest{}
+	err = json.NewDecoder(resp.Body).Decode(manifest)
+	if err != nil {
+		return "", errors.Wrap(err, "decode chart manifest")
+	}
+
+	chartDigest := ""
+	for _, layer := range manifest.Layers {
+		if chartLayerMediaTypes[layer.MediaType] {
+			chartDigest = layer.Digest
+			break
+		}
+	}
+	if chartDigest == "" {
+		return "", fmt.Errorf("%s/%s:%s is not a helm chart", registryURL, repository, version)
+	}
+
+	parsedDigest, err := digest.Parse(chartDigest)
+	if err != nil {
+		return "", err
+	}
+
+	chartPath := filepath.Join(OCIChartFolder, parsedDigest.Algorithm().String(), parsedDigest.Hex()+".tgz")
+	if _, err := os.Stat(chartPath); err == nil {
+		return filepath.Abs(chartPath)
+	}
+
+	// Download the chart
+	resp, err = httpClient.Get(baseURL + repository + "/blobs/" + chartDigest)
+	if err != nil {
+		return "", errors.Wrap(err, "download chart")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Error downloading chart %s/%s:%s: %s", registryURL, repository, version, resp.Status)
+	}
+
+	err = os.MkdirAll(filepath.Dir(chartPath), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(chartPath), "download")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tempFile.Name())
+
+	verifier := parsedDigest.Verifier()
+	_, err = io.Copy(io.MultiWriter(tempFile, verifier), resp.Body)
+	tempFile.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "download chart")
+	}
+	if verifier.Verified() == false {
+		return "", fmt.Errorf("Downloaded chart %s/%s:%s does not match digest %s", registryURL, repository, version, chartDigest)
+	}
+
+	err = os.Rename(tempFile.Name(), chartPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(chartPath)
+}
@@ -0,0 +1,84 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+)
+
+func TestParseOCIChartName(t *testing.T) {
+	registryURL, repository, err := parseOCIChartName("oci://my-registry.com:5000/charts/my-chart")
+	assert.NilError(t, err, "Error parsing chart name")
+	assert.Equal(t, registryURL, "my-registry.com:5000")
+	assert.Equal(t, repository, "charts/my-chart")
+
+	_, _, err = parseOCIChartName("oci://my-registry.com")
+	assert.Assert(t, err != nil, "No error parsing chart name without repository")
+}
+
+func TestPullOCIChart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-oci-chart")
+	assert.NilError(t, err, "Error creating temporary directory")
+	defer os.RemoveAll(dir)
+
+	oldOCIChartFolder := OCIChartFolder
+	OCIChartFolder = dir
+	defer func() { OCIChartFolder = oldOCIChartFolder }()
+
+	chartContent := "packaged chart"
+	chartDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(chartContent)))
+	blobRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case "/v2/charts/my-chart/manifests/0.1.0":
+			fmt.Fprintf(w, `{"layers":[{"mediaType":"application/vnd.cncf.helm.chart.content.v1.tar+gzip","digest":"%s"}]}`, chartDigest)
+		case "/v2/charts/my-chart/blobs/" + chartDigest:
+			blobRequests++
+			fmt.Fprint(w, chartContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registryURL := strings.TrimPrefix(server.URL, "http://")
+	authConfig := &types.AuthConfig{Username: "user", Password: "secret"}
+
+	for i := 0; i < 2; i++ {
+		chartPath, err := pullOCIChart(registryURL, "charts/my-chart", "0.1.0", authConfig)
+		assert.NilError(t, err, "Error pulling chart")
+		assert.Equal(t, filepath.Dir(chartPath), filepath.Join(dir, "sha256"))
+
+		content, err := ioutil.ReadFile(chartPath)
+		assert.NilError(t, err, "Error reading chart")
+		assert.Equal(t, string(content), chartContent)
+	}
+
+	// The second pull is served from the cache
+	assert.Equal(t, blobRequests, 1)
+
+	_, err = pullOCIChart(registryURL, "charts/other-chart", "0.1.0", authConfig)
+	assert.Assert(t, err != nil, "No error pulling a chart that does not exist")
+
+	_, err = pullOCIChart(registryURL, "charts/my-chart", "0.1.0", &types.AuthConfig{})
+	assert.Assert(t, err != nil, "No error pulling a chart without credentials")
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
@@ -14,6 +16,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // NewClient creates a new kubernetes client
@@ -73,53 +76,82 @@ func GetRestConfigBySelect(allowPrivate bool, switchContext bool) (*rest.Config,
 		return nil, err
 	}
 
-	// Get all kube contexts
+	// Get all kube contexts. Contexts of clusters in a private network are skipped if they are not allowed
 	options := make([]string, 0, len(kubeConfig.Contexts))
+	skipped := []string{}
 	for context := range kubeConfig.Contexts {
+		if allowPrivate == false {
+			isPrivate, err := isPrivateContext(kubeConfig, context)
+			if err != nil {
+				return nil, err
+			} else if isPrivate {
+				skipped = append(skipped, context)
+				continue
+			}
+		}
+
 		options = append(options, context)
 	}
 	if len(options) == 0 {
+		if len(skipped) > 0 {
+			return nil, errors.Errorf("The kube contexts %s point to clusters with private ips, which cannot be used. Please configure a kube context of a cluster with a public ip", strings.Join(skipped, ", "))
+		}
+
 		return nil, errors.New("No kubectl context found. Make sure kubectl is installed and you have a working kubernetes context configured")
 	}
 
-	for true {
-		kubeContext := survey.Question(&survey.QuestionOptions{
-			Question:     "Which kube context do you want to use",
-			DefaultValue: kubeConfig.CurrentContext,
-			Options:      options,
-		})
+	sort.Strings(options)
+	if len(skipped) > 0 {
+		log.Infof("Skipping the kube contexts %s, because clusters with private ips cannot be used", strings.Join(skipped, ", "))
+	}
 
-		// Check if cluster is in private network
-		if allowPrivate == false {
-			context := kubeConfig.Contexts[kubeContext]
-			cluster := kubeConfig.Clusters[context.Cluster]
+	defaultContext := ""
+	for _, context := range options {
+		if context == kubeConfig.CurrentContext {
+			defaultContext = context
+		}
+	}
 
-			url, err := url.Parse(cluster.Server)
-			if err != nil {
-				return nil, errors.Wrap(err, "url parse")
-			}
+	kubeContext, err := survey.Question(&survey.QuestionOptions{
+		Question:           "Which kube context do you want to use",
+		DefaultValue:       defaultContext,
+		Options:            options,
+		NonInteractiveHint: "Please select a kube context with 'kubectl config use-context [CONTEXT]'",
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			ip := net.ParseIP(url.Hostname())
-			if ip != nil {
-				if IsPrivateIP(ip) {
-					log.Infof("Clusters with private ips (%s) cannot be used", url.Hostname())
-					continue
-				}
-			}
+	if switchContext {
+		kubeConfig.CurrentContext = kubeContext
+		err = kubeconfig.SaveConfig(kubeConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "write kube config")
 		}
+	}
 
-		if switchContext {
-			kubeConfig.CurrentContext = kubeContext
-			err = kubeconfig.SaveConfig(kubeConfig)
-			if err != nil {
-				return nil, errors.Wrap(err, "write kube config")
-			}
-		}
+	return GetRestConfigFromContext(kubeContext)
+}
+
+// isPrivateContext returns true if the server of the cluster of the kube context has a private ip
+func isPrivateContext(kubeConfig *clientcmdapi.Config, context string) (bool, error) {
+	kubeContext := kubeConfig.Contexts[context]
+	if kubeContext == nil {
+		return false, nil
+	}
 
-		return GetRestConfigFromContext(kubeContext)
+	cluster := kubeConfig.Clusters[kubeContext.Cluster]
+	if cluster == nil {
+		return false, nil
+	}
+
+	url, err := url.Parse(cluster.Server)
+	if err != nil {
+		return false, errors.Wrap(err, "url parse")
 	}
 
-	return nil, errors.New("We should not reach this point")
+	ip := net.ParseIP(url.Hostname())
+	return ip != nil && IsPrivateIP(ip), nil
 }
 
 // GetRestConfigFromContext loads the configuration from a kubernetes context
@@ -12,8 +12,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func createTestConfig() *latest.Config {
@@ -150,3 +153,185 @@ func TestLogs(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestIsPrivateContext(t *testing.T) {
+	kubeConfig := &clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"private": &clientcmdapi.Cluster{Server: "https://192.168.0.10:6443"},
+			"public":  &clientcmdapi.Cluster{Server: "https://35.1.2.3"},
+			"dns":     &clientcmdapi.Cluster{Server: "https://my-cluster.example.com"},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"private": &clientcmdapi.Context{Cluster: "private"},
+			"public":  &clientcmdapi.Context{Cluster: "public"},
+			"dns":     &clientcmdapi.Context{Cluster: "dns"},
+			"missing": &clientcmdapi.Context{Cluster: "missing"},
+		},
+	}
+
+	expected := map[string]bool{
+		"private": true,
+		"public":  false,
+		"dns":     false,
+		"missing": false,
+	}
+	for context, expectedPrivate := range expected {
+		isPrivate, err := isPrivateContext(kubeConfig, context)
+		if err != nil {
+			t.Fatalf("Error checking context %s: %v", context, err)
+		}
+		if isPrivate != expectedPrivate {
+			t.Fatalf("Context %s: expected private %v, got %v", context, expectedPrivate, isPrivate)
+		}
+	}
+}
+
+func TestPodReplaced(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "old"}}
+	_, err := client.CoreV1().Pods("default").Create(pod)
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+
+	if PodReplaced(client, pod) {
+		t.Fatal("Running pod was reported as replaced")
+	}
+
+	now := metav1.Now()
+	terminatingPod := pod.DeepCopy()
+	terminatingPod.DeletionTimestamp = &now
+	_, err = client.CoreV1().Pods("default").Update(terminatingPod)
+	if err != nil {
+		t.Fatalf("Error updating pod: %v", err)
+	}
+
+	if PodReplaced(client, pod) == false {
+		t.Fatal("Terminating pod was not reported as replaced")
+	}
+
+	err = client.CoreV1().Pods("default").Delete("test-pod", nil)
+	if err != nil {
+		t.Fatalf("Error deleting pod: %v", err)
+	}
+
+	if PodReplaced(client, pod) == false {
+		t.Fatal("Deleted pod was not reported as replaced")
+	}
+}
+
+func createReadyTestPod(client kubernetes.Interface, name string, labels map[string]string, created time.Time, ready bool) (*v1.Pod, error) {
+	readyStatus := v1.ConditionFalse
+	if ready {
+		readyStatus = v1.ConditionTrue
+	}
+
+	return client.CoreV1().Pods("default").Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), Labels: labels, CreationTimestamp: metav1.NewTime(created)},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "server", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}}},
+		},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "server", Ready: ready, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	})
+}
+
+func TestGetNewestReadyPod(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	labels := map[string]string{"app": "api"}
+
+	_, err := createReadyTestPod(client, "old", labels, time.Now().Add(-time.Hour), true)
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+	_, err = createReadyTestPod(client, "new-not-ready", labels, time.Now(), false)
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+
+	pod, err := GetNewestReadyPod(client, "app=api", "default")
+	if err != nil {
+		t.Fatalf("Error getting pod: %v", err)
+	}
+	if pod == nil || pod.Name != "old" {
+		t.Fatalf("Expected ready pod old, got %v", pod)
+	}
+
+	pod, err = GetNewestReadyPod(client, "app=other", "default")
+	if err != nil {
+		t.Fatalf("Error getting pod: %v", err)
+	}
+	if pod != nil {
+		t.Fatalf("Expected no pod, got %s", pod.Name)
+	}
+}
+
+func TestGetServiceEndpointPod(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	_, err := createReadyTestPod(client, "not-ready", nil, time.Now(), false)
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+	_, err = createReadyTestPod(client, "ready", nil, time.Now(), true)
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+
+	pod, err := GetServiceEndpointPod(client, "api", "default")
+	if err != nil || pod != nil {
+		t.Fatalf("Expected no pod and no error without endpoints, got %v %v", pod, err)
+	}
+
+	_, err = client.CoreV1().Endpoints("default").Create(&v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Subsets: []v1.EndpointSubset{{
+			Addresses: []v1.EndpointAddress{
+				{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "deleted"}},
+				{IP: "10.0.0.2", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "not-ready"}},
+				{IP: "10.0.0.3", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "ready"}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Error creating endpoints: %v", err)
+	}
+
+	pod, err = GetServiceEndpointPod(client, "api", "default")
+	if err != nil {
+		t.Fatalf("Error getting pod: %v", err)
+	}
+	if pod == nil || pod.Name != "ready" {
+		t.Fatalf("Expected pod ready, got %v", pod)
+	}
+}
+
+func TestGetServiceTargetPort(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "server", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}}},
+		},
+	}
+	service := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{Port: 80, TargetPort: intstr.FromString("http")},
+				{Port: 443, TargetPort: intstr.FromInt(8443)},
+				{Port: 9000},
+			},
+		},
+	}
+
+	for port, expected := range map[int]int{80: 8080, 443: 8443, 9000: 9000, 3000: 3000} {
+		targetPort := GetServiceTargetPort(service, pod, port)
+		if targetPort != expected {
+			t.Fatalf("Expected target port %d for port %d, got %d", expected, port, targetPort)
+		}
+	}
+}
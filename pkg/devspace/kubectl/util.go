@@ -17,7 +17,9 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/rbac/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
@@ -260,6 +262,133 @@ func GetPodStatus(pod *k8sv1.Pod) string {
 	return reason
 }
 
+// PodReplaced checks if the pod was deleted or is terminating, e.g. because a redeployment replaced it with a new pod
+func PodReplaced(client kubernetes.Interface, pod *k8sv1.Pod) bool {
+	currentPod, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return kerrors.IsNotFound(err)
+	}
+
+	return currentPod.UID != pod.UID || currentPod.DeletionTimestamp != nil
+}
+
+// IsPodReady checks if the pod is running, ready and not terminating
+func IsPodReady(pod *k8sv1.Pod) bool {
+	if pod.DeletionTimestamp != nil || GetPodStatus(pod) != "Running" {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == k8sv1.PodReady {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// GetNewestReadyPod returns the newest ready pod of the label selector or nil if no pod is ready
+func GetNewestReadyPod(client kubernetes.Interface, labelSelector, namespace string) (*k8sv1.Pod, error) {
+	podList, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var selectedPod *k8sv1.Pod
+	for _, pod := range podList.Items {
+		currentPod := pod
+		if IsPodReady(&currentPod) == false {
+			continue
+		}
+
+		if selectedPod == nil || currentPod.CreationTimestamp.Time.After(selectedPod.CreationTimestamp.Time) {
+			selectedPod = &currentPod
+		}
+	}
+
+	return selectedPod, nil
+}
+
+// GetServiceEndpointPod returns a ready pod that backs the service according to its endpoints or nil if no pod is ready
+func GetServiceEndpointPod(client kubernetes.Interface, service, namespace string) (*k8sv1.Pod, error) {
+	endpoints, err := client.CoreV1().Endpoints(namespace).Get(service, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+				continue
+			}
+
+			pod, err := client.CoreV1().Pods(namespace).Get(address.TargetRef.Name, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+
+				return nil, err
+			}
+
+			if IsPodReady(pod) {
+				return pod, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// GetServiceTargetPort returns the port of the pod the service forwards the given service port to. If the service does not
+// expose the port, the port is returned unchanged
+func GetServiceTargetPort(service *k8sv1.Service, pod *k8sv1.Pod, port int) int {
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) != port {
+			continue
+		}
+
+		if servicePort.TargetPort.Type == intstr.String {
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return int(containerPort.ContainerPort)
+					}
+				}
+			}
+
+			return port
+		} else if servicePort.TargetPort.IntVal != 0 {
+			return int(servicePort.TargetPort.IntVal)
+		}
+
+		return port
+	}
+
+	return port
+}
+
+// GetDeploymentLabelSelector returns the label selector of the pods of a deployment
+func GetDeploymentLabelSelector(client kubernetes.Interface, name, namespace string) (string, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+
+	return selector.String(), nil
+}
+
 // GetPodsFromDeployment retrieves all found pods from a deployment name
 func GetPodsFromDeployment(kubectl kubernetes.Interface, deployment, namespace string) (*k8sv1.PodList, error) {
 	deploy, err := kubectl.ExtensionsV1beta1().Deployments(namespace).Get(deployment, metav1.GetOptions{})
@@ -49,11 +49,19 @@ func StartAttach(config *latest.Config, client kubernetes.Interface, cmdParamete
 
 	log.Infof("Printing logs of pod %s/%s...", pod.Name, container.Name)
 
+	streamErr := make(chan error, 1)
 	go func() {
-		interrupt <- kubectl.AttachStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, true, nil, os.Stdout, os.Stderr)
+		streamErr <- kubectl.AttachStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, true, nil, os.Stdout, os.Stderr)
 	}()
 
-	err = <-interrupt
+	// Errors sent to interrupt, e.g. to reload the dev mode, are returned as they are
+	select {
+	case err = <-interrupt:
+		upgradeRoundTripper.Close()
+		return 0, err
+	case err = <-streamErr:
+	}
+
 	upgradeRoundTripper.Close()
 	if err != nil {
 		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
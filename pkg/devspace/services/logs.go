@@ -1,26 +1,86 @@
 package services
 
 import (
-	"fmt"
+	"bufio"
+	"context"
 	"io"
 	"os"
+	"regexp"
+	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/mgutz/ansi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	kubectlExec "k8s.io/client-go/util/exec"
 )
 
-// StartLogs print the logs and then attaches to the container
-func StartLogs(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, follow bool, tail int64, log log.Logger) error {
-	return StartLogsWithWriter(config, client, cmdParameter, follow, tail, log, os.Stdout, os.Stderr)
+// LogOptions defines which logs are printed
+type LogOptions struct {
+	// Follow keeps streaming new log lines and reconnects if the container restarts or the pod is replaced
+	Follow bool
+
+	// Tail is the max amount of lines printed from the existing logs, if nil all lines are printed
+	Tail *int64
+
+	// Since only prints the lines that are newer than this duration, if 0 all lines are printed
+	Since time.Duration
+
+	// Timestamps prefixes each line with its timestamp
+	Timestamps bool
+
+	// Previous prints the logs of the previous instance of the container, e.g. after it crashed
+	Previous bool
+
+	// Filter only prints the lines that match this regular expression
+	Filter *regexp.Regexp
+}
+
+// podLogOptions returns the options of the pods/log request
+func (o *LogOptions) podLogOptions(container string, tail *int64) *v1.PodLogOptions {
+	options := &v1.PodLogOptions{
+		Container:  container,
+		Follow:     o.Follow && o.Previous == false,
+		TailLines:  tail,
+		Timestamps: o.Timestamps,
+		Previous:   o.Previous,
+	}
+
+	if o.Since > 0 {
+		seconds := int64(o.Since.Seconds())
+		options.SinceSeconds = &seconds
+	}
+
+	return options
+}
+
+// hasLogs returns if the logs of the container can be printed
+func (o *LogOptions) hasLogs(status *v1.ContainerStatus) bool {
+	if o.Previous {
+		return status.LastTerminationState.Terminated != nil
+	} else if o.Follow {
+		return status.State.Running != nil
+	}
+
+	return status.State.Running != nil || status.State.Terminated != nil
 }
 
-// StartLogsWithWriter prints the logs and then attaches to the container with the given stdout and stderr
-func StartLogsWithWriter(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, follow bool, tail int64, log log.Logger, stdout io.Writer, stderr io.Writer) error {
+// matches returns if the line should be printed
+func (o *LogOptions) matches(line string) bool {
+	return o.Filter == nil || o.Filter.MatchString(line)
+}
+
+// StartLogs prints the logs of the selected container
+func StartLogs(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, options *LogOptions, log log.Logger) error {
+	return StartLogsWithWriter(config, client, cmdParameter, options, log, os.Stdout)
+}
+
+// StartLogsWithWriter prints the logs of the selected container to the given writer. If options.Follow is true, it keeps streaming
+// until the container terminates and reconnects if the container restarts or the pod is replaced
+func StartLogsWithWriter(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, options *LogOptions, log log.Logger, writer io.Writer) error {
 	selectorParameter := &targetselector.SelectorParameter{
 		CmdParameter: cmdParameter,
 	}
@@ -44,49 +104,132 @@ func StartLogsWithWriter(config *latest.Config, client kubernetes.Interface, cmd
 		return err
 	}
 
-	kubeconfig, err := kubectl.GetRestConfig(config)
-	if err != nil {
-		return err
-	}
+	// Only a pod that was not selected by name or picked can be selected again after it was replaced
+	reselect := cmdParameter.PodName == nil && (cmdParameter.Pick == nil || *cmdParameter.Pick == false)
 
-	wrapper, upgradeRoundTripper, err := kubectl.GetUpgraderWrapper(kubeconfig)
-	if err != nil {
-		return err
+	tail := options.Tail
+	for {
+		log.Infof("Printing logs of pod:container %s:%s", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
+
+		printed, err := printLogs(client, pod, container.Name, options, tail, writer)
+		if err != nil {
+			return err
+		}
+		if printed == false && options.Follow == false {
+			log.Infof("Logs of pod %s:%s were empty", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
+		}
+		if options.Follow == false || options.Previous {
+			return nil
+		}
+
+		restartedPod, err := waitForRestart(client, pod, container.Name)
+		if err != nil {
+			return err
+		}
+
+		if restartedPod == nil {
+			if kubectl.PodReplaced(client, pod) == false || reselect == false {
+				return nil
+			}
+
+			log.Infof("Pod %s was replaced, waiting for the new pod", pod.Name)
+			restartedPod, container, err = targetSelector.GetContainer(client)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The new container instance is printed completely
+		pod, tail = restartedPod, nil
 	}
+}
+
+// StartAggregatedLogs prints the logs of all containers of the pods matched by the sources, prefixed with the pod and container name.
+// If options.Follow is true, it keeps streaming and also prints the logs of new pods and restarted containers
+func StartAggregatedLogs(client kubernetes.Interface, sources []*LogSource, options *LogOptions, log log.Logger) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	multiplexer := newLogMultiplexer(ctx, client, sources, options, os.Stdout, log)
+	for {
+		err := multiplexer.update()
+		if err != nil {
+			return err
+		}
 
-	log.Infof("Printing logs of pod:container %s:%s", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
+		if options.Follow == false || options.Previous {
+			multiplexer.streams.Wait()
+			return nil
+		}
 
-	logOutput, err := kubectl.Logs(client, pod.Namespace, pod.Name, container.Name, false, &tail)
+		time.Sleep(time.Second * 2)
+	}
+}
+
+// printLogs prints the logs of a container and returns if at least one line was printed
+func printLogs(client kubernetes.Interface, pod *v1.Pod, container string, options *LogOptions, tail *int64, writer io.Writer) (bool, error) {
+	reader, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options.podLogOptions(container, tail)).Context(context.Background()).Stream()
 	if err != nil {
-		return nil
+		return false, err
 	}
+	defer reader.Close()
+
+	printed := false
+	bufReader := bufio.NewReader(reader)
+	for {
+		line, err := bufReader.ReadString('\n')
+		if line != "" && options.matches(line) {
+			_, writeErr := writer.Write([]byte(line))
+			if writeErr != nil {
+				return printed, writeErr
+			}
 
-	stdout.Write([]byte(logOutput))
-	if follow == false {
-		if logOutput == "" {
-			log.Infof("Logs of pod %s:%s were empty", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
+			printed = true
+		}
+		if err == io.EOF {
+			return printed, nil
+		} else if err != nil {
+			return printed, err
 		}
+	}
+}
 
-		return nil
+// waitForRestart waits until the container runs again after it terminated. It returns nil if the pod was replaced or the container
+// will not be restarted
+func waitForRestart(client kubernetes.Interface, pod *v1.Pod, container string) (*v1.Pod, error) {
+	restartCount := int32(-1)
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			restartCount = status.RestartCount
+		}
 	}
 
-	interrupt := make(chan error)
+	for {
+		if kubectl.PodReplaced(client, pod) {
+			return nil, nil
+		}
 
-	// TODO: Refactor this, because with this method we could miss some messages between logs and attach
-	go func() {
-		err := kubectl.AttachStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, true, nil, stdout, stderr)
+		currentPod, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
 		if err != nil {
-			if _, ok := err.(kubectlExec.CodeExitError); ok == false {
-				interrupt <- fmt.Errorf("Unable to start attach session: %v", err)
-				return
-			}
+			return nil, err
 		}
 
-		interrupt <- nil
-	}()
+		for _, status := range currentPod.Status.ContainerStatuses {
+			if status.Name != container {
+				continue
+			}
 
-	err = <-interrupt
-	upgradeRoundTripper.Close()
+			if status.RestartCount > restartCount && status.State.Running != nil {
+				return currentPod, nil
+			}
 
-	return err
+			// Containers that completed are not restarted with these restart policies
+			terminated := status.State.Terminated
+			if terminated != nil && (currentPod.Spec.RestartPolicy == v1.RestartPolicyNever || (currentPod.Spec.RestartPolicy == v1.RestartPolicyOnFailure && terminated.ExitCode == 0)) {
+				return nil, nil
+			}
+		}
+
+		time.Sleep(time.Second * 2)
+	}
 }
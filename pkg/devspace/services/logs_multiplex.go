@@ -0,0 +1,446 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// DefaultLogLines is the amount of lines printed of containers that were already running
+const DefaultLogLines = int64(100)
+
+// maxBufferedLines is the amount of log lines kept while a terminal is open
+const maxBufferedLines = 1000
+
+// logColors are the colors the log lines of the different containers are prefixed with
+var logColors = []string{"cyan+b", "magenta+b", "yellow+b", "green+b", "blue+b", "red+b", "cyan", "magenta", "yellow", "green", "blue", "red"}
+
+// LogSource defines pods and containers whose logs are multiplexed
+type LogSource struct {
+	Namespace     string
+	LabelSelector string
+
+	// ContainerName selects a single container, if empty the logs of all containers are streamed
+	ContainerName string
+}
+
+// GetLogSources returns the log sources defined in dev.logs or the sources of all dev.selectors if no selectors are specified
+func GetLogSources(config *latest.Config) ([]*LogSource, error) {
+	selectors := []*latest.LogsSelector{}
+	if config.Dev != nil && config.Dev.Logs != nil && config.Dev.Logs.Selectors != nil {
+		selectors = *config.Dev.Logs.Selectors
+	} else if config.Dev != nil && config.Dev.Selectors != nil {
+		for _, selector := range *config.Dev.Selectors {
+			selectors = append(selectors, &latest.LogsSelector{Selector: selector.Name})
+		}
+	}
+
+	if len(selectors) == 0 {
+		return nil, errors.New("Please specify at least one selector in dev.logs.selectors or dev.selectors")
+	}
+
+	sources := make([]*LogSource, 0, len(selectors))
+	for _, selector := range selectors {
+		selectorParameter := &targetselector.SelectorParameter{
+			ConfigParameter: targetselector.ConfigParameter{
+				Selector:      selector.Selector,
+				Namespace:     selector.Namespace,
+				LabelSelector: selector.LabelSelector,
+				ContainerName: selector.ContainerName,
+			},
+		}
+
+		namespace, err := selectorParameter.GetNamespace(config)
+		if err != nil {
+			return nil, err
+		}
+
+		labelSelector, err := selectorParameter.GetLabelSelector(config)
+		if err != nil {
+			return nil, err
+		}
+		if labelSelector == nil {
+			return nil, errors.New("Please specify a selector or labelSelector for each entry of dev.logs.selectors")
+		}
+
+		source := &LogSource{
+			Namespace:     namespace,
+			LabelSelector: *labelSelector,
+		}
+
+		if selector.ContainerName != nil {
+			source.ContainerName = *selector.ContainerName
+		} else if selector.Selector != nil {
+			selectorConfig, err := configutil.GetSelector(config, *selector.Selector)
+			if err != nil {
+				return nil, err
+			}
+			if selectorConfig.ContainerName != nil {
+				source.ContainerName = *selectorConfig.ContainerName
+			}
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// StartMultiplexedLogs streams the logs of all containers selected in dev.logs, prefixed with the pod and container name,
+// until an error is sent to interrupt. New pods and restarted containers are picked up automatically. If withTerminal is true,
+// a terminal to one of the containers can be opened by entering its number
+func StartMultiplexedLogs(config *latest.Config, client kubernetes.Interface, withTerminal bool, interrupt chan error, log log.Logger) error {
+	sources, err := GetLogSources(config)
+	if err != nil {
+		return err
+	}
+
+	lines := DefaultLogLines
+	if config.Dev.Logs != nil && config.Dev.Logs.Lines != nil {
+		lines = *config.Dev.Logs.Lines
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	multiplexer := newLogMultiplexer(ctx, client, sources, &LogOptions{Follow: true, Tail: &lines}, os.Stdout, log)
+	err = multiplexer.update()
+	if err != nil {
+		return err
+	}
+
+	var input <-chan string
+	if withTerminal {
+		input = readStdinLines()
+		log.Info("Press enter to list the containers and enter the number of a container to open a terminal")
+	}
+
+	for {
+		select {
+		case err := <-interrupt:
+			return err
+		case line, ok := <-input:
+			if ok == false {
+				input = nil
+				continue
+			}
+
+			err := multiplexer.handleInput(config, line, interrupt)
+			stdinResume <- true
+			if err != nil {
+				return err
+			}
+		case <-time.After(time.Second * 2):
+			err := multiplexer.update()
+			if err != nil {
+				log.Warnf("Error updating the pods to stream the logs of: %v", err)
+			}
+		}
+	}
+}
+
+// logTarget is a single container instance whose logs are streamed
+type logTarget struct {
+	pod       *v1.Pod
+	container string
+}
+
+func (t *logTarget) name() string {
+	return t.pod.Name + ":" + t.container
+}
+
+type logMultiplexer struct {
+	client  kubernetes.Interface
+	sources []*LogSource
+	options *LogOptions
+	started time.Time
+	writer  *logWriter
+	log     log.Logger
+
+	// openStream opens the log stream of a container, if tail is nil the complete log is streamed
+	openStream func(pod *v1.Pod, container string, tail *int64) (io.ReadCloser, error)
+
+	mutex   sync.Mutex
+	seen    map[string]bool
+	active  map[string]*logTarget
+	colors  map[string]string
+	streams sync.WaitGroup
+}
+
+func newLogMultiplexer(ctx context.Context, client kubernetes.Interface, sources []*LogSource, options *LogOptions, out io.Writer, log log.Logger) *logMultiplexer {
+	return &logMultiplexer{
+		client:  client,
+		sources: sources,
+		options: options,
+		started: time.Now(),
+		writer:  &logWriter{out: out},
+		log:     log,
+
+		openStream: func(pod *v1.Pod, container string, tail *int64) (io.ReadCloser, error) {
+			return client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options.podLogOptions(container, tail)).Context(ctx).Stream()
+		},
+
+		seen:   map[string]bool{},
+		active: map[string]*logTarget{},
+		colors: map[string]string{},
+	}
+}
+
+// update starts streaming the logs of all matched containers that are not streamed yet
+func (m *logMultiplexer) update() error {
+	for _, source := range m.sources {
+		pods, err := m.client.CoreV1().Pods(source.Namespace).List(metav1.ListOptions{LabelSelector: source.LabelSelector})
+		if err != nil {
+			return err
+		}
+
+		for idx := range pods.Items {
+			pod := &pods.Items[idx]
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+
+			for _, status := range pod.Status.ContainerStatuses {
+				if (source.ContainerName != "" && status.Name != source.ContainerName) || m.options.hasLogs(&status) == false {
+					continue
+				}
+
+				// A restarted container gets a new key, so its logs are streamed again
+				key := string(pod.UID) + "/" + status.Name + "/" + strconv.Itoa(int(status.RestartCount))
+
+				m.mutex.Lock()
+				if m.seen[key] {
+					m.mutex.Unlock()
+					continue
+				}
+
+				target := &logTarget{pod: pod, container: status.Name}
+				m.seen[key] = true
+				m.active[key] = target
+				if _, ok := m.colors[status.Name]; ok == false {
+					m.colors[status.Name] = logColors[len(m.colors)%len(logColors)]
+				}
+				m.mutex.Unlock()
+
+				// Only the last lines are printed of containers that were already running before
+				var tail *int64
+				if status.State.Running == nil || status.State.Running.StartedAt.Time.Before(m.started) {
+					tail = m.options.Tail
+				}
+
+				m.streams.Add(1)
+				go m.stream(key, target, tail)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *logMultiplexer) stream(key string, target *logTarget, tail *int64) {
+	defer m.streams.Done()
+	defer func() {
+		m.mutex.Lock()
+		delete(m.active, key)
+		m.mutex.Unlock()
+	}()
+
+	reader, err := m.openStream(target.pod, target.container, tail)
+	if err != nil {
+		m.log.Warnf("Unable to stream the logs of %s: %v", target.name(), err)
+		return
+	}
+	defer reader.Close()
+
+	m.mutex.Lock()
+	prefix := ansi.Color("["+target.name()+"]", m.colors[target.container]) + " "
+	m.mutex.Unlock()
+
+	bufReader := bufio.NewReader(reader)
+	for {
+		line, err := bufReader.ReadString('\n')
+		if line != "" && m.options.matches(line) {
+			m.writer.WriteLine(prefix + strings.TrimRight(line, "\r\n"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// targets returns the containers whose logs are currently streamed sorted by name
+func (m *logMultiplexer) targets() []*logTarget {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	targets := make([]*logTarget, 0, len(m.active))
+	for _, target := range m.active {
+		targets = append(targets, target)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].name() < targets[j].name()
+	})
+
+	return targets
+}
+
+// handleInput lists the containers or opens a terminal to the container with the entered number
+func (m *logMultiplexer) handleInput(config *latest.Config, line string, interrupt chan error) error {
+	targets := m.targets()
+
+	number, err := strconv.Atoi(line)
+	if err != nil || number < 1 || number > len(targets) {
+		m.writer.Pause()
+		for idx, target := range targets {
+			m.log.WriteString(fmt.Sprintf("[%d] %s\n", idx+1, target.name()))
+		}
+		m.log.Info("Enter the number of a container to open a terminal")
+		m.writer.Resume()
+
+		return nil
+	}
+
+	target := targets[number-1]
+	m.writer.Pause()
+	defer m.writer.Resume()
+
+	m.log.Infof("Opening shell to pod:container %s:%s, exit the shell to return to the logs", ansi.Color(target.pod.Name, "white+b"), ansi.Color(target.container, "white+b"))
+
+	kubeconfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		return err
+	}
+
+	wrapper, upgradeRoundTripper, err := kubectl.GetUpgraderWrapper(kubeconfig)
+	if err != nil {
+		return err
+	}
+	defer upgradeRoundTripper.Close()
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- kubectl.ExecStreamWithTransport(wrapper, upgradeRoundTripper, m.client, target.pod, target.container, getCommand(config, nil), true, os.Stdin, os.Stdout, os.Stderr)
+	}()
+
+	// Errors sent to interrupt, e.g. to reload the dev mode, are returned as they are
+	select {
+	case err = <-interrupt:
+		return err
+	case err = <-streamErr:
+	}
+
+	if err != nil {
+		if _, ok := err.(kubectlExec.CodeExitError); ok == false {
+			m.log.Warnf("Unable to start terminal session: %v", err)
+		}
+	}
+
+	m.log.Info("Returned to the logs")
+	return nil
+}
+
+// logWriter writes complete log lines and buffers them while paused
+type logWriter struct {
+	out io.Writer
+
+	mutex  sync.Mutex
+	paused bool
+	buffer []string
+}
+
+// WriteLine writes a single line or buffers it if the writer is paused
+func (w *logWriter) WriteLine(line string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.paused {
+		if len(w.buffer) >= maxBufferedLines {
+			w.buffer = w.buffer[1:]
+		}
+
+		w.buffer = append(w.buffer, line)
+		return
+	}
+
+	w.out.Write([]byte(line + "\n"))
+}
+
+// Pause buffers all lines until Resume is called
+func (w *logWriter) Pause() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.paused = true
+}
+
+// Resume writes the buffered lines and stops buffering
+func (w *logWriter) Resume() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, line := range w.buffer {
+		w.out.Write([]byte(line + "\n"))
+	}
+
+	w.paused = false
+	w.buffer = nil
+}
+
+var (
+	stdinOnce   sync.Once
+	stdinLines  = make(chan string)
+	stdinResume = make(chan bool)
+)
+
+// readStdinLines reads lines from stdin. After each line stdin is not read until true is sent to stdinResume,
+// so that a terminal can use stdin in the meantime
+func readStdinLines() <-chan string {
+	stdinOnce.Do(func() {
+		go func() {
+			defer close(stdinLines)
+
+			// Stdin is read byte by byte to not read ahead of the line
+			buf := make([]byte, 1)
+			line := []byte{}
+			for {
+				n, err := os.Stdin.Read(buf)
+				if err != nil {
+					return
+				}
+				if n == 0 {
+					continue
+				}
+				if buf[0] != '\n' {
+					line = append(line, buf[0])
+					continue
+				}
+
+				stdinLines <- strings.TrimSpace(string(line))
+				line = line[:0]
+				<-stdinResume
+			}
+		}()
+	})
+
+	return stdinLines
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/mgutz/ansi"
+
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetLogSources(t *testing.T) {
+	config := &latest.Config{
+		Cluster: &latest.Cluster{Namespace: ptr.String("default")},
+		Dev: &latest.DevConfig{
+			Selectors: &[]*latest.SelectorConfig{
+				{Name: ptr.String("api"), LabelSelector: &map[string]*string{"app": ptr.String("api")}, ContainerName: ptr.String("server")},
+				{Name: ptr.String("worker"), Namespace: ptr.String("jobs"), LabelSelector: &map[string]*string{"app": ptr.String("worker")}},
+			},
+		},
+	}
+
+	sources, err := GetLogSources(config)
+	assert.NilError(t, err, "Error getting log sources of selectors")
+	assert.DeepEqual(t, sources, []*LogSource{
+		{Namespace: "default", LabelSelector: "app=api", ContainerName: "server"},
+		{Namespace: "jobs", LabelSelector: "app=worker"},
+	})
+
+	config.Dev.Logs = &latest.LogsConfig{
+		Selectors: &[]*latest.LogsSelector{
+			{Selector: ptr.String("api"), ContainerName: ptr.String("sidecar")},
+			{LabelSelector: &map[string]*string{"app": ptr.String("frontend")}},
+		},
+	}
+
+	sources, err = GetLogSources(config)
+	assert.NilError(t, err, "Error getting log sources of dev.logs")
+	assert.DeepEqual(t, sources, []*LogSource{
+		{Namespace: "default", LabelSelector: "app=api", ContainerName: "sidecar"},
+		{Namespace: "default", LabelSelector: "app=frontend"},
+	})
+
+	config.Dev.Logs.Selectors = &[]*latest.LogsSelector{{Selector: ptr.String("doesnotexist")}}
+	_, err = GetLogSources(config)
+	assert.Error(t, err, "Unable to find selector: doesnotexist")
+}
+
+func TestLogMultiplexer(t *testing.T) {
+	ansi.DisableColors(true)
+	defer ansi.DisableColors(false)
+
+	client := fake.NewSimpleClientset()
+	startedBefore := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "default", UID: types.UID("api-1"), Labels: map[string]string{"app": "api"}},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "server", State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: startedBefore}}},
+				{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: startedBefore}}},
+				{Name: "crashing", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+	_, err := client.CoreV1().Pods("default").Create(pod)
+	assert.NilError(t, err, "Error creating pod")
+
+	out := &bytes.Buffer{}
+	tails := map[string]*int64{}
+	tailsMutex := sync.Mutex{}
+	multiplexer := newLogMultiplexer(context.Background(), client, []*LogSource{{Namespace: "default", LabelSelector: "app=api"}}, &LogOptions{Follow: true, Tail: ptr.Int64(10)}, out, log.Discard)
+	multiplexer.openStream = func(pod *v1.Pod, container string, tail *int64) (io.ReadCloser, error) {
+		tailsMutex.Lock()
+		tails[container] = tail
+		tailsMutex.Unlock()
+
+		return ioutil.NopCloser(strings.NewReader("hello from " + container + "\nsecond line\n")), nil
+	}
+
+	err = multiplexer.update()
+	assert.NilError(t, err, "Error updating multiplexer")
+	multiplexer.streams.Wait()
+
+	assert.DeepEqual(t, sortedLines(out.String()), []string{
+		"[api-1:server] hello from server",
+		"[api-1:server] second line",
+		"[api-1:sidecar] hello from sidecar",
+		"[api-1:sidecar] second line",
+	})
+	assert.Equal(t, *tails["sidecar"], int64(10))
+
+	// Containers that are streamed already are not streamed again
+	out.Reset()
+	err = multiplexer.update()
+	assert.NilError(t, err, "Error updating multiplexer")
+	multiplexer.streams.Wait()
+	assert.Equal(t, out.String(), "")
+
+	// A restarted container is streamed completely
+	pod.Status.ContainerStatuses[0].RestartCount = 1
+	pod.Status.ContainerStatuses[0].State.Running.StartedAt = metav1.Now()
+	_, err = client.CoreV1().Pods("default").Update(pod)
+	assert.NilError(t, err, "Error updating pod")
+
+	err = multiplexer.update()
+	assert.NilError(t, err, "Error updating multiplexer")
+	multiplexer.streams.Wait()
+	assert.DeepEqual(t, sortedLines(out.String()), []string{
+		"[api-1:server] hello from server",
+		"[api-1:server] second line",
+	})
+	assert.Assert(t, tails["server"] == nil, "Restarted container should be streamed without tail")
+}
+
+func TestLogMultiplexerFilter(t *testing.T) {
+	ansi.DisableColors(true)
+	defer ansi.DisableColors(false)
+
+	client := fake.NewSimpleClientset()
+	_, err := client.CoreV1().Pods("default").Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "default", UID: types.UID("worker-1"), Labels: map[string]string{"app": "worker"}},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "worker", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1}}},
+			},
+		},
+	})
+	assert.NilError(t, err, "Error creating pod")
+
+	out := &bytes.Buffer{}
+	multiplexer := newLogMultiplexer(context.Background(), client, []*LogSource{{Namespace: "default", LabelSelector: "app=worker"}}, &LogOptions{Filter: regexp.MustCompile("ERROR")}, out, log.Discard)
+	multiplexer.openStream = func(pod *v1.Pod, container string, tail *int64) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("INFO starting\nERROR failed\n")), nil
+	}
+
+	// Terminated containers are printed if the logs are not followed
+	err = multiplexer.update()
+	assert.NilError(t, err, "Error updating multiplexer")
+	multiplexer.streams.Wait()
+	assert.Equal(t, out.String(), "[worker-1:worker] ERROR failed\n")
+}
+
+func TestLogWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	writer := &logWriter{out: out}
+
+	writer.WriteLine("first")
+	writer.Pause()
+	writer.WriteLine("second")
+	assert.Equal(t, out.String(), "first\n")
+
+	writer.Resume()
+	writer.WriteLine("third")
+	assert.Equal(t, out.String(), "first\nsecond\nthird\n")
+}
+
+func sortedLines(output string) []string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	sort.Strings(lines)
+	return lines
+}
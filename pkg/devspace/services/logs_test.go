@@ -0,0 +1,93 @@
+package services
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLogOptions(t *testing.T) {
+	options := &LogOptions{
+		Follow:     true,
+		Since:      time.Minute * 5,
+		Timestamps: true,
+		Filter:     regexp.MustCompile("ERROR|WARN"),
+	}
+
+	assert.DeepEqual(t, options.podLogOptions("api", ptr.Int64(10)), &v1.PodLogOptions{
+		Container:    "api",
+		Follow:       true,
+		TailLines:    ptr.Int64(10),
+		Timestamps:   true,
+		SinceSeconds: ptr.Int64(300),
+	})
+	assert.Equal(t, options.matches("ERROR something failed"), true)
+	assert.Equal(t, options.matches("INFO started"), false)
+
+	running := &v1.ContainerStatus{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+	crashed := &v1.ContainerStatus{
+		State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}},
+		LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}},
+	}
+	assert.Equal(t, options.hasLogs(running), true)
+	assert.Equal(t, options.hasLogs(crashed), false)
+
+	// Previous logs are never followed
+	options.Previous = true
+	assert.Equal(t, options.podLogOptions("api", nil).Follow, false)
+	assert.Equal(t, options.hasLogs(running), false)
+	assert.Equal(t, options.hasLogs(crashed), true)
+}
+
+func TestWaitForRestart(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: types.UID("api")},
+		Spec:       v1.PodSpec{RestartPolicy: v1.RestartPolicyAlways},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "api", RestartCount: 1, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	_, err := client.CoreV1().Pods("default").Create(pod)
+	assert.NilError(t, err, "Error creating pod")
+
+	// The restarted container is returned
+	restarted := pod.DeepCopy()
+	restarted.Status.ContainerStatuses[0].RestartCount = 2
+	_, err = client.CoreV1().Pods("default").Update(restarted)
+	assert.NilError(t, err, "Error updating pod")
+
+	restartedPod, err := waitForRestart(client, pod, "api")
+	assert.NilError(t, err, "Error waiting for restart")
+	assert.Assert(t, restartedPod != nil, "Restarted pod is nil")
+	assert.Equal(t, restartedPod.Status.ContainerStatuses[0].RestartCount, int32(2))
+
+	// Completed containers are not restarted
+	completed := restarted.DeepCopy()
+	completed.Spec.RestartPolicy = v1.RestartPolicyNever
+	completed.Status.ContainerStatuses[0].State = v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}
+	_, err = client.CoreV1().Pods("default").Update(completed)
+	assert.NilError(t, err, "Error updating pod")
+
+	restartedPod, err = waitForRestart(client, restarted, "api")
+	assert.NilError(t, err, "Error waiting for restart")
+	assert.Assert(t, restartedPod == nil, "Completed container should not be restarted")
+
+	// Replaced pods are not returned
+	err = client.CoreV1().Pods("default").Delete("api", nil)
+	assert.NilError(t, err, "Error deleting pod")
+
+	restartedPod, err = waitForRestart(client, restarted, "api")
+	assert.NilError(t, err, "Error waiting for restart")
+	assert.Assert(t, restartedPod == nil, "Replaced pod should not be returned")
+}
@@ -1,93 +1,516 @@
 package services
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/sync/util"
+	"github.com/pkg/errors"
 )
 
+// Status values of a port forwarding
+const (
+	PortForwardingStatusForwarding = "Forwarding"
+	PortForwardingStatusWaiting    = "Waiting for pod"
+	PortForwardingStatusError      = "Error"
+)
+
+// portForwardingPodTimeout is the max time StartPortForwarding and RestartPortForwarding wait for a ready pod
+const portForwardingPodTimeout = time.Second * 120
+
+// PortForwarding is a port forwarding of dev.ports and the pod it forwards to. It is restarted in place if its pod is replaced
+// or the connection is lost
+type PortForwarding struct {
+	Config    *latest.PortForwardingConfig
+	Pod       *v1.Pod
+	Forwarder *portforward.PortForwarder
+
+	// Ports are the forwarded ports as local:remote, where the local port is the selected free port for auto and ranges
+	Ports []string
+
+	// ReversePorts are the reverse forwarded ports as remote:local
+	ReversePorts []string
+
+	// ReverseForwarders are the running reverse port forwardings of the reverseForward section
+	ReverseForwarders []io.Closer
+
+	// Status is the live status of the port forwarding and Error the reason if the status is PortForwardingStatusError
+	Status string
+	Error  error
+
+	index int
+
+	// stopped is true after Close was called, stopped port forwardings are not restarted anymore
+	stopped bool
+
+	// localPorts are the local ports selected for auto and ranges, which are kept if the port forwarding is restarted
+	localPorts map[int]int
+
+	// lost is closed if the port forwarder or a reverse port forwarder lost the connection to the pod
+	lost     chan struct{}
+	lostOnce *sync.Once
+
+	mutex sync.Mutex
+}
+
+// Close stops the port forwarding
+func (p *PortForwarding) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.stopped = true
+	p.close()
+}
+
+func (p *PortForwarding) close() {
+	if p.Forwarder != nil {
+		p.Forwarder.Close()
+	}
+	for _, reverseForwarder := range p.ReverseForwarders {
+		reverseForwarder.Close()
+	}
+
+	p.Pod = nil
+	p.Forwarder = nil
+	p.Ports = nil
+	p.ReversePorts = nil
+	p.ReverseForwarders = nil
+}
+
 // StartPortForwarding starts the port forwarding functionality
-func StartPortForwarding(config *latest.Config, client kubernetes.Interface, log log.Logger) ([]*portforward.PortForwarder, error) {
+func StartPortForwarding(config *latest.Config, client kubernetes.Interface, log log.Logger) ([]*PortForwarding, error) {
 	if config.Dev.Ports != nil {
-		portforwarder := make([]*portforward.PortForwarder, 0, len(*config.Dev.Ports))
-
-		for portConfigIndex, portForwarding := range *config.Dev.Ports {
-			selector, err := targetselector.NewTargetSelector(config, &targetselector.SelectorParameter{
-				ConfigParameter: targetselector.ConfigParameter{
-					Selector:      portForwarding.Selector,
-					Namespace:     portForwarding.Namespace,
-					LabelSelector: portForwarding.LabelSelector,
-				},
-			}, false)
-			if err != nil {
-				return nil, fmt.Errorf("Error creating target selector: %v", err)
+		portForwardings := make([]*PortForwarding, 0, len(*config.Dev.Ports))
+
+		for portConfigIndex, portForwardingConfig := range *config.Dev.Ports {
+			portForwarding := &PortForwarding{
+				Config:     portForwardingConfig,
+				index:      portConfigIndex,
+				localPorts: map[int]int{},
 			}
 
-			log.StartWait("Port-Forwarding: Waiting for pods...")
-			pod, err := selector.GetPod(client)
-			log.StopWait()
+			err := portForwarding.start(config, client, portForwardingPodTimeout, log)
 			if err != nil {
-				return nil, fmt.Errorf("Error starting port-forwarding: Unable to list devspace pods: %s", err.Error())
-			} else if pod != nil {
-				ports := make([]string, len(*portForwarding.PortMappings))
-				addresses := make([]string, len(*portForwarding.PortMappings))
-
-				for index, value := range *portForwarding.PortMappings {
-					if value.LocalPort == nil {
-						return nil, fmt.Errorf("port is not defined in portmapping %d:%d", portConfigIndex, index)
-					}
-
-					localPort := strconv.Itoa(*value.LocalPort)
-					remotePort := localPort
-					if value.RemotePort != nil {
-						remotePort = strconv.Itoa(*value.RemotePort)
-					}
-
-					ports[index] = localPort + ":" + remotePort
-					if value.BindAddress == nil {
-						addresses[index] = "127.0.0.1"
-					} else {
-						addresses[index] = *value.BindAddress
-					}
+				for _, started := range portForwardings {
+					started.Close()
 				}
 
-				readyChan := make(chan struct{})
+				return nil, err
+			}
 
-				pf, err := kubectl.NewPortForwarder(config, client, pod, ports, addresses, make(chan struct{}), readyChan)
-				if err != nil {
-					return nil, fmt.Errorf("Error starting port forwarding: %v", err)
-				}
+			portForwardings = append(portForwardings, portForwarding)
+		}
 
-				go func() {
-					err := pf.ForwardPorts()
-					if err != nil {
-						log.Errorf("Error forwarding ports: %v", err)
-					}
-				}()
-
-				// Wait till forwarding is ready
-				select {
-				case <-readyChan:
-					log.Donef("Port forwarding started on %s", strings.Join(ports, ", "))
-
-					portforwarder = append(portforwarder, pf)
-				case <-time.After(20 * time.Second):
-					return nil, fmt.Errorf("Timeout waiting for port forwarding to start")
-				}
+		return portForwardings, nil
+	}
+
+	return nil, nil
+}
+
+// RestartPortForwarding restarts the port forwardings whose pods were replaced, e.g. by a redeployment, and the port
+// forwardings that did not find a pod before. The other port forwardings keep running
+func RestartPortForwarding(config *latest.Config, client kubernetes.Interface, portForwardings []*PortForwarding, log log.Logger) error {
+	for _, portForwarding := range portForwardings {
+		portForwarding.mutex.Lock()
+		if portForwarding.stopped == false && portForwarding.needsRestart(config, client) {
+			portForwarding.close()
+
+			err := portForwarding.start(config, client, portForwardingPodTimeout, log)
+			if err != nil {
+				portForwarding.mutex.Unlock()
+				return err
 			}
 		}
+		portForwarding.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// GetPortForwardingTarget returns a description of the pods the port forwarding forwards to, e.g. service api
+func GetPortForwardingTarget(portForwarding *latest.PortForwardingConfig) string {
+	if portForwarding.Service != nil {
+		return "service " + *portForwarding.Service
+	} else if portForwarding.Deployment != nil {
+		return "deployment " + *portForwarding.Deployment
+	} else if portForwarding.Selector != nil {
+		return "selector " + *portForwarding.Selector
+	} else if portForwarding.LabelSelector != nil {
+		labels := make([]string, 0, len(*portForwarding.LabelSelector))
+		for key, value := range *portForwarding.LabelSelector {
+			labels = append(labels, key+"="+*value)
+		}
 
-		return portforwarder, nil
+		sort.Strings(labels)
+		return "labelSelector " + strings.Join(labels, ",")
 	}
 
-	return nil, nil
+	return "default selector"
+}
+
+// connectionLost returns if the port forwarder or a reverse port forwarder lost the connection to the pod
+func (p *PortForwarding) connectionLost() bool {
+	if p.lost == nil {
+		return false
+	}
+
+	select {
+	case <-p.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// needsRestart returns if the port forwarding has no pod, lost the connection, its pod was replaced or if its pod is not ready
+// anymore while another pod is ready
+func (p *PortForwarding) needsRestart(config *latest.Config, client kubernetes.Interface) bool {
+	if p.Pod == nil || p.connectionLost() {
+		return true
+	}
+
+	currentPod, err := client.CoreV1().Pods(p.Pod.Namespace).Get(p.Pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return kerrors.IsNotFound(err)
+	} else if currentPod.UID != p.Pod.UID || currentPod.DeletionTimestamp != nil {
+		return true
+	} else if kubectl.IsPodReady(currentPod) {
+		return false
+	}
+
+	// The port forwarding stays at a pod that is not ready, e.g. because its container restarts, until another pod is ready
+	readyPod, _, err := p.findPod(config, client)
+	return err == nil && readyPod != nil && readyPod.UID != p.Pod.UID
+}
+
+// selectPod waits up to maxWaiting for a ready pod of the port forwarding. If the port forwarding targets a service, the service
+// is returned as well. The pod is nil if no pod got ready
+func (p *PortForwarding) selectPod(config *latest.Config, client kubernetes.Interface, maxWaiting time.Duration) (*v1.Pod, *v1.Service, error) {
+	deadline := time.Now().Add(maxWaiting)
+	for {
+		pod, service, err := p.findPod(config, client)
+		if err != nil || pod != nil || time.Now().After(deadline) {
+			return pod, service, err
+		}
+
+		time.Sleep(time.Second * 2)
+	}
+}
+
+// findPod returns a ready pod of the service, deployment, selector or label selector of the port forwarding or nil if no pod is ready
+func (p *PortForwarding) findPod(config *latest.Config, client kubernetes.Interface) (*v1.Pod, *v1.Service, error) {
+	selectorParameter := &targetselector.SelectorParameter{
+		ConfigParameter: targetselector.ConfigParameter{
+			Selector:      p.Config.Selector,
+			Namespace:     p.Config.Namespace,
+			LabelSelector: p.Config.LabelSelector,
+		},
+	}
+
+	namespace, err := selectorParameter.GetNamespace(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.Config.Service != nil {
+		service, err := client.CoreV1().Services(namespace).Get(*p.Config.Service, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, nil, nil
+			}
+
+			return nil, nil, err
+		}
+
+		pod, err := kubectl.GetServiceEndpointPod(client, service.Name, namespace)
+		return pod, service, err
+	}
+
+	labelSelector := ""
+	if p.Config.Deployment != nil {
+		labelSelector, err = kubectl.GetDeploymentLabelSelector(client, *p.Config.Deployment, namespace)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, nil, nil
+			}
+
+			return nil, nil, err
+		}
+	} else {
+		configLabelSelector, err := selectorParameter.GetLabelSelector(config)
+		if err != nil {
+			return nil, nil, err
+		} else if configLabelSelector == nil {
+			return nil, nil, fmt.Errorf("Couldn't select pods, because no selector, labelSelector, service or deployment was specified")
+		}
+
+		labelSelector = *configLabelSelector
+	}
+
+	pod, err := kubectl.GetNewestReadyPod(client, labelSelector, namespace)
+	return pod, nil, err
+}
+
+// start selects a ready pod and starts the port forwarding and reverse port forwarding to it. If no pod is ready after
+// maxWaiting, the port forwarding waits for a pod
+func (p *PortForwarding) start(config *latest.Config, client kubernetes.Interface, maxWaiting time.Duration, log log.Logger) error {
+	target := GetPortForwardingTarget(p.Config)
+	if maxWaiting > 0 {
+		log.StartWait("Port-Forwarding: Waiting for pods of " + target + "...")
+	}
+	pod, service, err := p.selectPod(config, client, maxWaiting)
+	log.StopWait()
+	if err != nil {
+		p.Status, p.Error = PortForwardingStatusError, err
+		return fmt.Errorf("Error starting port-forwarding: Unable to select a pod of %s: %s", target, err.Error())
+	} else if pod == nil {
+		if maxWaiting > 0 {
+			log.Warnf("Port-Forwarding: No pod of %s is ready, the ports will be forwarded once a pod is ready", target)
+		}
+
+		p.Status, p.Error = PortForwardingStatusWaiting, nil
+		return nil
+	}
+
+	p.Pod = pod
+	p.lost = make(chan struct{})
+	p.lostOnce = &sync.Once{}
+
+	lost := p.lost
+	lostOnce := p.lostOnce
+	onLost := func() {
+		lostOnce.Do(func() { close(lost) })
+	}
+
+	err = p.startForwarding(config, client, service, onLost, log)
+	if err == nil {
+		err = p.startReverseForwarding(config, onLost, log)
+	}
+	if err != nil {
+		p.close()
+		p.Status, p.Error = PortForwardingStatusError, err
+		return err
+	}
+
+	p.Status, p.Error = PortForwardingStatusForwarding, nil
+	return nil
+}
+
+// startForwarding forwards the local ports of the forward section to the pod
+func (p *PortForwarding) startForwarding(config *latest.Config, client kubernetes.Interface, service *v1.Service, onLost func(), log log.Logger) error {
+	if p.Config.PortMappings == nil || len(*p.Config.PortMappings) == 0 {
+		return nil
+	}
+
+	ports := make([]string, len(*p.Config.PortMappings))
+	addresses := make([]string, len(*p.Config.PortMappings))
+
+	for index, value := range *p.Config.PortMappings {
+		addresses[index] = getBindAddress(value)
+
+		localPort, ok := p.localPorts[index]
+		if ok == false {
+			var err error
+
+			localPort, err = selectLocalPort(value, addresses[index])
+			if err != nil {
+				return fmt.Errorf("Error in portmapping %d:%d: %v", p.index, index, err)
+			}
+
+			p.localPorts[index] = localPort
+		}
+
+		remotePort := localPort
+		if value.RemotePort != nil {
+			remotePort = *value.RemotePort
+		} else if value.LocalPort == nil {
+			return fmt.Errorf("remotePort is not defined in portmapping %d:%d, which is required if port is %s or a range", p.index, index, latest.AutoPort)
+		}
+
+		// The port of a service is forwarded to the target port of the pod
+		if service != nil {
+			remotePort = kubectl.GetServiceTargetPort(service, p.Pod, remotePort)
+		}
+
+		ports[index] = strconv.Itoa(localPort) + ":" + strconv.Itoa(remotePort)
+	}
+
+	readyChan := make(chan struct{})
+
+	pf, err := kubectl.NewPortForwarder(config, client, p.Pod, ports, addresses, make(chan struct{}), readyChan)
+	if err != nil {
+		return fmt.Errorf("Error starting port forwarding: %v", err)
+	}
+
+	go func() {
+		err := pf.ForwardPorts()
+		if err != nil {
+			log.Errorf("Error forwarding ports: %v", err)
+		}
+
+		onLost()
+	}()
+
+	// Wait till forwarding is ready
+	select {
+	case <-readyChan:
+		log.Donef("Port forwarding started on %s to pod %s", strings.Join(ports, ", "), p.Pod.Name)
+	case <-time.After(20 * time.Second):
+		pf.Close()
+		return fmt.Errorf("Timeout waiting for port forwarding to start")
+	}
+
+	p.Forwarder = pf
+	p.Ports = ports
+	return nil
+}
+
+// startReverseForwarding forwards the ports of the reverseForward section in the pod to the local ports
+func (p *PortForwarding) startReverseForwarding(config *latest.Config, onLost func(), log log.Logger) error {
+	if p.Config.ReversePortMappings == nil || len(*p.Config.ReversePortMappings) == 0 {
+		return nil
+	}
+
+	restConfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// The sync helper that listens in the pod is injected into the first container
+	container := p.Pod.Spec.Containers[0].Name
+	err = injectSync(restConfig, p.Pod, container)
+	if err != nil {
+		return errors.Wrap(err, "start reverse port forwarding")
+	}
+
+	for index, value := range *p.Config.ReversePortMappings {
+		if value.LocalPort == nil {
+			return fmt.Errorf("port must be a number in reverse portmapping %d:%d", p.index, index)
+		}
+
+		remotePort := *value.LocalPort
+		if value.RemotePort != nil {
+			remotePort = *value.RemotePort
+		}
+
+		localAddress := net.JoinHostPort(getBindAddress(value), strconv.Itoa(*value.LocalPort))
+		p.ReverseForwarders = append(p.ReverseForwarders, startReversePortForwarding(restConfig, p.Pod, container, remotePort, localAddress, onLost, log))
+		p.ReversePorts = append(p.ReversePorts, strconv.Itoa(remotePort)+":"+strconv.Itoa(*value.LocalPort))
+	}
+
+	log.Donef("Reverse port forwarding started on %s from pod %s", strings.Join(p.ReversePorts, ", "), p.Pod.Name)
+	return nil
+}
+
+// getBindAddress returns the local address of the port mapping
+func getBindAddress(portMapping *latest.PortMapping) string {
+	if portMapping.BindAddress == nil {
+		return "127.0.0.1"
+	}
+
+	return *portMapping.BindAddress
+}
+
+// selectLocalPort returns the local port of the port mapping. If port is auto, a free port is selected and if port is a range,
+// the first free port of the range is selected
+func selectLocalPort(portMapping *latest.PortMapping, bindAddress string) (int, error) {
+	if portMapping.LocalPort != nil {
+		return *portMapping.LocalPort, nil
+	} else if portMapping.LocalPortRange == nil {
+		return 0, fmt.Errorf("port is not defined")
+	}
+
+	first, last, err := latest.ParsePortRange(*portMapping.LocalPortRange)
+	if err != nil {
+		return 0, err
+	}
+
+	for port := first; port <= last; port++ {
+		listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.Itoa(port)))
+		if err != nil {
+			continue
+		}
+
+		selectedPort := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+		return selectedPort, nil
+	}
+
+	return 0, fmt.Errorf("no free port in range %s", *portMapping.LocalPortRange)
+}
+
+// reverseForwarder forwards the connections to a port in the pod through the sync helper to a local address
+type reverseForwarder struct {
+	stdinWriter  *io.PipeWriter
+	stdoutReader *io.PipeReader
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Close stops the reverse port forwarding
+func (r *reverseForwarder) Close() error {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+
+		// Closing stdin stops the sync helper in the container
+		r.stdinWriter.Close()
+		r.stdoutReader.Close()
+	})
+
+	return nil
+}
+
+// startReversePortForwarding starts the sync helper in the container, which listens on the remote port and sends the connections
+// through stdin and stdout of the exec to the local address. onLost is called when the exec stopped
+func startReversePortForwarding(restConfig *rest.Config, pod *v1.Pod, container string, remotePort int, localAddress string, onLost func(), log log.Logger) io.Closer {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+
+	forwarder := &reverseForwarder{
+		stdinWriter:  stdinWriter,
+		stdoutReader: stdoutReader,
+		closed:       make(chan struct{}),
+	}
+
+	tunnel := util.NewTunnel(stdoutReader, stdinWriter)
+	tunnel.Dial = func() (net.Conn, error) {
+		return net.Dial("tcp", localAddress)
+	}
+
+	go func() {
+		stderr := &bytes.Buffer{}
+		err := kubectl.ExecStream(restConfig, pod, container, []string{SyncHelperContainerPath, "--reverse-forward", strconv.Itoa(remotePort)}, false, stdinReader, stdoutWriter, stderr)
+		if err != nil {
+			select {
+			case <-forwarder.closed:
+			default:
+				log.Errorf("Reverse port forwarding %d -> %s to pod %s/%s stopped: %s %v", remotePort, localAddress, pod.Namespace, pod.Name, stderr.String(), err)
+			}
+		}
+
+		stdoutWriter.Close()
+		onLost()
+	}()
+
+	go tunnel.Run()
+	return forwarder
 }
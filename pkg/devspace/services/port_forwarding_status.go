@@ -0,0 +1,160 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PortForwardingStatusPath is the file devspace dev writes the live status of the port forwardings to
+var PortForwardingStatusPath = ".devspace/ports.yaml"
+
+// portForwardingCheckInterval is the interval in which the port forwardings are checked and the status file is updated
+const portForwardingCheckInterval = time.Second * 2
+
+// portForwardingStatusTimeout is the time after which a status file that was not updated anymore is outdated, e.g. because
+// devspace dev was killed
+const portForwardingStatusTimeout = portForwardingCheckInterval * 5
+
+// PortForwardingStatusFile is the content of the status file
+type PortForwardingStatusFile struct {
+	// Updated is the time of the last check of the port forwardings
+	Updated time.Time `yaml:"updated"`
+
+	PortForwardings []*PortForwardingStatus `yaml:"portForwardings"`
+}
+
+// PortForwardingStatus is the live status of a port forwarding of dev.ports
+type PortForwardingStatus struct {
+	Target       string   `yaml:"target"`
+	Pod          string   `yaml:"pod,omitempty"`
+	Ports        []string `yaml:"ports,omitempty"`
+	ReversePorts []string `yaml:"reversePorts,omitempty"`
+	Status       string   `yaml:"status"`
+	Error        string   `yaml:"error,omitempty"`
+}
+
+// WatchPortForwarding checks the port forwardings until stop is closed. Port forwardings that lost the connection or whose pod was
+// replaced or is not ready anymore are restarted with another ready pod. The live status is written to PortForwardingStatusPath
+// and the file is removed when stop is closed
+func WatchPortForwarding(config *latest.Config, client kubernetes.Interface, portForwardings []*PortForwarding, stop <-chan struct{}, log log.Logger) {
+	defer os.Remove(PortForwardingStatusPath)
+
+	err := writePortForwardingStatus(PortForwardingStatusPath, portForwardings)
+	if err != nil {
+		log.Warnf("Error writing port forwarding status: %v", err)
+	}
+
+	ticker := time.NewTicker(portForwardingCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for _, portForwarding := range portForwardings {
+				checkPortForwarding(config, client, portForwarding, log)
+			}
+
+			err := writePortForwardingStatus(PortForwardingStatusPath, portForwardings)
+			if err != nil {
+				log.Warnf("Error writing port forwarding status: %v", err)
+			}
+		}
+	}
+}
+
+// checkPortForwarding restarts the port forwarding with a ready pod if necessary
+func checkPortForwarding(config *latest.Config, client kubernetes.Interface, portForwarding *PortForwarding, log log.Logger) {
+	portForwarding.mutex.Lock()
+	defer portForwarding.mutex.Unlock()
+
+	if portForwarding.stopped || portForwarding.needsRestart(config, client) == false {
+		return
+	}
+
+	if portForwarding.Pod != nil {
+		log.Infof("Port-Forwarding: Pod %s of %s is not available anymore, selecting another pod", portForwarding.Pod.Name, GetPortForwardingTarget(portForwarding.Config))
+	}
+
+	portForwarding.close()
+
+	// Errors are shown in the status and the port forwarding is tried again with the next check
+	portForwarding.start(config, client, 0, log)
+}
+
+// getStatus returns the live status of the port forwarding
+func (p *PortForwarding) getStatus() *PortForwardingStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	status := &PortForwardingStatus{
+		Target:       GetPortForwardingTarget(p.Config),
+		Ports:        p.Ports,
+		ReversePorts: p.ReversePorts,
+		Status:       p.Status,
+	}
+	if p.Pod != nil {
+		status.Pod = p.Pod.Name
+	}
+	if p.Error != nil {
+		status.Error = p.Error.Error()
+	}
+
+	return status
+}
+
+func writePortForwardingStatus(path string, portForwardings []*PortForwarding) error {
+	statusFile := &PortForwardingStatusFile{
+		Updated:         time.Now(),
+		PortForwardings: make([]*PortForwardingStatus, 0, len(portForwardings)),
+	}
+	for _, portForwarding := range portForwardings {
+		statusFile.PortForwardings = append(statusFile.PortForwardings, portForwarding.getStatus())
+	}
+
+	out, err := yaml.Marshal(statusFile)
+	if err != nil {
+		return err
+	}
+
+	// The status is replaced at once, so that it is never read partially
+	err = fsutil.WriteToFile(out, path+".tmp")
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// LoadPortForwardingStatus loads the live status of the port forwardings written by devspace dev. It returns nil if devspace
+// dev is not running
+func LoadPortForwardingStatus(path string) (*PortForwardingStatusFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	statusFile := &PortForwardingStatusFile{}
+	err = yaml.Unmarshal(data, statusFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(statusFile.Updated) > portForwardingStatusTimeout {
+		return nil, nil
+	}
+
+	return statusFile, nil
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSelectLocalPort(t *testing.T) {
+	port, err := selectLocalPort(&latest.PortMapping{LocalPort: ptr.Int(8080)}, "127.0.0.1")
+	assert.NilError(t, err, "Error selecting configured port")
+	assert.Equal(t, port, 8080)
+
+	port, err = selectLocalPort(&latest.PortMapping{LocalPortRange: ptr.String(latest.AutoPort)}, "127.0.0.1")
+	assert.NilError(t, err, "Error selecting auto port")
+	assert.Assert(t, port > 0, "No port selected for auto")
+
+	// A used port of the range is skipped
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err, "Error listening")
+	defer listener.Close()
+
+	usedPort := listener.Addr().(*net.TCPAddr).Port
+	port, err = selectLocalPort(&latest.PortMapping{LocalPortRange: ptr.String(strconv.Itoa(usedPort) + "-" + strconv.Itoa(usedPort+1))}, "127.0.0.1")
+	if err == nil {
+		assert.Equal(t, port, usedPort+1)
+	}
+
+	_, err = selectLocalPort(&latest.PortMapping{LocalPortRange: ptr.String(strconv.Itoa(usedPort))}, "127.0.0.1")
+	assert.Error(t, err, "no free port in range "+strconv.Itoa(usedPort))
+
+	_, err = selectLocalPort(&latest.PortMapping{}, "127.0.0.1")
+	assert.Error(t, err, "port is not defined")
+}
+
+func createPortForwardingTestPod(t *testing.T, client kubernetes.Interface, name string, labels map[string]string, ready bool) *v1.Pod {
+	readyStatus := v1.ConditionFalse
+	if ready {
+		readyStatus = v1.ConditionTrue
+	}
+
+	pod, err := client.CoreV1().Pods("default").Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), Labels: labels},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "server"}}},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			Conditions:        []v1.PodCondition{{Type: v1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []v1.ContainerStatus{{Name: "server", Ready: ready, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+		},
+	})
+	assert.NilError(t, err, "Error creating pod")
+
+	return pod
+}
+
+func TestFindPod(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	createPortForwardingTestPod(t, client, "api-1", map[string]string{"app": "api"}, true)
+	createPortForwardingTestPod(t, client, "worker-1", map[string]string{"app": "worker"}, false)
+
+	// Label selector
+	portForwarding := &PortForwarding{Config: &latest.PortForwardingConfig{Namespace: ptr.String("default"), LabelSelector: &map[string]*string{"app": ptr.String("api")}}}
+	pod, service, err := portForwarding.findPod(nil, client)
+	assert.NilError(t, err, "Error finding pod of label selector")
+	assert.Equal(t, pod.Name, "api-1")
+	assert.Assert(t, service == nil, "Service returned for label selector")
+
+	// Pods that are not ready are not selected
+	portForwarding.Config.LabelSelector = &map[string]*string{"app": ptr.String("worker")}
+	pod, _, err = portForwarding.findPod(nil, client)
+	assert.NilError(t, err, "Error finding pod of label selector")
+	assert.Assert(t, pod == nil, "Pod that is not ready was selected")
+
+	// Deployment
+	_, err = client.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+
+	portForwarding = &PortForwarding{Config: &latest.PortForwardingConfig{Namespace: ptr.String("default"), Deployment: ptr.String("api")}}
+	pod, _, err = portForwarding.findPod(nil, client)
+	assert.NilError(t, err, "Error finding pod of deployment")
+	assert.Equal(t, pod.Name, "api-1")
+
+	// Service
+	portForwarding = &PortForwarding{Config: &latest.PortForwardingConfig{Namespace: ptr.String("default"), Service: ptr.String("api")}}
+	pod, _, err = portForwarding.findPod(nil, client)
+	assert.NilError(t, err, "Error finding pod of missing service")
+	assert.Assert(t, pod == nil, "Pod selected for missing service")
+
+	_, err = client.CoreV1().Services("default").Create(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}})
+	assert.NilError(t, err, "Error creating service")
+	_, err = client.CoreV1().Endpoints("default").Create(&v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Subsets: []v1.EndpointSubset{{
+			Addresses: []v1.EndpointAddress{{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "api-1"}}},
+		}},
+	})
+	assert.NilError(t, err, "Error creating endpoints")
+
+	pod, service, err = portForwarding.findPod(nil, client)
+	assert.NilError(t, err, "Error finding pod of service")
+	assert.Equal(t, pod.Name, "api-1")
+	assert.Equal(t, service.Name, "api")
+}
+
+func TestNeedsRestart(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	pod := createPortForwardingTestPod(t, client, "api-1", map[string]string{"app": "api"}, true)
+
+	portForwarding := &PortForwarding{
+		Config: &latest.PortForwardingConfig{Namespace: ptr.String("default"), LabelSelector: &map[string]*string{"app": ptr.String("api")}},
+		Pod:    pod,
+		lost:   make(chan struct{}),
+	}
+	assert.Equal(t, portForwarding.needsRestart(nil, client), false)
+
+	// A pod that is not ready is kept until another pod is ready
+	notReadyPod := pod.DeepCopy()
+	notReadyPod.Status.Conditions[0].Status = v1.ConditionFalse
+	_, err := client.CoreV1().Pods("default").Update(notReadyPod)
+	assert.NilError(t, err, "Error updating pod")
+	assert.Equal(t, portForwarding.needsRestart(nil, client), false)
+
+	createPortForwardingTestPod(t, client, "api-2", map[string]string{"app": "api"}, true)
+	assert.Equal(t, portForwarding.needsRestart(nil, client), true)
+
+	// Deleted pods and lost connections are restarted
+	portForwarding.Pod = &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "default"}}
+	assert.Equal(t, portForwarding.needsRestart(nil, client), true)
+
+	portForwarding.Pod, err = client.CoreV1().Pods("default").Get("api-2", metav1.GetOptions{})
+	assert.NilError(t, err, "Error getting pod")
+	assert.Equal(t, portForwarding.needsRestart(nil, client), false)
+
+	close(portForwarding.lost)
+	assert.Equal(t, portForwarding.needsRestart(nil, client), true)
+}
+
+func TestPortForwardingStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err, "Error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".devspace", "ports.yaml")
+	statusFile, err := LoadPortForwardingStatus(path)
+	assert.NilError(t, err, "Error loading missing status")
+	assert.Assert(t, statusFile == nil, "Status loaded without status file")
+
+	portForwardings := []*PortForwarding{
+		{
+			Config: &latest.PortForwardingConfig{Service: ptr.String("api")},
+			Pod:    &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1"}},
+			Ports:  []string{"41235:8080"},
+			Status: PortForwardingStatusForwarding,
+		},
+		{
+			Config: &latest.PortForwardingConfig{LabelSelector: &map[string]*string{"app": ptr.String("worker"), "tier": ptr.String("backend")}},
+			Status: PortForwardingStatusWaiting,
+		},
+	}
+
+	err = writePortForwardingStatus(path, portForwardings)
+	assert.NilError(t, err, "Error writing status")
+
+	statusFile, err = LoadPortForwardingStatus(path)
+	assert.NilError(t, err, "Error loading status")
+	assert.DeepEqual(t, statusFile.PortForwardings, []*PortForwardingStatus{
+		{Target: "service api", Pod: "api-1", Ports: []string{"41235:8080"}, Status: PortForwardingStatusForwarding},
+		{Target: "labelSelector app=worker,tier=backend", Status: PortForwardingStatusWaiting},
+	})
+
+	// An outdated status means that devspace dev is not running anymore
+	statusFile.Updated = time.Now().Add(-time.Minute)
+	out, err := yaml.Marshal(statusFile)
+	assert.NilError(t, err, "Error marshaling status")
+	err = ioutil.WriteFile(path, out, 0666)
+	assert.NilError(t, err, "Error writing status")
+
+	statusFile, err = LoadPortForwardingStatus(path)
+	assert.NilError(t, err, "Error loading outdated status")
+	assert.Assert(t, statusFile == nil, "Outdated status loaded")
+}
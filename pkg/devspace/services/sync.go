@@ -99,10 +99,24 @@ func StartSyncFromCmd(config *latest.Config, cmdParameter targetselector.CmdPara
 	return nil
 }
 
+// SyncSession is a sync of dev.sync and the pod it syncs with
+type SyncSession struct {
+	Config *latest.SyncConfig
+	Pod    *v1.Pod
+	Sync   *sync.Sync
+}
+
+// Stop stops the sync
+func (s *SyncSession) Stop() {
+	if s.Sync != nil {
+		s.Sync.Stop(nil)
+	}
+}
+
 // StartSync starts the syncing functionality
-func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*sync.Sync, error) {
+func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*SyncSession, error) {
 	if config.Dev.Sync == nil {
-		return []*sync.Sync{}, nil
+		return []*SyncSession{}, nil
 	}
 
 	restConfig, err := kubectl.GetRestConfig(config)
@@ -115,57 +129,94 @@ func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*sync
 		return nil, errors.Wrap(err, "create new kubernetes client")
 	}
 
-	syncClients := make([]*sync.Sync, 0, len(*config.Dev.Sync))
+	syncSessions := make([]*SyncSession, 0, len(*config.Dev.Sync))
 	for _, syncConfig := range *config.Dev.Sync {
-		selector, err := targetselector.NewTargetSelector(config, &targetselector.SelectorParameter{
-			ConfigParameter: targetselector.ConfigParameter{
-				Selector:      syncConfig.Selector,
-				Namespace:     syncConfig.Namespace,
-				LabelSelector: syncConfig.LabelSelector,
-				ContainerName: syncConfig.ContainerName,
-			},
-		}, false)
+		syncSession, err := startSyncSession(restConfig, client, config, syncConfig, verboseSync, log)
 		if err != nil {
-			return nil, fmt.Errorf("Error creating target selector: %v", err)
+			return nil, err
 		}
 
-		log.StartWait("Sync: Waiting for pods...")
-		pod, container, err := selector.GetContainer(client)
-		log.StopWait()
-		if err != nil {
-			return nil, fmt.Errorf("Unable to start sync, because an error occured during pod selection: %v", err)
-		}
+		syncSessions = append(syncSessions, syncSession)
+	}
 
-		log.StartWait("Starting sync...")
-		syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verboseSync, nil, nil)
-		log.StopWait()
-		if err != nil {
-			return nil, errors.Wrap(err, "start sync")
+	return syncSessions, nil
+}
+
+// RestartSync restarts the syncs whose pods were replaced, e.g. by a redeployment. The other syncs keep running
+func RestartSync(config *latest.Config, syncSessions []*SyncSession, verboseSync bool, log log.Logger) error {
+	restConfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "get rest config")
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "create new kubernetes client")
+	}
+
+	for idx, syncSession := range syncSessions {
+		if kubectl.PodReplaced(client, syncSession.Pod) == false {
+			continue
 		}
 
-		err = syncClient.Start()
+		syncSession.Stop()
+
+		syncSessions[idx], err = startSyncSession(restConfig, client, config, syncSession.Config, verboseSync, log)
 		if err != nil {
-			return nil, fmt.Errorf("Sync error: %v", err)
+			return err
 		}
+	}
 
-		containerPath := "."
-		if syncConfig.ContainerPath != nil {
-			containerPath = *syncConfig.ContainerPath
-		}
+	return nil
+}
 
-		log.Donef("Sync started on %s <-> %s (Pod: %s/%s)", syncClient.LocalPath, containerPath, pod.Namespace, pod.Name)
+func startSyncSession(restConfig *rest.Config, client kubernetes.Interface, config *latest.Config, syncConfig *latest.SyncConfig, verboseSync bool, log log.Logger) (*SyncSession, error) {
+	selector, err := targetselector.NewTargetSelector(config, &targetselector.SelectorParameter{
+		ConfigParameter: targetselector.ConfigParameter{
+			Selector:      syncConfig.Selector,
+			Namespace:     syncConfig.Namespace,
+			LabelSelector: syncConfig.LabelSelector,
+			ContainerName: syncConfig.ContainerName,
+		},
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating target selector: %v", err)
+	}
 
-		if syncConfig.WaitInitialSync != nil && *syncConfig.WaitInitialSync == true {
-			log.StartWait("Sync: waiting for intial sync to complete")
-			<-syncClient.Options.UpstreamInitialSyncDone
-			<-syncClient.Options.DownstreamInitialSyncDone
-			log.StopWait()
-		}
+	log.StartWait("Sync: Waiting for pods...")
+	pod, container, err := selector.GetContainer(client)
+	log.StopWait()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to start sync, because an error occured during pod selection: %v", err)
+	}
 
-		syncClients = append(syncClients, syncClient)
+	log.StartWait("Starting sync...")
+	syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verboseSync, nil, nil)
+	log.StopWait()
+	if err != nil {
+		return nil, errors.Wrap(err, "start sync")
+	}
+
+	err = syncClient.Start()
+	if err != nil {
+		return nil, fmt.Errorf("Sync error: %v", err)
+	}
+
+	containerPath := "."
+	if syncConfig.ContainerPath != nil {
+		containerPath = *syncConfig.ContainerPath
+	}
+
+	log.Donef("Sync started on %s <-> %s (Pod: %s/%s)", syncClient.LocalPath, containerPath, pod.Namespace, pod.Name)
+
+	if syncConfig.WaitInitialSync != nil && *syncConfig.WaitInitialSync == true {
+		log.StartWait("Sync: waiting for intial sync to complete")
+		<-syncClient.Options.UpstreamInitialSyncDone
+		<-syncClient.Options.DownstreamInitialSyncDone
+		log.StopWait()
 	}
 
-	return syncClients, nil
+	return &SyncSession{Config: syncConfig, Pod: pod, Sync: syncClient}, nil
 }
 
 func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfig *latest.SyncConfig, verbose bool, syncDone chan bool, customLog log.Logger) (*sync.Sync, error) {
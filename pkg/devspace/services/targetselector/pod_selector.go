@@ -43,10 +43,14 @@ func SelectPod(client kubernetes.Interface, namespace string, labelSelector *str
 
 			podName := ""
 			if len(options) > 1 {
-				podName = survey.Question(&survey.QuestionOptions{
-					Question: *question,
-					Options:  options,
+				podName, err = survey.Question(&survey.QuestionOptions{
+					Question:           *question,
+					Options:            options,
+					NonInteractiveHint: NonInteractivePodHint,
 				})
+				if err != nil {
+					return nil, err
+				}
 			} else if len(options) == 1 {
 				podName = options[0]
 			} else {
@@ -86,10 +90,14 @@ func SelectPod(client kubernetes.Interface, namespace string, labelSelector *str
 
 		podName := ""
 		if len(options) > 1 {
-			podName = survey.Question(&survey.QuestionOptions{
-				Question: *question,
-				Options:  options,
+			podName, err = survey.Question(&survey.QuestionOptions{
+				Question:           *question,
+				Options:            options,
+				NonInteractiveHint: NonInteractivePodHint,
 			})
+			if err != nil {
+				return nil, err
+			}
 		} else if len(options) == 1 {
 			podName = options[0]
 		} else {
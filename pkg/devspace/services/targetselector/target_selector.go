@@ -21,6 +21,9 @@ const DefaultPodQuestion = "Select a pod"
 // DefaultContainerQuestion defines the default question for selecting a container
 const DefaultContainerQuestion = "Select a container"
 
+// NonInteractivePodHint tells the user how to select a pod in non-interactive mode
+const NonInteractivePodHint = "Please specify the pod with --pod or --label-selector"
+
 // TargetSelector is the struct that will select a target
 type TargetSelector struct {
 	PodQuestion       *string
@@ -139,10 +142,15 @@ func (t *TargetSelector) GetContainer(client kubernetes.Interface) (*v1.Pod, *v1
 			t.ContainerQuestion = ptr.String(DefaultContainerQuestion)
 		}
 
-		containerName := survey.Question(&survey.QuestionOptions{
-			Question: *t.ContainerQuestion,
-			Options:  options,
+		containerName, err := survey.Question(&survey.QuestionOptions{
+			Question:           *t.ContainerQuestion,
+			Options:            options,
+			NonInteractiveHint: "Please specify the container with --container",
 		})
+		if err != nil {
+			return nil, nil, err
+		}
+
 		for _, container := range pod.Spec.Containers {
 			if container.Name == containerName {
 				return pod, &container, nil
@@ -56,11 +56,19 @@ func StartTerminal(config *latest.Config, client kubernetes.Interface, cmdParame
 
 	log.Infof("Opening shell to pod:container %s:%s", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
 
+	streamErr := make(chan error, 1)
 	go func() {
-		interrupt <- kubectl.ExecStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, command, true, os.Stdin, os.Stdout, os.Stderr)
+		streamErr <- kubectl.ExecStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, command, true, os.Stdin, os.Stdout, os.Stderr)
 	}()
 
-	err = <-interrupt
+	// Errors sent to interrupt, e.g. to reload the dev mode, are returned as they are
+	select {
+	case err = <-interrupt:
+		upgradeRoundTripper.Close()
+		return 0, err
+	case err = <-streamErr:
+	}
+
 	upgradeRoundTripper.Close()
 	if err != nil {
 		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
@@ -2,12 +2,14 @@ package watch
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bmatcuk/doublestar"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/rjeczalik/notify"
 )
 
 // Callback is the function type
@@ -21,6 +23,15 @@ type Watcher struct {
 	Callback     Callback
 	Log          log.Logger
 
+	// Polling disables filesystem events and polls the paths every PollInterval instead
+	Polling bool
+
+	// Debounce is the time without further changes after which the collected changes are passed to the callback
+	Debounce time.Duration
+
+	// BatchWindow is the maximum time changes are collected before they are passed to the callback
+	BatchWindow time.Duration
+
 	startOnce sync.Once
 	closeOnce sync.Once
 
@@ -32,6 +43,8 @@ func New(paths []string, callback Callback, log log.Logger) (*Watcher, error) {
 	watcher := &Watcher{
 		Paths:        paths,
 		PollInterval: time.Second,
+		Debounce:     time.Millisecond * 100,
+		BatchWindow:  time.Second,
 		Callback:     callback,
 		FileMap:      make(map[string]os.FileInfo),
 		Log:          log,
@@ -47,32 +60,277 @@ func New(paths []string, callback Callback, log log.Logger) (*Watcher, error) {
 	return watcher, nil
 }
 
-// Start starts the watching process every second
+// Start starts watching the paths. Filesystem events are used if possible, otherwise the paths are polled every PollInterval
 func (w *Watcher) Start() {
 	w.startOnce.Do(func() {
-		go func() {
-			for {
+		changes := make(chan *changeSet, 10)
+
+		if w.Polling == false {
+			events, err := w.watchEvents()
+			if err == nil {
+				go w.handleEvents(events, changes)
+			} else {
+				w.Log.Warnf("Unable to watch filesystem events, will poll for changes instead: %v", err)
+				w.Polling = true
+			}
+		}
+
+		if w.Polling {
+			go w.poll(changes)
+		}
+
+		go w.batch(changes)
+	})
+}
+
+// changeSet holds the changed and deleted paths of a single update
+type changeSet struct {
+	changed []string
+	deleted []string
+}
+
+// batch collects changes until no change happened for Debounce or BatchWindow is exceeded and then calls the callback
+func (w *Watcher) batch(changes <-chan *changeSet) {
+	var (
+		order    = []string{}
+		deleted  = map[string]bool{}
+		debounce <-chan time.Time
+		window   <-chan time.Time
+	)
+
+	add := func(file string, isDeleted bool) {
+		if _, ok := deleted[file]; !ok {
+			order = append(order, file)
+		}
+
+		deleted[file] = isDeleted
+	}
+
+	for {
+		select {
+		case <-w.interrupt:
+			return
+		case change := <-changes:
+			for _, file := range change.changed {
+				add(file, false)
+			}
+			for _, file := range change.deleted {
+				add(file, true)
+			}
+
+			debounce = time.After(w.Debounce)
+			if window == nil {
+				window = time.After(w.BatchWindow)
+			}
+
+			continue
+		case <-debounce:
+		case <-window:
+		}
+
+		changed, removed := make([]string, 0, len(order)), make([]string, 0, 1)
+		for _, file := range order {
+			if deleted[file] {
+				removed = append(removed, file)
+			} else {
+				changed = append(changed, file)
+			}
+		}
+
+		order, deleted = []string{}, map[string]bool{}
+		debounce, window = nil, nil
+
+		err := w.Callback(changed, removed)
+		if err != nil {
+			w.Log.Errorf("Error during watcher callback: %v", err)
+			return
+		}
+	}
+}
+
+func (w *Watcher) poll(changes chan<- *changeSet) {
+	for {
+		select {
+		case <-w.interrupt:
+			return
+		case <-time.After(w.PollInterval):
+			changed, deleted, err := w.Update()
+			if err != nil {
+				w.Log.Errorf("Error during watcher update: %v", err)
+				return
+			}
+
+			if len(changed) > 0 || len(deleted) > 0 {
 				select {
 				case <-w.interrupt:
 					return
-				case <-time.After(w.PollInterval):
-					changed, deleted, err := w.Update()
-					if err != nil {
-						w.Log.Errorf("Error during watcher update: %v", err)
-						return
-					}
-
-					if len(changed) > 0 || len(deleted) > 0 {
-						err = w.Callback(changed, deleted)
-						if err != nil {
-							w.Log.Errorf("Error during watcher callback: %v", err)
-							return
-						}
-					}
+				case changes <- &changeSet{changed: changed, deleted: deleted}:
 				}
 			}
-		}()
-	})
+		}
+	}
+}
+
+// watchEvents registers filesystem watches for the directories the paths can be located in
+func (w *Watcher) watchEvents() (chan notify.EventInfo, error) {
+	// High buffer size so we don't miss any events if there are a lot of changes
+	events := make(chan notify.EventInfo, 3000)
+	watched := map[string]bool{}
+
+	for _, pattern := range w.Paths {
+		dir, recursive := watchDir(pattern)
+		if recursive {
+			dir = filepath.Join(dir, "...")
+		}
+		if watched[dir] {
+			continue
+		}
+
+		err := notify.Watch(dir, events, notify.All)
+		if err != nil {
+			notify.Stop(events)
+			return nil, err
+		}
+
+		watched[dir] = true
+	}
+
+	return events, nil
+}
+
+// watchDir returns the closest existing directory that contains all files the pattern can match and if the
+// directory has to be watched recursively
+func watchDir(pattern string) (string, bool) {
+	pattern = filepath.Clean(pattern)
+
+	// Split off the part of the pattern that contains meta characters
+	dir, rest := pattern, ""
+	for strings.ContainsAny(dir, "*?[{") {
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = filepath.Dir(dir)
+	}
+
+	recursive := strings.Contains(rest, "**") || strings.ContainsRune(rest, filepath.Separator)
+	if rest == "" {
+		// Only the parent directory is needed to see a path being created, changed or deleted
+		dir = filepath.Dir(dir)
+	}
+
+	// Directories that do not exist yet are seen from the closest existing parent
+	for {
+		stat, err := os.Stat(dir)
+		if err == nil && stat.IsDir() {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir, recursive = parent, true
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return dir, recursive
+	}
+
+	return absDir, recursive
+}
+
+func (w *Watcher) handleEvents(events chan notify.EventInfo, changes chan<- *changeSet) {
+	defer notify.Stop(events)
+
+	workDir, _ := os.Getwd()
+	realWorkDir, err := filepath.EvalSymlinks(workDir)
+	if err != nil {
+		realWorkDir = workDir
+	}
+
+	for {
+		select {
+		case <-w.interrupt:
+			return
+		case event := <-events:
+			change := &changeSet{}
+
+			// Catch up with all events that happened in the meantime
+			for eventsLeft := true; eventsLeft; {
+				file := w.eventPath(event.Path(), workDir, realWorkDir)
+				if file != "" {
+					w.updateFile(file, change)
+				}
+
+				select {
+				case event = <-events:
+				default:
+					eventsLeft = false
+				}
+			}
+
+			if len(change.changed) > 0 || len(change.deleted) > 0 {
+				select {
+				case <-w.interrupt:
+					return
+				case changes <- change:
+				}
+			}
+		}
+	}
+}
+
+// eventPath returns the path of the event in the form the paths are globbed or an empty string if the event path
+// doesn't match any of the paths
+func (w *Watcher) eventPath(path, workDir, realWorkDir string) string {
+	relPath, err := filepath.Rel(realWorkDir, path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		relPath, err = filepath.Rel(workDir, path)
+		if err != nil {
+			relPath = path
+		}
+	}
+
+	for _, pattern := range w.Paths {
+		file := relPath
+		if filepath.IsAbs(pattern) {
+			file = path
+		}
+
+		matched, err := doublestar.PathMatch(filepath.Clean(pattern), file)
+		if err == nil && matched {
+			return file
+		}
+	}
+
+	return ""
+}
+
+// updateFile updates the filemap entry of a single file and adds the file to the change set if it has changed
+func (w *Watcher) updateFile(file string, change *changeSet) {
+	if strings.HasPrefix(file, ".devspace") {
+		return
+	}
+
+	oldFileInfo, existed := w.FileMap[file]
+	stat, err := os.Stat(file)
+	if err != nil {
+		if existed {
+			delete(w.FileMap, file)
+			change.deleted = append(change.deleted, file)
+		}
+
+		return
+	}
+
+	w.FileMap[file] = stat
+
+	// Changes within existing directories are only reported for the files that match the paths
+	if existed && oldFileInfo.IsDir() && stat.IsDir() {
+		return
+	}
+
+	change.changed = append(change.changed, file)
 }
 
 // Stop stopps the watcher
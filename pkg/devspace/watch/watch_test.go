@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -28,6 +30,14 @@ type testCaseChange struct {
 }
 
 func TestWatcher(t *testing.T) {
+	testWatcher(t, false)
+}
+
+func TestPollingWatcher(t *testing.T) {
+	testWatcher(t, true)
+}
+
+func testWatcher(t *testing.T, polling bool) {
 	watchedPaths := []string{".", "hello.txt", "watchedsubdir"}
 	testCases := []testCase{
 		{
@@ -141,6 +151,7 @@ func TestWatcher(t *testing.T) {
 	}
 
 	watcher.PollInterval = time.Millisecond * 10
+	watcher.Polling = polling
 	watcher.Start()
 
 	for _, testCase := range testCases {
@@ -184,6 +195,70 @@ func TestWatcher(t *testing.T) {
 	watcher.Stop()
 }
 
+func TestWatcherDebounce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	callbackChan := make(chan []string, 10)
+	watcher, err := New([]string{filepath.Join(dir, "**")}, func(changed []string, deleted []string) error {
+		callbackChan <- changed
+		return nil
+	}, log.GetInstance())
+	if err != nil {
+		t.Fatalf("Error creating watcher: %v", err)
+	}
+
+	watcher.Debounce = time.Millisecond * 300
+	watcher.BatchWindow = time.Second * 5
+	watcher.Start()
+	defer watcher.Stop()
+
+	// Changes that follow each other within the debounce time are passed to the callback together
+	for _, file := range []string{"a.txt", "b.txt", "c.txt"} {
+		err = fsutil.WriteToFile([]byte(file), filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Error creating file %s: %v", file, err)
+		}
+
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	select {
+	case changed := <-callbackChan:
+		sort.Strings(changed)
+		assert.DeepEqual(t, changed, []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"), filepath.Join(dir, "c.txt")})
+	case <-time.After(time.Second * 5):
+		t.Fatal("Watcher callback was not called")
+	}
+}
+
+func TestWatchDir(t *testing.T) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+
+	testCases := map[string]struct {
+		dir       string
+		recursive bool
+	}{
+		"**":                 {dir: workDir, recursive: true},
+		"./*.go":             {dir: workDir, recursive: false},
+		"watch.go":           {dir: workDir, recursive: false},
+		"../watch/**/*.go":   {dir: workDir, recursive: true},
+		"notexisting/a/*.go": {dir: workDir, recursive: true},
+	}
+
+	for pattern, expected := range testCases {
+		dir, recursive := watchDir(pattern)
+		assert.Equal(t, dir, expected.dir, "Unexpected directory for pattern %s", pattern)
+		assert.Equal(t, recursive, expected.recursive, "Unexpected recursive for pattern %s", pattern)
+	}
+}
+
 func indexOf(element string, data []string) int {
 	for k, v := range data {
 		if element == v {
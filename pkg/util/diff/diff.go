@@ -0,0 +1,123 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextLines is the number of unchanged lines that are shown around the changes
+const ContextLines = 3
+
+type line struct {
+	kind    byte
+	text    string
+	fromIdx int
+	toIdx   int
+}
+
+// Unified returns the line based difference between from and to in the unified diff format. An empty string is returned
+// if there is no difference
+func Unified(fromName, toName, from, to string) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	// Find the ranges of lines that are shown
+	hunks := [][2]int{}
+	for idx, l := range lines {
+		if l.kind == ' ' {
+			continue
+		}
+
+		start, end := idx-ContextLines, idx+ContextLines+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		if len(hunks) > 0 && hunks[len(hunks)-1][1] >= start {
+			hunks[len(hunks)-1][1] = end
+		} else {
+			hunks = append(hunks, [2]int{start, end})
+		}
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", fromName, toName)
+	for _, hunk := range hunks {
+		fromCount, toCount := 0, 0
+		for _, l := range lines[hunk[0]:hunk[1]] {
+			if l.kind != '+' {
+				fromCount++
+			}
+			if l.kind != '-' {
+				toCount++
+			}
+		}
+
+		first := lines[hunk[0]]
+		fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(first.fromIdx, fromCount), hunkRange(first.toIdx, toCount))
+		for _, l := range lines[hunk[0]:hunk[1]] {
+			fmt.Fprintf(out, "%c%s\n", l.kind, l.text)
+		}
+	}
+
+	return out.String()
+}
+
+func hunkRange(idx, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", idx)
+	}
+
+	return fmt.Sprintf("%d,%d", idx+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines returns the lines of both texts based on their longest common subsequence. Unchanged lines have the kind ' ',
+// removed lines '-' and added lines '+'
+func diffLines(from, to []string) []*line {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := []*line{}
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		if i < len(from) && j < len(to) && from[i] == to[j] {
+			lines = append(lines, &line{kind: ' ', text: from[i], fromIdx: i, toIdx: j})
+			i++
+			j++
+		} else if j >= len(to) || (i < len(from) && lcs[i+1][j] >= lcs[i][j+1]) {
+			lines = append(lines, &line{kind: '-', text: from[i], fromIdx: i, toIdx: j})
+			i++
+		} else {
+			lines = append(lines, &line{kind: '+', text: to[j], fromIdx: i, toIdx: j})
+			j++
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,31 @@
+package diff
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestUnified(t *testing.T) {
+	assert.Equal(t, Unified("a", "b", "same\n", "same\n"), "")
+
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	to := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n"
+	assert.Equal(t, Unified("devspace.yaml", "devspace.yaml (upgraded)", from, to), `--- devspace.yaml
++++ devspace.yaml (upgraded)
+@@ -2,9 +2,10 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+ 9
+ 10
++11
+`)
+
+	assert.Equal(t, Unified("a", "b", "", "new\n"), "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+new\n")
+}
@@ -1,4 +1,4 @@
-package dependency
+package graph
 
 import (
 	"fmt"
@@ -43,6 +43,11 @@ func NewNode(id string, data interface{}) *Node {
 	}
 }
 
+// IsLeaf returns true if the node has no children
+func (n *Node) IsLeaf() bool {
+	return len(n.childs) == 0
+}
+
 // InsertNodeAt inserts a new node at the given parent position
 func (g *Graph) InsertNodeAt(parentID string, id string, data interface{}) (*Node, error) {
 	parentNode, ok := g.Nodes[parentID]
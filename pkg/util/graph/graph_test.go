@@ -1,4 +1,4 @@
-package dependency
+package graph
 
 import (
 	"testing"
@@ -63,6 +63,9 @@ rootChild2Child1Child1`
 	if leaf.ID != rootChild1.ID {
 		t.Fatalf("GetLeaf1: Got id %s, expected %s", leaf.ID, rootChild1.ID)
 	}
+	if leaf.IsLeaf() == false || root.IsLeaf() {
+		t.Fatal("IsLeaf returned wrong result")
+	}
 
 	err = testGraph.AddEdge("NotThere", leaf.ID)
 	if err == nil {
@@ -25,7 +25,7 @@ func GetFileLogger(filename string) Logger {
 		newLogger := &fileLogger{
 			logger: logrus.New(),
 		}
-		newLogger.logger.Formatter = &logrus.JSONFormatter{}
+		newLogger.logger.Formatter = &maskingFormatter{formatter: &logrus.JSONFormatter{}}
 
 		os.MkdirAll(Logdir, os.ModePerm)
 
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// secretMask replaces secret values in log messages
+const secretMask = "********"
+
+// minSecretLength is the minimum length of secrets that are masked, shorter values would mask too much of the output
+const minSecretLength = 3
+
+// maxPendingLength is the maximum length of an incomplete line that is buffered before it is masked and written
+const maxPendingLength = 4096
+
+var secrets = []string{}
+var secretsMutex sync.RWMutex
+
+// AddSecret masks the value in all messages that are logged afterwards
+func AddSecret(value string) {
+	if len(value) < minSecretLength {
+		return
+	}
+
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	for _, secret := range secrets {
+		if secret == value {
+			return
+		}
+	}
+
+	secrets = append(secrets, value)
+}
+
+// MaskSecrets replaces all secret values in the message
+func MaskSecrets(message string) string {
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+
+	for _, secret := range secrets {
+		message = strings.Replace(message, secret, secretMask, -1)
+	}
+
+	return message
+}
+
+func hasSecrets() bool {
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+
+	return len(secrets) > 0
+}
+
+// lineMasker masks secrets in output that is written in chunks, e.g. the output of a command. Incomplete lines are
+// buffered, so that secrets that are split across two chunks are masked as well
+type lineMasker struct {
+	pending []byte
+}
+
+// Mask returns the buffered output and the complete lines of the message with all secrets masked. The incomplete
+// last line of the message is buffered until the next call of Mask or Flush
+func (l *lineMasker) Mask(message []byte) []byte {
+	if len(l.pending) == 0 && hasSecrets() == false {
+		return message
+	}
+
+	output := append(l.pending, message...)
+	l.pending = nil
+
+	end := bytes.LastIndexAny(output, "\r\n") + 1
+	if end < len(output) && len(output)-end <= maxPendingLength {
+		l.pending = append([]byte{}, output[end:]...)
+		output = output[:end]
+	}
+
+	return []byte(MaskSecrets(string(output)))
+}
+
+// Flush returns the buffered incomplete line with all secrets masked
+func (l *lineMasker) Flush() []byte {
+	output := l.pending
+	l.pending = nil
+
+	return []byte(MaskSecrets(string(output)))
+}
+
+// maskingFormatter masks secrets in the message of log entries before they are formatted
+type maskingFormatter struct {
+	formatter logrus.Formatter
+}
+
+// Format implements interface
+func (m *maskingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	entry.Message = MaskSecrets(entry.Message)
+	return m.formatter.Format(entry)
+}
@@ -23,6 +23,9 @@ type stdoutLogger struct {
 
 	loadingText *loadingText
 	fileLogger  Logger
+
+	// masker buffers incomplete lines written with Write until their secrets can be masked
+	masker lineMasker
 }
 
 type fnTypeInformation struct {
@@ -91,12 +94,13 @@ func (s *stdoutLogger) writeMessage(fnType logFunctionType, message string) {
 			s.loadingText.Stop()
 		}
 
+		s.flushPending()
 		fnInformation.stream.Write([]byte(ansi.Color(fnInformation.tag, fnInformation.color)))
 		// ct.Foreground(fnInformation.color, false)
 		// fnInformation.stream.Write([]byte(fnInformation.tag))
 		// ct.ResetColor()
 
-		fnInformation.stream.Write([]byte(message))
+		fnInformation.stream.Write([]byte(MaskSecrets(message)))
 
 		if s.loadingText != nil && fnType != fatalFn {
 			s.loadingText.Start()
@@ -104,6 +108,14 @@ func (s *stdoutLogger) writeMessage(fnType logFunctionType, message string) {
 	}
 }
 
+// flushPending writes the incomplete line that is buffered from the last Write
+func (s *stdoutLogger) flushPending() {
+	pending := s.masker.Flush()
+	if len(pending) > 0 {
+		fnTypeInformationMap[infoFn].stream.Write(pending)
+	}
+}
+
 func (s *stdoutLogger) writeMessageToFileLogger(fnType logFunctionType, args ...interface{}) {
 	fnInformation := fnTypeInformationMap[fnType]
 
@@ -168,6 +180,7 @@ func (s *stdoutLogger) StartWait(message string) {
 		s.loadingText = nil
 	}
 
+	s.flushPending()
 	s.loadingText = &loadingText{
 		Message: message,
 		Stream:  goansi.NewAnsiStdout(),
@@ -387,13 +400,16 @@ func (s *stdoutLogger) Write(message []byte) (int, error) {
 		s.loadingText.Stop()
 	}
 
-	n, err := fnTypeInformationMap[infoFn].stream.Write(message)
+	var err error
+	if masked := s.masker.Mask(message); len(masked) > 0 {
+		_, err = fnTypeInformationMap[infoFn].stream.Write(masked)
+	}
 
 	if s.loadingText != nil {
 		s.loadingText.Start()
 	}
 
-	return n, err
+	return len(message), err
 }
 
 func (s *stdoutLogger) WriteString(message string) {
@@ -404,7 +420,8 @@ func (s *stdoutLogger) WriteString(message string) {
 		s.loadingText.Stop()
 	}
 
-	fnTypeInformationMap[infoFn].stream.Write([]byte(message))
+	s.flushPending()
+	fnTypeInformationMap[infoFn].stream.Write([]byte(MaskSecrets(message)))
 
 	if s.loadingText != nil {
 		s.loadingText.Start()
@@ -75,7 +75,7 @@ func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 			panic(err)
 		}
 
-		_, err = s.stream.Write([]byte(message))
+		_, err = s.stream.Write([]byte(MaskSecrets(message)))
 		if err != nil {
 			panic(err)
 		}
@@ -285,7 +285,12 @@ func (s *StreamLogger) Write(message []byte) (int, error) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	return s.stream.Write(message)
+	_, err := s.stream.Write([]byte(MaskSecrets(string(message))))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(message), nil
 }
 
 // WriteString implements interface
@@ -293,7 +298,7 @@ func (s *StreamLogger) WriteString(message string) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	_, err := s.stream.Write([]byte(message))
+	_, err := s.stream.Write([]byte(MaskSecrets(message)))
 	if err != nil {
 		panic(err)
 	}
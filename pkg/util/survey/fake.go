@@ -0,0 +1,58 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FakeSurvey answers questions with preset answers and records the asked questions. It can be used with SetSurvey in
+// tests that drive prompts
+type FakeSurvey struct {
+	answers []string
+
+	// Questions holds all questions that were asked
+	Questions []*QuestionOptions
+}
+
+// NewFakeSurvey creates a new fake survey
+func NewFakeSurvey() *FakeSurvey {
+	return &FakeSurvey{
+		answers:   []string{},
+		Questions: []*QuestionOptions{},
+	}
+}
+
+// SetNextAnswer queues the answer for the next question
+func (f *FakeSurvey) SetNextAnswer(answer string) {
+	f.answers = append(f.answers, answer)
+}
+
+// Question implements interface
+func (f *FakeSurvey) Question(params *QuestionOptions) (string, error) {
+	f.Questions = append(f.Questions, params)
+	if len(f.answers) == 0 {
+		return "", fmt.Errorf("No answer set for question '%s'", strings.TrimSpace(params.Question))
+	}
+
+	answer := f.answers[0]
+	f.answers = f.answers[1:]
+
+	if len(params.Options) > 0 {
+		for _, option := range params.Options {
+			if option == answer {
+				return answer, nil
+			}
+		}
+
+		return "", fmt.Errorf("Answer %s is not an option of question '%s'", answer, strings.TrimSpace(params.Question))
+	}
+
+	if params.ValidationFunc != nil {
+		err := params.ValidationFunc(answer)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return answer, nil
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
+	"sync"
 
 	surveypkg "gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
 // QuestionOptions defines a question and its options
@@ -18,6 +21,40 @@ type QuestionOptions struct {
 	ValidationFunc         func(value string) error
 	Options                []string
 	IsPassword             bool
+
+	// Optional questions are answered with an empty string in non-interactive mode if there is no default value
+	Optional bool
+
+	// NonInteractiveHint tells the user how to answer the question in non-interactive mode, e.g. which flag to use
+	NonInteractiveHint string
+}
+
+// Survey asks the user questions. The implementation can be replaced with SetSurvey, e.g. to answer questions in tests
+type Survey interface {
+	Question(params *QuestionOptions) (string, error)
+}
+
+var currentSurvey Survey = &terminalSurvey{}
+
+// SetSurvey replaces the survey implementation that is used by Question and returns the previous one
+func SetSurvey(survey Survey) Survey {
+	previousSurvey := currentSurvey
+	currentSurvey = survey
+
+	return previousSurvey
+}
+
+var nonInteractive bool
+
+// SetNonInteractive enables or disables the non-interactive mode in which questions are answered with their default
+// value instead of asking the user
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// IsNonInteractive returns true if questions are not asked
+func IsNonInteractive() bool {
+	return nonInteractive
 }
 
 // DefaultValidationRegexPattern is the default regex pattern to validate the input
@@ -25,14 +62,66 @@ var DefaultValidationRegexPattern = regexp.MustCompile("^.*$")
 
 var nextAnswers []*string
 
+// questionMutex makes sure that only one question is asked at a time, e.g. by parallel deployments
+var questionMutex sync.Mutex
+
 // SetNextAnswer will set the next answer for the question function
 // THIS SHOULD BE ONLY USED FOR UNIT TESTS
 func SetNextAnswer(answer string) {
 	nextAnswers = append(nextAnswers, &answer)
 }
 
-// Question asks the user a question and returns the answer
-func Question(params *QuestionOptions) string {
+// Question asks the user a question and returns the answer. In non-interactive mode the default value is returned or
+// an error that tells the user how to answer the question if there is none
+func Question(params *QuestionOptions) (string, error) {
+	questionMutex.Lock()
+	defer questionMutex.Unlock()
+
+	if len(nextAnswers) != 0 {
+		answer := *nextAnswers[0]
+		nextAnswers = nextAnswers[1:]
+		return answer, nil
+	}
+
+	if nonInteractive {
+		return NonInteractiveAnswer(params)
+	}
+
+	answer, err := currentSurvey.Question(params)
+	if err != nil {
+		if err == terminal.InterruptErr {
+			// Keyboard interrupt
+			os.Exit(0)
+		}
+
+		return "", err
+	}
+
+	return answer, nil
+}
+
+// NonInteractiveAnswer returns the answer of the question in non-interactive mode or an error that tells the user how
+// to answer the question
+func NonInteractiveAnswer(params *QuestionOptions) (string, error) {
+	if params.DefaultValue != "" {
+		return params.DefaultValue, nil
+	} else if params.Optional {
+		return "", nil
+	}
+
+	hint := params.NonInteractiveHint
+	if hint == "" {
+		hint = "Please run the command in a terminal without --no-interactive"
+	}
+
+	return "", fmt.Errorf("Cannot ask '%s' in non-interactive mode. %s", strings.TrimSpace(params.Question), hint)
+}
+
+// terminalSurvey asks the questions in the terminal
+type terminalSurvey struct{}
+
+// Question implements interface
+func (t *terminalSurvey) Question(params *QuestionOptions) (string, error) {
 	var prompt surveypkg.Prompt
 	compiledRegex := DefaultValidationRegexPattern
 	if params.ValidationRegexPattern != "" {
@@ -100,17 +189,10 @@ func Question(params *QuestionOptions) string {
 		Question string
 	}{}
 
-	if len(nextAnswers) != 0 {
-		answer := *nextAnswers[0]
-		nextAnswers = nextAnswers[1:]
-		return answer
-	}
-
 	err := surveypkg.Ask(question, &answers)
 	if err != nil {
-		// Keyboard interrupt
-		os.Exit(0)
+		return "", err
 	}
 
-	return answers.Question
+	return answers.Question, nil
 }
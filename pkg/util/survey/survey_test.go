@@ -51,7 +51,53 @@ func TestSurvey(t *testing.T) {
 		}
 
 		for index, question := range test.questions{
-			assert.Equal(t, test.expectedAnswers[index], Question(question), "Wrong answer in testcase %s", test.name)
+			answer, err := Question(question)
+			assert.NilError(t, err, "Error in testcase %s", test.name)
+			assert.Equal(t, test.expectedAnswers[index], answer, "Wrong answer in testcase %s", test.name)
 		}
 	}
 }
+
+func TestNonInteractiveAnswer(t *testing.T) {
+	answer, err := NonInteractiveAnswer(&QuestionOptions{Question: "Hello", DefaultValue: "World"})
+	assert.NilError(t, err, "Error with default value")
+	assert.Equal(t, answer, "World")
+
+	answer, err = NonInteractiveAnswer(&QuestionOptions{Question: "Port? (Enter to skip)", Optional: true})
+	assert.NilError(t, err, "Error with optional question")
+	assert.Equal(t, answer, "")
+
+	_, err = NonInteractiveAnswer(&QuestionOptions{Question: "Select a pod", NonInteractiveHint: "Please specify the pod with --pod"})
+	assert.Error(t, err, "Cannot ask 'Select a pod' in non-interactive mode. Please specify the pod with --pod")
+
+	_, err = NonInteractiveAnswer(&QuestionOptions{Question: "Hello"})
+	assert.Error(t, err, "Cannot ask 'Hello' in non-interactive mode. Please run the command in a terminal without --no-interactive")
+
+	// Question returns the error instead of exiting
+	nonInteractiveBackup := IsNonInteractive()
+	defer SetNonInteractive(nonInteractiveBackup)
+	SetNonInteractive(true)
+
+	_, err = Question(&QuestionOptions{Question: "Select a pod", NonInteractiveHint: "Please specify the pod with --pod"})
+	assert.Error(t, err, "Cannot ask 'Select a pod' in non-interactive mode. Please specify the pod with --pod")
+}
+
+func TestFakeSurvey(t *testing.T) {
+	fakeSurvey := NewFakeSurvey()
+	previousSurvey := SetSurvey(fakeSurvey)
+	defer SetSurvey(previousSurvey)
+
+	fakeSurvey.SetNextAnswer("World")
+	answer, err := Question(&QuestionOptions{Question: "Hello"})
+	assert.NilError(t, err, "Error asking question")
+	assert.Equal(t, answer, "World")
+	assert.Equal(t, len(fakeSurvey.Questions), 1)
+	assert.Equal(t, fakeSurvey.Questions[0].Question, "Hello")
+
+	fakeSurvey.SetNextAnswer("other")
+	_, err = fakeSurvey.Question(&QuestionOptions{Question: "Select", Options: []string{"yes", "no"}})
+	assert.Error(t, err, "Answer other is not an option of question 'Select'")
+
+	_, err = fakeSurvey.Question(&QuestionOptions{Question: "Hello"})
+	assert.Error(t, err, "No answer set for question 'Hello'")
+}
@@ -35,3 +35,8 @@ func SetupTTY(stdin io.Reader, stdout io.Writer) term.TTY {
 
 	return t
 }
+
+// IsTerminalIn returns true if the given reader is a terminal
+func IsTerminalIn(in io.Reader) bool {
+	return term.TTY{In: in}.IsTerminalIn()
+}
@@ -1,6 +1,7 @@
 package vars
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 )
@@ -8,10 +9,13 @@ import (
 // VarMatchRegex is the regex to check if a value matches the devspace var format
 var VarMatchRegex = regexp.MustCompile("(\\$+\\{[^\\}]+\\})")
 
-// ReplaceVarFn defines the replace function
-type ReplaceVarFn func(value string) (string, error)
+// ReplaceVarFn defines the replace function. It returns the value of the variable and true if the value already has
+// the type of the variable. Values of variables without a type are strings
+type ReplaceVarFn func(value string) (interface{}, bool, error)
 
-// ParseString parses a given string, calls replace var on found variables and returns the replaced string
+// ParseString parses a given string, calls replace var on found variables and returns the replaced string. If the string
+// only consists of a single variable with a type, the value of the variable is returned as is, which can also be a list
+// or a map. Other values are converted to integers or booleans if possible, unless a variable with a type was used
 func ParseString(value string, replace ReplaceVarFn) (interface{}, error) {
 	matches := VarMatchRegex.FindAllStringIndex(value, -1)
 
@@ -20,21 +24,43 @@ func ParseString(value string, replace ReplaceVarFn) (interface{}, error) {
 		return value, nil
 	}
 
+	// The value is a single variable
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(value) && value[1] != '$' {
+		newValue, typed, err := replace(value[2 : len(value)-1])
+		if err != nil {
+			return "", err
+		} else if typed {
+			return newValue, nil
+		}
+
+		return convertString(fmt.Sprintf("%v", newValue)), nil
+	}
+
+	typed := false
 	newValue := value[:matches[0][0]]
 	for index, match := range matches {
 		var (
 			matchStr    = value[match[0]:match[1]]
 			newMatchStr string
-			err         error
 		)
 
 		if matchStr[0] == '$' && matchStr[1] == '$' {
 			newMatchStr = matchStr[1:]
 		} else {
-			newMatchStr, err = replace(matchStr[2 : len(matchStr)-1])
+			varName := matchStr[2 : len(matchStr)-1]
+
+			replaced, isTyped, err := replace(varName)
 			if err != nil {
 				return "", err
 			}
+
+			switch replaced.(type) {
+			case []interface{}, map[interface{}]interface{}:
+				return "", fmt.Errorf("Variable %s is a list or map and cannot be used within a string", varName)
+			}
+
+			newMatchStr = fmt.Sprintf("%v", replaced)
+			typed = typed || isTyped
 		}
 
 		newValue += newMatchStr
@@ -45,12 +71,21 @@ func ParseString(value string, replace ReplaceVarFn) (interface{}, error) {
 		}
 	}
 
-	// Try to convert new value to boolean or integer
-	if i, err := strconv.Atoi(newValue); err == nil {
-		return i, nil
-	} else if b, err := strconv.ParseBool(newValue); err == nil {
-		return b, nil
+	// Values of variables with a type are not converted, e.g. a version "1.0" stays a string
+	if typed {
+		return newValue, nil
+	}
+
+	return convertString(newValue), nil
+}
+
+// convertString tries to convert the value to a boolean or integer
+func convertString(value string) interface{} {
+	if i, err := strconv.Atoi(value); err == nil {
+		return i
+	} else if b, err := strconv.ParseBool(value); err == nil {
+		return b
 	}
 
-	return newValue, nil
+	return value
 }
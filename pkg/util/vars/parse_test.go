@@ -18,37 +18,57 @@ func TestParse(t *testing.T) {
 	testCases := map[string]*testCase{
 		"Single Replace": &testCase{
 			input:   " test abc ${Test} ",
-			replace: func(value string) (string, error) { return "test", nil },
+			replace: func(value string) (interface{}, bool, error) { return "test", false, nil },
 			output:  " test abc test ",
 		},
 		"Multiple Replace": &testCase{
 			input:   " test ${ABC}${Test} abc $${Test}${Test} ",
-			replace: func(value string) (string, error) { return "test", nil },
+			replace: func(value string) (interface{}, bool, error) { return "test", false, nil },
 			output:  " test testtest abc ${Test}test ",
 		},
 		"Multiple Replace 2": &testCase{
 			input:   "${Test}${Test}${Test}",
-			replace: func(value string) (string, error) { return value, nil },
+			replace: func(value string) (interface{}, bool, error) { return value, false, nil },
 			output:  "TestTestTest",
 		},
 		"Return integer": &testCase{
 			input:   "${integer}",
-			replace: func(value string) (string, error) { return "1", nil },
+			replace: func(value string) (interface{}, bool, error) { return "1", false, nil },
 			output:  1,
 		},
 		"Return bool": &testCase{
 			input:   "${bool}",
-			replace: func(value string) (string, error) { return "true", nil },
+			replace: func(value string) (interface{}, bool, error) { return "true", false, nil },
 			output:  true,
 		},
 		"Return error": &testCase{
 			input:   "${bool}",
-			replace: func(value string) (string, error) { return "", errors.New("Test Error") },
+			replace: func(value string) (interface{}, bool, error) { return "", false, errors.New("Test Error") },
 			err:     ptr.String("Test Error"),
 		},
+		"Typed string": &testCase{
+			input:   "${version}",
+			replace: func(value string) (interface{}, bool, error) { return "0123", true, nil },
+			output:  "0123",
+		},
+		"Typed integer": &testCase{
+			input:   "${replicas}",
+			replace: func(value string) (interface{}, bool, error) { return 3, true, nil },
+			output:  3,
+		},
+		"Typed value within string": &testCase{
+			input:   "${major}${minor}",
+			replace: func(value string) (interface{}, bool, error) { return "1", true, nil },
+			output:  "11",
+		},
+		"Typed list within string": &testCase{
+			input:   "hosts: ${hosts}",
+			replace: func(value string) (interface{}, bool, error) { return []interface{}{"a", "b"}, true, nil },
+			err:     ptr.String("Variable hosts is a list or map and cannot be used within a string"),
+		},
 		"No match": &testCase{
 			input:   "Test",
-			replace: func(value string) (string, error) { return "", errors.New("Test Error") },
+			replace: func(value string) (interface{}, bool, error) { return "", false, errors.New("Test Error") },
 			output:  "Test",
 		},
 	}
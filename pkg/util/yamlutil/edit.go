@@ -0,0 +1,800 @@
+package yamlutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrUnsupported is returned by the document if a change cannot be made without rewriting the parent value
+var ErrUnsupported = errors.New("change is not supported by the yaml editor")
+
+// Document is a yaml document that is changed line by line, so that the comments and the formatting of all values that are
+// not changed are kept. Only block mappings and block sequences can be changed partially, all other values, e.g. flow
+// values like [a, b], are replaced as a whole
+type Document struct {
+	lines           []string
+	trailingNewline bool
+}
+
+// ResolveFn returns the value a string of the document stands for, e.g. the value of a variable
+type ResolveFn func(value string) (interface{}, error)
+
+type nodeKind int
+
+const (
+	nullNode nodeKind = iota
+	scalarNode
+	mappingNode
+	sequenceNode
+)
+
+// node is a value of the document. The content of the value starts at line and col
+type node struct {
+	kind    nodeKind
+	line    int
+	col     int
+	end     int
+	entries []*entry
+}
+
+// entry is a key of a mapping or an item of a sequence. The key or the dash starts at line and col
+type entry struct {
+	key   string
+	line  int
+	col   int
+	end   int
+	value *node
+}
+
+// NewDocument creates a new document from the content of a yaml file
+func NewDocument(content []byte) *Document {
+	str := string(content)
+
+	return &Document{
+		lines:           strings.Split(strings.TrimSuffix(str, "\n"), "\n"),
+		trailingNewline: strings.HasSuffix(str, "\n"),
+	}
+}
+
+// Bytes returns the content of the document
+func (d *Document) Bytes() []byte {
+	out := strings.Join(d.lines, "\n")
+	if d.trailingNewline {
+		out += "\n"
+	}
+
+	return []byte(out)
+}
+
+// Update changes the value at path, so that it equals value. Only the parts that differ are rewritten and values that are
+// null are treated as missing. If resolve is not nil, the strings of the document are resolved before they are compared,
+// so that a string like ${VAR} is kept if the variable still has the same value
+func (d *Document) Update(path []interface{}, value interface{}, resolve ResolveFn) error {
+	current := yaml.MapSlice{}
+	err := yaml.Unmarshal(d.Bytes(), &current)
+	if err != nil {
+		return err
+	}
+
+	var old interface{} = current
+	for _, key := range path {
+		old, err = lookupValue(old, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resolve != nil {
+		old, err = resolveValue(old, resolve)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.update(path, old, pruneNull(value))
+}
+
+func (d *Document) update(path []interface{}, old, new interface{}) error {
+	if equal(old, new) {
+		return nil
+	}
+
+	oldMap, oldIsMap := old.(yaml.MapSlice)
+	newMap, newIsMap := new.(yaml.MapSlice)
+	oldList, oldIsList := old.([]interface{})
+	newList, newIsList := new.([]interface{})
+
+	var err error
+	if oldIsMap && newIsMap && len(oldMap) > 0 && len(newMap) > 0 && d.kindOf(path) == mappingNode {
+		err = d.updateMapping(path, oldMap, newMap)
+	} else if oldIsList && newIsList && len(oldList) > 0 && len(newList) > 0 && d.kindOf(path) == sequenceNode {
+		err = d.updateSequence(path, oldList, newList)
+	} else {
+		err = ErrUnsupported
+	}
+
+	// The value is rewritten as a whole if it cannot be changed partially
+	if err == ErrUnsupported {
+		return d.Set(path, new)
+	}
+
+	return err
+}
+
+func (d *Document) updateMapping(path []interface{}, old, new yaml.MapSlice) error {
+	for _, item := range old {
+		if _, ok := getKey(new, item.Key); ok == false && item.Value != nil {
+			err := d.Delete(appendPath(path, keyString(item.Key)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, item := range new {
+		itemPath := appendPath(path, keyString(item.Key))
+		if oldValue, ok := getKey(old, item.Key); ok {
+			err := d.update(itemPath, oldValue, item.Value)
+			if err != nil {
+				return err
+			}
+		} else if isEmpty(item.Value) == false {
+			err := d.Set(itemPath, item.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Document) updateSequence(path []interface{}, old, new []interface{}) error {
+	// Items that were removed or added at the same position are removed or inserted without changing the other items
+	start := 0
+	for start < len(old) && start < len(new) && equal(old[start], new[start]) {
+		start++
+	}
+
+	if len(new) < len(old) && equal(old[start+len(old)-len(new):], new[start:]) {
+		for i := 0; i < len(old)-len(new); i++ {
+			err := d.Delete(appendPath(path, start))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	} else if len(new) > len(old) && equal(old[start:], new[start+len(new)-len(old):]) {
+		for i := start; i < start+len(new)-len(old); i++ {
+			err := d.Insert(appendPath(path, i), new[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := 0; i < len(old) && i < len(new); i++ {
+		err := d.update(appendPath(path, i), old[i], new[i])
+		if err != nil {
+			return err
+		}
+	}
+	for i := len(old) - 1; i >= len(new); i-- {
+		err := d.Delete(appendPath(path, i))
+		if err != nil {
+			return err
+		}
+	}
+	for i := len(old); i < len(new); i++ {
+		err := d.Insert(appendPath(path, i), new[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Set replaces the value at path or adds it at the end of the parent mapping or sequence
+func (d *Document) Set(path []interface{}, value interface{}) error {
+	if len(path) == 0 {
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		*d = *NewDocument(out)
+		return nil
+	}
+
+	parent, e, err := d.lookup(path)
+	if err != nil {
+		return err
+	} else if e == nil {
+		return d.Insert(path, value)
+	}
+
+	// Scalars are replaced in place to keep the comment at the end of the line
+	if e.value.kind == scalarNode && e.value.end == e.value.line+1 {
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		scalar := strings.TrimSuffix(string(out), "\n")
+		if isScalar(value) && strings.Contains(scalar, "\n") == false {
+			line := d.lines[e.value.line]
+			d.lines[e.value.line] = line[:e.value.col] + scalar + line[valueEnd(line, e.value.col):]
+			return nil
+		}
+	}
+
+	lines, err := render(d.lines[e.line][:e.col], e.col, e.key, parent.kind == sequenceNode, value)
+	if err != nil {
+		return err
+	}
+
+	d.replaceLines(e.line, e.end, lines)
+	return nil
+}
+
+// Insert adds a value to the mapping or the sequence that contains path. Items of sequences are inserted before the item
+// that is currently at the index, keys are added at the end of the mapping
+func (d *Document) Insert(path []interface{}, value interface{}) error {
+	if len(path) == 0 {
+		return ErrUnsupported
+	}
+
+	parent, e, err := d.lookup(path)
+	if err != nil {
+		return err
+	}
+
+	switch parent.kind {
+	case mappingNode:
+		if e != nil {
+			return d.Set(path, value)
+		}
+
+		lines, err := render(strings.Repeat(" ", parent.col), parent.col, keyString(path[len(path)-1]), false, value)
+		if err != nil {
+			return err
+		}
+
+		d.replaceLines(parent.end, parent.end, lines)
+		return nil
+	case sequenceNode:
+		index := parent.end
+		if e != nil {
+			if isIndentation(d.lines[e.line][:e.col]) == false {
+				return ErrUnsupported
+			}
+
+			index = d.commentStart(e)
+		}
+
+		lines, err := render(strings.Repeat(" ", parent.col), parent.col, "", true, value)
+		if err != nil {
+			return err
+		}
+
+		d.replaceLines(index, index, lines)
+		return nil
+	}
+
+	return ErrUnsupported
+}
+
+// Delete removes the key or the item at path. Removing the last key of a mapping or the last item of a sequence is not
+// supported, because the parent value would have to be rewritten as {} or []
+func (d *Document) Delete(path []interface{}) error {
+	if len(path) == 0 {
+		return ErrUnsupported
+	}
+
+	parent, e, err := d.lookup(path)
+	if err != nil {
+		return err
+	} else if e == nil {
+		return nil
+	} else if len(parent.entries) == 1 {
+		return ErrUnsupported
+	}
+
+	prefix := d.lines[e.line][:e.col]
+	if isIndentation(prefix) == false {
+		// The first key of a mapping in a sequence item, e.g. - name: value, gives its dash to the next key
+		if parent.kind != mappingNode || parent.entries[0] != e {
+			return ErrUnsupported
+		}
+
+		next := parent.entries[1]
+		d.lines[next.line] = prefix + d.lines[next.line][next.col:]
+	}
+
+	d.replaceLines(d.commentStart(e), e.end, nil)
+	return nil
+}
+
+// commentStart returns the first line of the comments directly above the entry that have the same indentation as the entry
+func (d *Document) commentStart(e *entry) int {
+	start := e.line
+	for start > 0 && indentation(d.lines[start-1]) == e.col && strings.HasPrefix(strings.TrimSpace(d.lines[start-1]), "#") {
+		start--
+	}
+
+	return start
+}
+
+func (d *Document) kindOf(path []interface{}) nodeKind {
+	if len(path) == 0 {
+		root, err := d.parse()
+		if err != nil {
+			return nullNode
+		}
+
+		return root.kind
+	}
+
+	_, e, err := d.lookup(path)
+	if err != nil || e == nil {
+		return nullNode
+	}
+
+	return e.value.kind
+}
+
+// lookup returns the parent value of path and the entry at path, which is nil if it does not exist
+func (d *Document) lookup(path []interface{}) (*node, *entry, error) {
+	current, err := d.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for idx, key := range path {
+		var found *entry
+
+		switch key := key.(type) {
+		case string:
+			if current.kind != mappingNode {
+				return nil, nil, ErrUnsupported
+			}
+
+			for _, e := range current.entries {
+				if e.key == key {
+					found = e
+					break
+				}
+			}
+		case int:
+			if current.kind != sequenceNode {
+				return nil, nil, ErrUnsupported
+			}
+
+			if key < len(current.entries) {
+				found = current.entries[key]
+			} else if key > len(current.entries) {
+				return nil, nil, ErrUnsupported
+			}
+		default:
+			return nil, nil, errors.Errorf("Unsupported path element %v", key)
+		}
+
+		if idx == len(path)-1 {
+			return current, found, nil
+		} else if found == nil {
+			return nil, nil, ErrUnsupported
+		}
+
+		current = found.value
+	}
+
+	return nil, nil, ErrUnsupported
+}
+
+func (d *Document) replaceLines(start, end int, lines []string) {
+	newLines := make([]string, 0, len(d.lines)-(end-start)+len(lines))
+	newLines = append(newLines, d.lines[:start]...)
+	newLines = append(newLines, lines...)
+	d.lines = append(newLines, d.lines[end:]...)
+}
+
+// parse parses the structure of the document. Anchors, aliases, tags and multiple documents are not supported
+func (d *Document) parse() (*node, error) {
+	start := d.nextLine(0)
+	if start == len(d.lines) {
+		return &node{kind: nullNode}, nil
+	}
+
+	root, err := d.parseNode(start, indentation(d.lines[start]))
+	if err != nil {
+		return nil, err
+	} else if root.kind != mappingNode && root.kind != sequenceNode {
+		return nil, ErrUnsupported
+	} else if d.nextLine(root.end) != len(d.lines) {
+		return nil, errors.Errorf("Unexpected content in line %d", d.nextLine(root.end)+1)
+	}
+
+	return root, nil
+}
+
+func (d *Document) parseNode(line, col int) (*node, error) {
+	text := d.lines[line][col:]
+	if isSequenceItem(text) {
+		return d.parseSequence(line, col)
+	} else if _, _, ok := splitKey(text); ok {
+		return d.parseMapping(line, col)
+	}
+
+	return nil, errors.Errorf("Unsupported yaml in line %d", line+1)
+}
+
+func (d *Document) parseMapping(line, col int) (*node, error) {
+	n := &node{kind: mappingNode, line: line, col: col}
+
+	for i := line; i < len(d.lines); i = d.nextLine(i) {
+		if i != line && (indentation(d.lines[i]) != col || isSequenceItem(d.lines[i][col:])) {
+			break
+		}
+
+		key, valueCol, ok := splitKey(d.lines[i][col:])
+		if ok == false {
+			break
+		}
+
+		value, err := d.parseValue(i, col+valueCol, col, true)
+		if err != nil {
+			return nil, err
+		}
+
+		n.entries = append(n.entries, &entry{key: key, line: i, col: col, end: value.end, value: value})
+		n.end = value.end
+		i = value.end
+	}
+
+	return n, nil
+}
+
+func (d *Document) parseSequence(line, col int) (*node, error) {
+	n := &node{kind: sequenceNode, line: line, col: col}
+
+	for i := line; i < len(d.lines); i = d.nextLine(i) {
+		if i != line && (indentation(d.lines[i]) != col || isSequenceItem(d.lines[i][col:]) == false) {
+			break
+		}
+
+		value, err := d.parseValue(i, col+1, col, false)
+		if err != nil {
+			return nil, err
+		}
+
+		n.entries = append(n.entries, &entry{line: i, col: col, end: value.end, value: value})
+		n.end = value.end
+		i = value.end
+	}
+
+	return n, nil
+}
+
+// parseValue parses the value that follows a key or a dash. Values on the following lines have to be indented more than
+// the key or the dash, only sequences of a key can have the same indentation
+func (d *Document) parseValue(line, col, parentCol int, isKey bool) (*node, error) {
+	text := d.lines[line][col:]
+	col += len(text) - len(strings.TrimLeft(text, " "))
+	text = strings.TrimSpace(text)
+
+	if text == "" || strings.HasPrefix(text, "#") {
+		next := d.nextLine(line + 1)
+		if next < len(d.lines) {
+			indent := indentation(d.lines[next])
+			if indent > parentCol || (isKey && indent == parentCol && isSequenceItem(d.lines[next][indent:])) {
+				return d.parseNode(next, indent)
+			}
+		}
+
+		return &node{kind: nullNode, line: line, col: col, end: line + 1}, nil
+	}
+
+	switch text[0] {
+	case '&', '*', '!':
+		return nil, ErrUnsupported
+	case '|', '>':
+		// Block scalars end at the first line that is not indented more than the key, empty lines at the end are kept
+		end := line + 1
+		for i := line + 1; i < len(d.lines); i++ {
+			if strings.TrimSpace(d.lines[i]) == "" {
+				continue
+			} else if indentation(d.lines[i]) <= parentCol {
+				break
+			}
+
+			end = i + 1
+		}
+
+		return &node{kind: scalarNode, line: line, col: col, end: end}, nil
+	}
+
+	if isSequenceItem(text) {
+		return d.parseSequence(line, col)
+	} else if _, _, ok := splitKey(text); ok && isKey == false {
+		return d.parseMapping(line, col)
+	}
+
+	// Plain and flow scalars can be continued on the next lines
+	end := line + 1
+	for i := d.nextLine(line + 1); i < len(d.lines) && indentation(d.lines[i]) > parentCol; i = d.nextLine(i + 1) {
+		end = i + 1
+	}
+
+	return &node{kind: scalarNode, line: line, col: col, end: end}, nil
+}
+
+// nextLine returns the index of the first line at or after line that is not empty and not a comment
+func (d *Document) nextLine(line int) int {
+	for ; line < len(d.lines); line++ {
+		trimmed := strings.TrimSpace(d.lines[line])
+		if trimmed == "---" && line == 0 {
+			continue
+		} else if trimmed != "" && strings.HasPrefix(trimmed, "#") == false {
+			return line
+		}
+	}
+
+	return line
+}
+
+// render returns the lines of key: value or - value. The first line starts with prefix and the other lines are indented
+// by col
+func render(prefix string, col int, key string, isItem bool, value interface{}) ([]string, error) {
+	var v interface{} = yaml.MapSlice{{Key: key, Value: value}}
+	if isItem {
+		v = []interface{}{value}
+	}
+
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	for idx, line := range lines {
+		if idx == 0 {
+			lines[idx] = prefix + line
+		} else if line != "" {
+			lines[idx] = strings.Repeat(" ", col) + line
+		}
+	}
+
+	return lines, nil
+}
+
+// splitKey returns the key of a line like key: value and the column where the value starts
+func splitKey(text string) (string, int, bool) {
+	if text == "" || text[0] == '#' || text[0] == '[' || text[0] == '{' || text[0] == '?' {
+		return "", 0, false
+	}
+
+	start := 0
+	if text[0] == '"' || text[0] == '\'' {
+		closing := strings.IndexByte(text[1:], text[0])
+		if closing == -1 {
+			return "", 0, false
+		}
+
+		start = closing + 2
+	}
+
+	for i := start; i < len(text); i++ {
+		if text[i] == '#' && i > 0 && text[i-1] == ' ' {
+			return "", 0, false
+		} else if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			key := ""
+			err := yaml.Unmarshal([]byte(text[:i]), &key)
+			if err != nil {
+				return "", 0, false
+			}
+
+			return key, i + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// valueEnd returns the position after the scalar that starts at col, i.e. before the spaces and the comment after the value
+func valueEnd(line string, col int) int {
+	var quote byte
+	for i := col; i < len(line); i++ {
+		if quote != 0 {
+			if line[i] == quote {
+				quote = 0
+			}
+		} else if i == col && (line[i] == '"' || line[i] == '\'') {
+			quote = line[i]
+		} else if line[i] == '#' && i > col && line[i-1] == ' ' {
+			return col + len(strings.TrimRight(line[col:i], " "))
+		}
+	}
+
+	return col + len(strings.TrimRight(line[col:], " "))
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isIndentation(text string) bool {
+	return strings.TrimLeft(text, " ") == ""
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case yaml.MapSlice, []interface{}, map[interface{}]interface{}:
+		return false
+	}
+
+	return true
+}
+
+func isEmpty(value interface{}) bool {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+
+	return value == nil
+}
+
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	newPath := make([]interface{}, 0, len(path)+1)
+	return append(append(newPath, path...), key)
+}
+
+func keyString(key interface{}) string {
+	if str, ok := key.(string); ok {
+		return str
+	}
+
+	return fmt.Sprintf("%v", key)
+}
+
+func getKey(m yaml.MapSlice, key interface{}) (interface{}, bool) {
+	for _, item := range m {
+		if keyString(item.Key) == keyString(key) {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookupValue(value interface{}, key interface{}) (interface{}, error) {
+	switch key := key.(type) {
+	case string:
+		if m, ok := value.(yaml.MapSlice); ok {
+			if v, ok := getKey(m, key); ok {
+				return v, nil
+			}
+		}
+	case int:
+		if list, ok := value.([]interface{}); ok && key < len(list) {
+			return list[key], nil
+		}
+	}
+
+	return nil, errors.Errorf("Couldn't find %v", key)
+}
+
+// equal compares two values and ignores the order of the keys of mappings
+func equal(a, b interface{}) bool {
+	switch a := a.(type) {
+	case yaml.MapSlice:
+		b, ok := b.(yaml.MapSlice)
+		if ok == false || len(a) != len(b) {
+			return false
+		}
+
+		for _, item := range a {
+			value, ok := getKey(b, item.Key)
+			if ok == false || equal(item.Value, value) == false {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if ok == false || len(a) != len(b) {
+			return false
+		}
+
+		for idx := range a {
+			if equal(a[idx], b[idx]) == false {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// pruneNull converts all mappings to yaml.MapSlice and removes the keys with null values
+func pruneNull(value interface{}) interface{} {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		pruned := yaml.MapSlice{}
+		for _, item := range value {
+			if item.Value != nil {
+				pruned = append(pruned, yaml.MapItem{Key: item.Key, Value: pruneNull(item.Value)})
+			}
+		}
+
+		return pruned
+	case map[interface{}]interface{}:
+		pruned := yaml.MapSlice{}
+		for key, v := range value {
+			if v != nil {
+				pruned = append(pruned, yaml.MapItem{Key: key, Value: pruneNull(v)})
+			}
+		}
+
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			pruned = append(pruned, pruneNull(item))
+		}
+
+		return pruned
+	}
+
+	return value
+}
+
+func resolveValue(value interface{}, resolve ResolveFn) (interface{}, error) {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		resolved := make(yaml.MapSlice, 0, len(value))
+		for _, item := range value {
+			v, err := resolveValue(item.Value, resolve)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved = append(resolved, yaml.MapItem{Key: item.Key, Value: v})
+		}
+
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			v, err := resolveValue(item, resolve)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved = append(resolved, v)
+		}
+
+		return resolved, nil
+	case string:
+		return resolve(value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,157 @@
+package yamlutil
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const testDocument = `# My project
+version: v1beta3
+images:
+  default:
+    image: ${IMAGE} # the image of the app
+deployments:
+# The backend
+- name: backend
+  helm:
+    chart:
+      name: ./chart
+- name: frontend
+  component:
+    containers:
+    - image: nginx
+dev:
+  ports:
+  - labelSelector: {app: backend}
+    forward:
+    - port: 3000
+`
+
+type updateTestCase struct {
+	name     string
+	update   func(value yaml.MapSlice)
+	expected string
+}
+
+func TestUpdate(t *testing.T) {
+	resolve := func(value string) (interface{}, error) {
+		return strings.Replace(value, "${IMAGE}", "my-image", -1), nil
+	}
+
+	testCases := []updateTestCase{
+		{
+			name:     "Nothing changed",
+			update:   func(value yaml.MapSlice) {},
+			expected: testDocument,
+		},
+		{
+			name: "Change scalar",
+			update: func(value yaml.MapSlice) {
+				setValue(value, "other-image", "images", "default", "image")
+			},
+			expected: strings.Replace(testDocument, "image: ${IMAGE} #", "image: other-image #", 1),
+		},
+		{
+			name: "Prepend item",
+			update: func(value yaml.MapSlice) {
+				deployments := getValue(value, "deployments").([]interface{})
+				setValue(value, append([]interface{}{yaml.MapSlice{{Key: "name", Value: "db"}}}, deployments...), "deployments")
+			},
+			expected: strings.Replace(testDocument, "deployments:\n", "deployments:\n- name: db\n", 1),
+		},
+		{
+			name: "Remove item",
+			update: func(value yaml.MapSlice) {
+				deployments := getValue(value, "deployments").([]interface{})
+				setValue(value, deployments[1:], "deployments")
+			},
+			expected: strings.Replace(testDocument, "# The backend\n- name: backend\n  helm:\n    chart:\n      name: ./chart\n", "", 1),
+		},
+		{
+			name: "Remove first key of item",
+			update: func(value yaml.MapSlice) {
+				item := getValue(value, "deployments").([]interface{})[1].(yaml.MapSlice)
+				getValue(value, "deployments").([]interface{})[1] = item[1:]
+			},
+			expected: strings.Replace(testDocument, "- name: frontend\n  component:", "- component:", 1),
+		},
+		{
+			name: "Add key",
+			update: func(value yaml.MapSlice) {
+				setValue(value, append(getValue(value, "dev").(yaml.MapSlice), yaml.MapItem{Key: "sync", Value: []interface{}{yaml.MapSlice{{Key: "localSubPath", Value: "./src"}}}}), "dev")
+			},
+			expected: testDocument + "  sync:\n  - localSubPath: ./src\n",
+		},
+		{
+			name: "Replace flow value",
+			update: func(value yaml.MapSlice) {
+				port := getValue(value, "dev", "ports").([]interface{})[0].(yaml.MapSlice)
+				port[0].Value = yaml.MapSlice{{Key: "app", Value: "frontend"}}
+			},
+			expected: strings.Replace(testDocument, "  - labelSelector: {app: backend}\n", "  - labelSelector:\n      app: frontend\n", 1),
+		},
+	}
+
+	for _, testCase := range testCases {
+		value := yaml.MapSlice{}
+		err := yaml.Unmarshal([]byte(strings.Replace(testDocument, "${IMAGE}", "my-image", -1)), &value)
+		assert.NilError(t, err, "Error parsing document in testCase %s", testCase.name)
+		testCase.update(value)
+
+		document := NewDocument([]byte(testDocument))
+		err = document.Update(nil, value, resolve)
+		assert.NilError(t, err, "Error updating document in testCase %s", testCase.name)
+		assert.Equal(t, string(document.Bytes()), testCase.expected, "Unexpected document in testCase %s", testCase.name)
+	}
+}
+
+func TestUpdatePath(t *testing.T) {
+	document := NewDocument([]byte(`default:
+  config:
+    data:
+      # Inline config
+      version: v1beta3
+      images:
+        default:
+          image: nginx
+`))
+
+	err := document.Update([]interface{}{"default", "config", "data"}, map[interface{}]interface{}{
+		"version": "v1beta3",
+		"images":  nil,
+	}, nil)
+	assert.NilError(t, err, "Error updating document")
+	assert.Equal(t, string(document.Bytes()), `default:
+  config:
+    data:
+      # Inline config
+      version: v1beta3
+`)
+}
+
+func TestUnsupportedDocument(t *testing.T) {
+	document := NewDocument([]byte("base: &base\n  image: nginx\nother: *base\n"))
+
+	err := document.Set([]interface{}{"base", "image"}, "node")
+	assert.Assert(t, err != nil, "Anchors are not supported")
+}
+
+func getValue(value interface{}, path ...string) interface{} {
+	for _, key := range path {
+		value, _ = getKey(value.(yaml.MapSlice), key)
+	}
+
+	return value
+}
+
+func setValue(value yaml.MapSlice, newValue interface{}, path ...string) {
+	parent := getValue(value, path[:len(path)-1]...).(yaml.MapSlice)
+	for idx, item := range parent {
+		if item.Key == path[len(path)-1] {
+			parent[idx].Value = newValue
+		}
+	}
+}
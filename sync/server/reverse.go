@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/devspace-cloud/devspace/sync/util"
+	"github.com/pkg/errors"
+)
+
+// StartReverseForwardServer listens on the given port and forwards every accepted connection through the reader and writer
+// to the other side of the tunnel
+func StartReverseForwardServer(port int, reader io.Reader, writer io.Writer, exitOnClose bool) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return errors.Wrapf(err, "listen on port %d", port)
+	}
+	defer listener.Close()
+
+	tunnel := util.NewTunnel(reader, writer)
+	done := make(chan error, 2)
+
+	go func() {
+		done <- tunnel.Run()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				done <- err
+				return
+			}
+
+			err = tunnel.Forward(conn)
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	err = <-done
+	if exitOnClose {
+		// We kill ourself here because the streams are closed
+		fmt.Fprintf(os.Stderr, "Streams are closed")
+		os.Exit(1)
+	}
+
+	return err
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/sync/util"
+)
+
+func TestReverseForwardServer(t *testing.T) {
+	// The local server the connections are forwarded to
+	echoListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echoListener.Close()
+
+	go func() {
+		for {
+			conn, err := echoListener.Accept()
+			if err != nil {
+				return
+			}
+
+			go io.Copy(conn, conn)
+		}
+	}()
+
+	// Select a free port the reverse forward server listens on
+	portListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := portListener.Addr().(*net.TCPAddr).Port
+	portListener.Close()
+
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+
+	tunnel := util.NewTunnel(stdoutReader, stdinWriter)
+	tunnel.Dial = func() (net.Conn, error) {
+		return net.Dial("tcp", echoListener.Addr().String())
+	}
+	go tunnel.Run()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- StartReverseForwardServer(port, stdinReader, stdoutWriter, false)
+	}()
+
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		conn, err = net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+		if err == nil {
+			break
+		}
+
+		time.Sleep(time.Millisecond * 100)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, message := range []string{"hello\n", "world\n"} {
+		_, err = conn.Write([]byte(message))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != message {
+			t.Fatalf("Expected %q, got %q", message, line)
+		}
+	}
+
+	// Closing stdin stops the server
+	stdinWriter.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("Reverse forward server did not stop after stdin was closed")
+	}
+}
@@ -22,6 +22,7 @@ func (i *arrayFlags) Set(value string) error {
 
 func printUsage() {
 	fmt.Fprintf(os.Stderr, "Usage: sync [--version] [--upstream] [--downstream] [--exclude] PATH\n")
+	fmt.Fprintf(os.Stderr, "       sync --reverse-forward PORT\n")
 	os.Exit(1)
 }
 
@@ -34,6 +35,8 @@ func main() {
 		isDownstream = flag.Bool("downstream", false, "Starts the downstream service")
 		isUpstream   = flag.Bool("upstream", false, "Starts the upstream service")
 		showVersion  = flag.Bool("version", false, "Shows the version")
+
+		reverseForward = flag.Int("reverse-forward", 0, "Listens on the port and forwards the connections through stdin and stdout")
 	)
 
 	flag.Var(&excludePaths, "exclude", "The exclude paths for downstream watching")
@@ -49,6 +52,17 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Should we forward a port to the local computer?
+	if *reverseForward > 0 {
+		err := server.StartReverseForwardServer(*reverseForward, os.Stdin, os.Stdout, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	args := flag.Args()
 	if len(args) != 1 {
 		printUsage()
@@ -0,0 +1,186 @@
+package util
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+)
+
+// Frame types of the tunnel protocol. Each frame consists of the type (1 byte), the connection id (4 bytes),
+// the payload length (4 bytes) and the payload
+const (
+	frameOpen  byte = 1
+	frameData  byte = 2
+	frameClose byte = 3
+)
+
+const frameHeaderSize = 9
+
+// maxFramePayload is the max amount of bytes that are sent within one frame
+const maxFramePayload = 32 * 1024
+
+// Tunnel multiplexes tcp connections over a single stream, e.g. the stdin and stdout of a kubectl exec
+type Tunnel struct {
+	reader io.Reader
+	writer io.Writer
+
+	// Dial is called for every connection the other side of the tunnel forwards. If it is nil, these connections are closed
+	Dial func() (net.Conn, error)
+
+	writeMutex sync.Mutex
+	connsMutex sync.Mutex
+	conns      map[uint32]net.Conn
+	nextID     uint32
+}
+
+// NewTunnel creates a new tunnel that reads frames from reader and writes frames to writer
+func NewTunnel(reader io.Reader, writer io.Writer) *Tunnel {
+	return &Tunnel{
+		reader: reader,
+		writer: writer,
+		conns:  make(map[uint32]net.Conn),
+	}
+}
+
+// Forward forwards the connection to the other side of the tunnel, which calls its Dial function
+func (t *Tunnel) Forward(conn net.Conn) error {
+	t.connsMutex.Lock()
+	t.nextID++
+	id := t.nextID
+	t.conns[id] = conn
+	t.connsMutex.Unlock()
+
+	err := t.writeFrame(frameOpen, id, nil)
+	if err != nil {
+		t.remove(id)
+		return err
+	}
+
+	go t.copy(id, conn)
+	return nil
+}
+
+// Run reads frames until the stream is closed and closes all connections afterwards
+func (t *Tunnel) Run() error {
+	defer t.closeAll()
+
+	header := make([]byte, frameHeaderSize)
+	for {
+		_, err := io.ReadFull(t.reader, header)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		id := binary.BigEndian.Uint32(header[1:5])
+		payload := make([]byte, binary.BigEndian.Uint32(header[5:9]))
+		_, err = io.ReadFull(t.reader, payload)
+		if err != nil {
+			return err
+		}
+
+		switch header[0] {
+		case frameOpen:
+			t.open(id)
+		case frameData:
+			t.connsMutex.Lock()
+			conn := t.conns[id]
+			t.connsMutex.Unlock()
+
+			if conn != nil {
+				_, err = conn.Write(payload)
+				if err != nil {
+					t.remove(id)
+					t.writeFrame(frameClose, id, nil)
+				}
+			}
+		case frameClose:
+			t.remove(id)
+		}
+	}
+}
+
+// open dials a connection for a connection forwarded by the other side
+func (t *Tunnel) open(id uint32) {
+	if t.Dial == nil {
+		t.writeFrame(frameClose, id, nil)
+		return
+	}
+
+	conn, err := t.Dial()
+	if err != nil {
+		t.writeFrame(frameClose, id, nil)
+		return
+	}
+
+	t.connsMutex.Lock()
+	t.conns[id] = conn
+	t.connsMutex.Unlock()
+
+	go t.copy(id, conn)
+}
+
+// copy sends the data read from the connection through the tunnel until the connection is closed
+func (t *Tunnel) copy(id uint32, conn net.Conn) {
+	buf := make([]byte, maxFramePayload)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			writeErr := t.writeFrame(frameData, id, buf[:n])
+			if writeErr != nil {
+				t.remove(id)
+				return
+			}
+		}
+		if err != nil {
+			// Only tell the other side if the connection was not closed by it
+			if t.remove(id) {
+				t.writeFrame(frameClose, id, nil)
+			}
+
+			return
+		}
+	}
+}
+
+func (t *Tunnel) writeFrame(frameType byte, id uint32, payload []byte) error {
+	frame := make([]byte, frameHeaderSize+len(payload))
+	frame[0] = frameType
+	binary.BigEndian.PutUint32(frame[1:5], id)
+	binary.BigEndian.PutUint32(frame[5:9], uint32(len(payload)))
+	copy(frame[frameHeaderSize:], payload)
+
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+
+	_, err := t.writer.Write(frame)
+	return err
+}
+
+// remove closes the connection and returns if it was still open
+func (t *Tunnel) remove(id uint32) bool {
+	t.connsMutex.Lock()
+	conn, ok := t.conns[id]
+	delete(t.conns, id)
+	t.connsMutex.Unlock()
+
+	if ok {
+		conn.Close()
+	}
+
+	return ok
+}
+
+func (t *Tunnel) closeAll() {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	for id, conn := range t.conns {
+		conn.Close()
+		delete(t.conns, id)
+	}
+}